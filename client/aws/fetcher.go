@@ -0,0 +1,99 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/client"
+)
+
+// NewS3Fetcher creates a new S3Fetcher for the S3 bucket, using the provided
+// S3 client.
+//
+// bucket should not contain any slash.
+// c may be nil, in which case a new S3 client using the default AWS
+// configuration will be used.
+func NewS3Fetcher(ctx context.Context, bucket string, c *s3.Client) (*S3Fetcher, error) {
+	if c == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default AWS configuration: %v", err)
+		}
+		c = s3.NewFromConfig(cfg)
+	}
+	return &S3Fetcher{
+		bucket: bucket,
+		c:      c,
+	}, nil
+}
+
+// S3Fetcher knows how to fetch log artifacts from an S3 bucket.
+type S3Fetcher struct {
+	bucket string
+	c      *s3.Client
+}
+
+func (f S3Fetcher) fetch(ctx context.Context, p string) ([]byte, error) {
+	r, err := f.c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(f.bucket),
+		Key:    aws.String(p),
+	})
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			// Callers rely on os.ErrNotExist, e.g. to fall back from partial to full tiles.
+			return nil, fmt.Errorf("getObject: object %q not found in bucket %q: %w", p, f.bucket, os.ErrNotExist)
+		}
+		return nil, fmt.Errorf("getObject: failed to get object %q in bucket %q: %w", p, f.bucket, err)
+	}
+	defer func() {
+		if err := r.Body.Close(); err != nil {
+			slog.ErrorContext(ctx, "r.Body.Close()", slog.Any("error", err))
+		}
+	}()
+
+	return io.ReadAll(r.Body)
+}
+
+func (f S3Fetcher) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	return f.fetch(ctx, layout.CheckpointPath)
+}
+
+func (f S3Fetcher) ReadTile(ctx context.Context, l, i uint64, p uint8) ([]byte, error) {
+	return client.PartialOrFullResource(ctx, p, func(ctx context.Context, p uint8) ([]byte, error) {
+		return f.fetch(ctx, layout.TilePath(l, i, p))
+	})
+}
+
+func (f S3Fetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	return client.PartialOrFullResource(ctx, p, func(ctx context.Context, p uint8) ([]byte, error) {
+		return f.fetch(ctx, fmt.Sprintf("tile/data/%s", layout.NWithSuffix(0, i, p)))
+	})
+}
+
+func (f S3Fetcher) ReadIssuer(ctx context.Context, hash []byte) ([]byte, error) {
+	return f.fetch(ctx, fmt.Sprintf("issuer/%x", hash))
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+const testBucket = "bucket"
+
+func newTestClient(t *testing.T, objs map[string][]byte) *s3.Client {
+	t.Helper()
+
+	ts := httptest.NewServer(gofakes3.New(s3mem.New()).Server())
+	t.Cleanup(ts.Close)
+	cfg, err := config.LoadDefaultConfig(
+		t.Context(),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("ACCESS_KEY", "SECRET_KEY", "")),
+		config.WithRegion("us-east"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to set up SDK config: %v", err)
+	}
+	c := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+		o.BaseEndpoint = aws.String(ts.URL)
+	})
+	if _, err := c.CreateBucket(t.Context(), &s3.CreateBucketInput{Bucket: aws.String(testBucket)}); err != nil {
+		t.Fatalf("Failed to create bucket: %v", err)
+	}
+	for k, v := range objs {
+		if _, err := c.PutObject(t.Context(), &s3.PutObjectInput{
+			Bucket: aws.String(testBucket),
+			Key:    aws.String(k),
+			Body:   bytes.NewReader(v),
+		}); err != nil {
+			t.Fatalf("Failed to put object %q: %v", k, err)
+		}
+	}
+	return c
+}
+
+func TestS3Fetcher(t *testing.T) {
+	c := newTestClient(t, map[string][]byte{
+		layout.CheckpointPath:     []byte("checkpoint"),
+		layout.TilePath(0, 1, 0):  []byte("tile"),
+		"tile/data/001":           []byte("bundle"),
+		"issuer/0102030405060708": []byte("issuer"),
+		layout.TilePath(1, 0, 2):  []byte("partial tile"),
+	})
+	f, err := NewS3Fetcher(t.Context(), testBucket, c)
+	if err != nil {
+		t.Fatalf("NewS3Fetcher: %v", err)
+	}
+	for _, test := range []struct {
+		name     string
+		read     func(context.Context) ([]byte, error)
+		want     []byte
+		notExist bool
+	}{
+		{
+			name: "checkpoint",
+			read: f.ReadCheckpoint,
+			want: []byte("checkpoint"),
+		},
+		{
+			name: "tile",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadTile(ctx, 0, 1, 0) },
+			want: []byte("tile"),
+		},
+		{
+			name: "partial tile",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadTile(ctx, 1, 0, 2) },
+			want: []byte("partial tile"),
+		},
+		{
+			name: "entry bundle falls back to full bundle",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadEntryBundle(ctx, 1, 5) },
+			want: []byte("bundle"),
+		},
+		{
+			name: "issuer",
+			read: func(ctx context.Context) ([]byte, error) {
+				return f.ReadIssuer(ctx, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			want: []byte("issuer"),
+		},
+		{
+			name:     "missing issuer",
+			read:     func(ctx context.Context) ([]byte, error) { return f.ReadIssuer(ctx, []byte{1}) },
+			notExist: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.read(t.Context())
+			if test.notExist {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("got err %v, want os.ErrNotExist", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -12,10 +12,24 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package client provides client support for interacting with logs that
-// uses the [tlog-tiles API].
+// Package client provides client support for interacting with TesseraCT logs,
+// and other logs implementing the [static-ct-api].
 //
-// [tlog-tiles API]: https://c2sp.org/tlog-tiles
+// To read from a log, HTTPFetcher and FileFetcher fetch checkpoints, tiles,
+// entry bundles and issuers, from a log served over HTTP or stored on a
+// filesystem. On top of these:
+//   - LogStateTracker follows a log's checkpoints, checking that each new one
+//     is consistent with the previous one. WitnessedConsensus restricts it to
+//     checkpoints cosigned by witnesses according to a policy.
+//   - ProofBuilder builds inclusion and consistency proofs from tiles.
+//   - CheckConsistency checks that a set of checkpoints are consistent.
+//   - GetEntryBundle fetches entry bundles, and Entries iterates over entries,
+//     with their certificates and issuers decoded.
+//
+// To write to a log, Submitter submits chains to add-chain and add-pre-chain,
+// and verifies the SCTs the log returns.
+//
+// [static-ct-api]: https://c2sp.org/static-ct-api
 package client
 
 import (
@@ -32,7 +46,7 @@ import (
 	"github.com/transparency-dev/merkle/rfc6962"
 	"github.com/transparency-dev/tessera/api"
 	"github.com/transparency-dev/tessera/api/layout"
-	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/staticct"
 	"golang.org/x/mod/sumdb/note"
 )
 
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/transparency-dev/formats/log"
 	"github.com/transparency-dev/merkle/compact"
@@ -473,3 +474,20 @@ func TestNodeFetcherAddressing(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryAfter(t *testing.T) {
+	for _, tc := range []struct {
+		v    string
+		want time.Duration
+	}{
+		{v: "", want: time.Minute},
+		{v: "3", want: 3 * time.Second},
+		{v: "-3", want: time.Minute},
+		{v: "soon", want: time.Minute},
+		{v: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0},
+	} {
+		if got := retryAfter(tc.v, time.Minute); got != tc.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+}
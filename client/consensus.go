@@ -0,0 +1,214 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// WitnessPolicy decides whether a checkpoint carries enough witness
+// cosignatures to be trusted.
+//
+// tessera.WitnessGroup implements WitnessPolicy, see ParseWitnessPolicy.
+type WitnessPolicy interface {
+	// Satisfied returns whether the signed checkpoint cp satisfies the policy.
+	Satisfied(cp []byte) bool
+}
+
+// ParseWitnessPolicy parses a witness policy file, in the format described at
+// https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md, as
+// accepted by TesseraCT's --witness_policy_file flag.
+func ParseWitnessPolicy(policy []byte) (WitnessPolicy, error) {
+	wg, err := tessera.NewWitnessGroupFromPolicy(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse witness policy: %v", err)
+	}
+	return wg, nil
+}
+
+// ErrSplitView is returned when two checkpoints of the same size, but with
+// different root hashes, are both signed by the log.
+//
+// The raw checkpoints are included as returned by their sources, as evidence
+// of the log's misbehaviour.
+type ErrSplitView struct {
+	ARaw []byte
+	BRaw []byte
+}
+
+func (e ErrSplitView) Error() string {
+	return "log presented a split view: two checkpoints of the same size have different root hashes"
+}
+
+// WitnessedConsensus returns a ConsensusCheckpointFunc which only accepts
+// checkpoints whose witness cosignatures satisfy policy.
+//
+// Checkpoints are fetched from each of fs: typically the log itself if it
+// publishes cosigned checkpoints, and distributors of cosigned checkpoints.
+// Cosignatures on identical checkpoints from different sources are merged
+// before checking them against the policy.
+//
+// The returned function returns the largest checkpoint satisfying policy. It
+// fails if none does, or with ErrSplitView if two sources serve conflicting
+// checkpoints signed by the log. Sources which fail are skipped, as long as
+// another one returns a satisfying checkpoint.
+func WitnessedConsensus(policy WitnessPolicy, fs ...CheckpointFetcherFunc) ConsensusCheckpointFunc {
+	return func(ctx context.Context, logSigV note.Verifier, origin string) (*log.Checkpoint, []byte, *note.Note, error) {
+		if len(fs) == 0 {
+			return nil, nil, nil, errors.New("no checkpoint sources")
+		}
+		type candidate struct {
+			cp  *log.Checkpoint
+			raw []byte
+		}
+		// Candidates are keyed by the checkpoint body, which carries the
+		// size and root hash, so that equal checkpoints are merged.
+		byBody := make(map[string]*candidate)
+		bySize := make(map[uint64]*candidate)
+		var errs []error
+		for i, f := range fs {
+			raw, err := f(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("source %d: %v", i, err))
+				continue
+			}
+			cp, _, _, err := log.ParseCheckpoint(raw, origin, logSigV)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("source %d: failed to parse checkpoint: %v", i, err))
+				continue
+			}
+			if other, ok := bySize[cp.Size]; ok && !bytes.Equal(other.cp.Hash, cp.Hash) {
+				return nil, nil, nil, ErrSplitView{ARaw: other.raw, BRaw: raw}
+			}
+			body, sigs := splitNote(raw)
+			if c, ok := byBody[string(body)]; ok {
+				_, prevSigs := splitNote(c.raw)
+				c.raw = joinNote(body, mergeSigs(prevSigs, sigs))
+				continue
+			}
+			c := &candidate{cp: cp, raw: raw}
+			byBody[string(body)], bySize[cp.Size] = c, c
+		}
+
+		var best *candidate
+		for _, c := range byBody {
+			if !policy.Satisfied(c.raw) {
+				slog.DebugContext(ctx, "Checkpoint doesn't satisfy witness policy", slog.Uint64("size", c.cp.Size))
+				continue
+			}
+			if best == nil || c.cp.Size > best.cp.Size {
+				best = c
+			}
+		}
+		if best == nil {
+			return nil, nil, nil, fmt.Errorf("no checkpoint satisfies the witness policy: %w", errors.Join(errs...))
+		}
+		n, err := note.Open(best.raw, note.VerifierList(logSigV))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to open checkpoint: %v", err)
+		}
+		return best.cp, best.raw, n, nil
+	}
+}
+
+// Cosignature describes whether, and when, a witness cosigned a checkpoint.
+type Cosignature struct {
+	// Name is the name of the witness key.
+	Name string
+	// URL is the witness URL, as listed in the witness policy.
+	URL string
+	// Signed reports whether the checkpoint carries a valid cosignature from
+	// the witness.
+	Signed bool
+	// Timestamp is when the witness cosigned the checkpoint, if Signed.
+	Timestamp time.Time
+}
+
+// Cosignatures returns the status of the cosignature of each witness listed
+// in policy on the signed checkpoint cp, sorted by witness name.
+//
+// policy must list its witnesses, as those returned by ParseWitnessPolicy do.
+// Signatures which don't verify are treated as missing.
+func Cosignatures(policy WitnessPolicy, cp []byte) ([]Cosignature, error) {
+	wp, ok := policy.(interface {
+		Endpoints() map[string]note.Verifier
+	})
+	if !ok {
+		return nil, errors.New("witness policy doesn't list its witnesses")
+	}
+	var r []Cosignature
+	for url, v := range wp.Endpoints() {
+		c := Cosignature{Name: v.Name(), URL: url}
+		// Open fails unless at least one signature verifies, so the
+		// witness signature is the only one which can be in n.Sigs.
+		if n, err := note.Open(cp, note.VerifierList(v)); err == nil {
+			for _, s := range n.Sigs {
+				if s.Name != v.Name() || s.Hash != v.KeyHash() {
+					continue
+				}
+				c.Signed = true
+				// Cosignatures are laid out as a 4 byte key hash, an 8
+				// byte big-endian timestamp in seconds, and the signature.
+				if sig, err := base64.StdEncoding.DecodeString(s.Base64); err == nil && len(sig) >= 12 {
+					c.Timestamp = time.Unix(int64(binary.BigEndian.Uint64(sig[4:12])), 0)
+				}
+			}
+		}
+		r = append(r, c)
+	}
+	slices.SortFunc(r, func(a, b Cosignature) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.URL, b.URL))
+	})
+	return r, nil
+}
+
+// splitNote splits a signed note into its text, and its signature lines.
+func splitNote(raw []byte) ([]byte, [][]byte) {
+	i := bytes.LastIndex(raw, []byte("\n\n"))
+	if i < 0 {
+		return raw, nil
+	}
+	return raw[:i+1], bytes.SplitAfter(raw[i+2:], []byte("\n"))
+}
+
+// joinNote is the inverse of splitNote.
+func joinNote(text []byte, sigs [][]byte) []byte {
+	return append(append(bytes.Clone(text), '\n'), bytes.Join(sigs, nil)...)
+}
+
+// mergeSigs returns the signature lines in a, followed by those in b which
+// are not in a.
+func mergeSigs(a, b [][]byte) [][]byte {
+	r := slices.Clone(a)
+	for _, s := range b {
+		if len(s) > 0 && !slices.ContainsFunc(r, func(o []byte) bool { return bytes.Equal(o, s) }) {
+			r = append(r, s)
+		}
+	}
+	return r
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	f_note "github.com/transparency-dev/formats/note"
+	"github.com/transparency-dev/tesseract/client"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const testOrigin = "example.com/log"
+
+func mustGenerateKey(t *testing.T, name string) (string, string) {
+	t.Helper()
+	skey, vkey, err := note.GenerateKey(rand.Reader, name)
+	if err != nil {
+		t.Fatalf("GenerateKey(%q): %v", name, err)
+	}
+	return skey, vkey
+}
+
+func TestWitnessedConsensus(t *testing.T) {
+	logSKey, logVKey := mustGenerateKey(t, testOrigin)
+	logS, err := note.NewSigner(logSKey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	logV, err := note.NewVerifier(logVKey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	var witnesses []note.Signer
+	policy := ""
+	for _, name := range []string{"w1", "w2", "w3"} {
+		skey, vkey := mustGenerateKey(t, name)
+		s, err := f_note.NewSignerForCosignatureV1(skey)
+		if err != nil {
+			t.Fatalf("NewSignerForCosignatureV1(): %v", err)
+		}
+		witnesses = append(witnesses, s)
+		policy += fmt.Sprintf("witness %s %s https://%s.example.com\n", name, vkey, name)
+	}
+	policy += "group two 2 w1 w2 w3\nquorum two\n"
+	wp, err := client.ParseWitnessPolicy([]byte(policy))
+	if err != nil {
+		t.Fatalf("ParseWitnessPolicy(): %v", err)
+	}
+
+	// cp returns a checkpoint of size, signed by the log, and cosigned by
+	// the witnesses at indices ws.
+	cp := func(size uint64, hashSeed string, ws ...int) []byte {
+		h := sha256.Sum256([]byte(hashSeed))
+		text := log.Checkpoint{Origin: testOrigin, Size: size, Hash: h[:]}.Marshal()
+		signers := []note.Signer{logS}
+		for _, w := range ws {
+			signers = append(signers, witnesses[w])
+		}
+		raw, err := note.Sign(&note.Note{Text: string(text)}, signers...)
+		if err != nil {
+			t.Fatalf("Sign(): %v", err)
+		}
+		return raw
+	}
+	source := func(raw []byte) client.CheckpointFetcherFunc {
+		return func(context.Context) ([]byte, error) { return raw, nil }
+	}
+	failing := func(context.Context) ([]byte, error) { return nil, errors.New("unavailable") }
+
+	for _, tc := range []struct {
+		name          string
+		sources       []client.CheckpointFetcherFunc
+		wantSize      uint64
+		wantErr       bool
+		wantSplitView bool
+	}{
+		{
+			name:     "single source",
+			sources:  []client.CheckpointFetcherFunc{source(cp(10, "a", 0, 1))},
+			wantSize: 10,
+		},
+		{
+			name:     "cosignatures merged across sources",
+			sources:  []client.CheckpointFetcherFunc{source(cp(10, "a", 0)), source(cp(10, "a", 2))},
+			wantSize: 10,
+		},
+		{
+			name:     "largest satisfying checkpoint",
+			sources:  []client.CheckpointFetcherFunc{source(cp(5, "b", 1, 2)), source(cp(10, "a", 0, 1)), source(cp(20, "c", 0))},
+			wantSize: 10,
+		},
+		{
+			name:     "failing source skipped",
+			sources:  []client.CheckpointFetcherFunc{failing, source(cp(10, "a", 0, 2))},
+			wantSize: 10,
+		},
+		{
+			name:    "unsigned by log",
+			sources: []client.CheckpointFetcherFunc{source([]byte("not a checkpoint"))},
+			wantErr: true,
+		},
+		{
+			name:    "quorum not reached",
+			sources: []client.CheckpointFetcherFunc{source(cp(10, "a", 0)), source(cp(10, "a", 0))},
+			wantErr: true,
+		},
+		{
+			name:    "no sources",
+			wantErr: true,
+		},
+		{
+			name:          "split view",
+			sources:       []client.CheckpointFetcherFunc{source(cp(10, "a", 0, 1)), source(cp(10, "b", 1, 2))},
+			wantErr:       true,
+			wantSplitView: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, raw, n, err := client.WitnessedConsensus(wp, tc.sources...)(t.Context(), logV, testOrigin)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("WitnessedConsensus(): got error %v, want error %t", err, tc.wantErr)
+			}
+			var splitView client.ErrSplitView
+			if errors.As(err, &splitView) != tc.wantSplitView {
+				t.Errorf("WitnessedConsensus(): got error %v, want split view %t", err, tc.wantSplitView)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.Size != tc.wantSize {
+				t.Errorf("got checkpoint of size %d, want %d", got.Size, tc.wantSize)
+			}
+			if !wp.Satisfied(raw) {
+				t.Errorf("returned checkpoint doesn't satisfy the policy:\n%s", raw)
+			}
+			if n == nil || len(n.Sigs) != 1 || n.Sigs[0].Name != testOrigin {
+				t.Errorf("got note %+v, want a note with the log's signature", n)
+			}
+		})
+	}
+}
+
+func TestCosignatures(t *testing.T) {
+	logSKey, _ := mustGenerateKey(t, testOrigin)
+	logS, err := note.NewSigner(logSKey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	signers := []note.Signer{logS}
+	policy := ""
+	for _, name := range []string{"w1", "w2", "w3"} {
+		skey, vkey := mustGenerateKey(t, name)
+		policy += fmt.Sprintf("witness %s %s https://%s.example.com\n", name, vkey, name)
+		if name == "w2" {
+			continue
+		}
+		s, err := f_note.NewSignerForCosignatureV1(skey)
+		if err != nil {
+			t.Fatalf("NewSignerForCosignatureV1(): %v", err)
+		}
+		signers = append(signers, s)
+	}
+	policy += "group all 3 w1 w2 w3\nquorum all\n"
+	wp, err := client.ParseWitnessPolicy([]byte(policy))
+	if err != nil {
+		t.Fatalf("ParseWitnessPolicy(): %v", err)
+	}
+	h := sha256.Sum256([]byte("a"))
+	text := log.Checkpoint{Origin: testOrigin, Size: 10, Hash: h[:]}.Marshal()
+	before := time.Now().Truncate(time.Second)
+	cp, err := note.Sign(&note.Note{Text: string(text)}, signers...)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+
+	got, err := client.Cosignatures(wp, cp)
+	if err != nil {
+		t.Fatalf("Cosignatures(): %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Cosignatures(): got %d witnesses, want 3", len(got))
+	}
+	for i, want := range []struct {
+		name   string
+		signed bool
+	}{{"w1", true}, {"w2", false}, {"w3", true}} {
+		c := got[i]
+		if c.Name != want.name || c.URL != fmt.Sprintf("https://%s.example.com", want.name) || c.Signed != want.signed {
+			t.Errorf("Cosignatures()[%d]: got %+v, want %s signed=%t", i, c, want.name, want.signed)
+		}
+		if c.Signed && (c.Timestamp.Before(before) || c.Timestamp.After(time.Now())) {
+			t.Errorf("Cosignatures()[%d]: got timestamp %v, want around %v", i, c.Timestamp, before)
+		}
+		if !c.Signed && !c.Timestamp.IsZero() {
+			t.Errorf("Cosignatures()[%d]: got timestamp %v for a missing cosignature", i, c.Timestamp)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"iter"
+
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/staticct"
+)
+
+// IssuerFetcherFunc is the signature of a function which can fetch an issuer
+// certificate given the SHA-256 hash of its DER encoding.
+type IssuerFetcherFunc func(ctx context.Context, hash []byte) ([]byte, error)
+
+// Entry is a log entry, with its certificates decoded.
+type Entry struct {
+	staticct.Entry
+	// Index is the index of the entry in the log.
+	Index uint64
+	// Cert is the certificate logged by the entry, or the precertificate for
+	// precertificate entries.
+	Cert *x509.Certificate
+	// Issuers is the chain of Cert, from its issuer to the root, resolved
+	// from the entry's FingerprintsChain.
+	Issuers []*x509.Certificate
+}
+
+// Entries returns an iterator over the log entries in [start, end), in order.
+//
+// logSize is the size of a checkpoint of the log, and end must not exceed it.
+// Entry bundles are fetched with bF, and issuers with iF, which are only
+// fetched once per call to Entries.
+//
+// The iterator yields an error along with the entry when the entry can't be
+// decoded, and carries on. It stops after yielding an error when bundles or
+// issuers can't be fetched.
+func Entries(ctx context.Context, bF EntryBundleFetcherFunc, iF IssuerFetcherFunc, start, end, logSize uint64) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		if start > end || end > logSize {
+			yield(nil, fmt.Errorf("invalid range [%d, %d) for log size %d", start, end, logSize))
+			return
+		}
+		issuers := make(map[[32]byte]*x509.Certificate)
+		for idx := start; idx < end; {
+			bIdx := idx / layout.EntryBundleWidth
+			bundle, err := GetEntryBundle(ctx, bF, bIdx, logSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			bStart := bIdx * layout.EntryBundleWidth
+			for ; idx < end && idx < bStart+layout.EntryBundleWidth; idx++ {
+				if idx-bStart >= uint64(len(bundle.Entries)) {
+					yield(nil, fmt.Errorf("entry bundle %d has %d entries, want entry %d", bIdx, len(bundle.Entries), idx))
+					return
+				}
+				e := &Entry{Index: idx}
+				if err := e.Entry.UnmarshalText(bundle.Entries[idx-bStart]); err != nil {
+					if !yield(e, fmt.Errorf("failed to parse entry %d: %v", idx, err)) {
+						return
+					}
+					continue
+				}
+				fatal, err := e.decode(ctx, iF, issuers)
+				if err != nil {
+					err = fmt.Errorf("failed to decode entry %d: %v", idx, err)
+				}
+				if !yield(e, err) || fatal {
+					return
+				}
+			}
+		}
+	}
+}
+
+// decode decodes e's certificate, and resolves its issuers with iF, caching
+// them in issuers.
+//
+// It returns whether an error is fatal, i.e. issuers couldn't be fetched.
+func (e *Entry) decode(ctx context.Context, iF IssuerFetcherFunc, issuers map[[32]byte]*x509.Certificate) (bool, error) {
+	der := e.Entry.Certificate
+	if e.IsPrecert {
+		der = e.Precertificate
+	}
+	var err error
+	if e.Cert, err = x509.ParseCertificate(der); err != nil {
+		return false, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	for _, fp := range e.FingerprintsChain {
+		issuer, ok := issuers[fp]
+		if !ok {
+			raw, err := iF(ctx, fp[:])
+			if err != nil {
+				return true, fmt.Errorf("failed to fetch issuer %x: %v", fp, err)
+			}
+			if got := sha256.Sum256(raw); got != fp {
+				return true, fmt.Errorf("issuer %x has hash %x", fp, got)
+			}
+			if issuer, err = x509.ParseCertificate(raw); err != nil {
+				return false, fmt.Errorf("failed to parse issuer %x: %v", fp, err)
+			}
+			issuers[fp] = issuer
+		}
+		e.Issuers = append(e.Issuers, issuer)
+	}
+	return false, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/tesseracttest"
+)
+
+func TestEntries(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	chains := [][]*x509.Certificate{l.IssueChain(t), l.IssuePrecertChain(t), l.IssueChain(t)}
+	for _, c := range chains {
+		l.MustSubmit(t, c)
+	}
+	size := l.Integrate(t).Size
+	f, err := client.NewHTTPFetcher(mustParseURL(t, l.URL()), http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewHTTPFetcher(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		start, end uint64
+	}{
+		{name: "all", start: 0, end: size},
+		{name: "range", start: 1, end: 3},
+		{name: "empty", start: 2, end: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := tc.start
+			for e, err := range client.Entries(t.Context(), f.ReadEntryBundle, f.ReadIssuer, tc.start, tc.end, size) {
+				if err != nil {
+					t.Fatalf("Entries(): %v", err)
+				}
+				if e.Index != want || e.LeafIndex != want {
+					t.Errorf("got entry with index %d and leaf index %d, want %d", e.Index, e.LeafIndex, want)
+				}
+				chain := chains[want]
+				if !bytes.Equal(e.Cert.Raw, chain[0].Raw) {
+					t.Errorf("entry %d: got certificate %q, want %q", want, e.Cert.Subject, chain[0].Subject)
+				}
+				if e.IsPrecert != (want == 1) {
+					t.Errorf("entry %d: IsPrecert = %t", want, e.IsPrecert)
+				}
+				if len(e.Issuers) != len(chain)-1 {
+					t.Fatalf("entry %d: got %d issuers, want %d", want, len(e.Issuers), len(chain)-1)
+				}
+				for i, iss := range e.Issuers {
+					if !bytes.Equal(iss.Raw, chain[i+1].Raw) {
+						t.Errorf("entry %d: issuer %d is %q, want %q", want, i, iss.Subject, chain[i+1].Subject)
+					}
+				}
+				want++
+			}
+			if want != tc.end {
+				t.Errorf("Entries() stopped before entry %d, want %d", want, tc.end)
+			}
+		})
+	}
+}
+
+func TestEntriesErrors(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	l.MustSubmit(t, l.IssueChain(t))
+	l.MustSubmit(t, l.IssueChain(t))
+	size := l.Integrate(t).Size
+	f, err := client.NewHTTPFetcher(mustParseURL(t, l.URL()), http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewHTTPFetcher(): %v", err)
+	}
+	errIssuer := errors.New("no issuer")
+
+	for _, tc := range []struct {
+		name       string
+		iF         client.IssuerFetcherFunc
+		start, end uint64
+		wantErrs   int
+	}{
+		{
+			name:     "end beyond log size",
+			iF:       f.ReadIssuer,
+			end:      size + 1,
+			wantErrs: 1,
+		},
+		{
+			name: "issuer not found",
+			iF: func(context.Context, []byte) ([]byte, error) {
+				return nil, errIssuer
+			},
+			end:      size,
+			wantErrs: 1,
+		},
+		{
+			name: "wrong issuer",
+			iF: func(ctx context.Context, hash []byte) ([]byte, error) {
+				return l.Intermediate.Raw, nil
+			},
+			end:      size,
+			wantErrs: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var errs int
+			for _, err := range client.Entries(t.Context(), f.ReadEntryBundle, tc.iF, tc.start, tc.end, size) {
+				if err != nil {
+					errs++
+				}
+			}
+			if errs != tc.wantErrs {
+				t.Errorf("Entries() yielded %d errors, want %d", errs, tc.wantErrs)
+			}
+		})
+	}
+}
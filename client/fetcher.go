@@ -31,6 +31,7 @@ import (
 
 	"github.com/cenkalti/backoff/v5"
 	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
 )
 
 // NewHTTPFetcher creates a new HTTPFetcher for the log rooted at the given URL, using
@@ -75,7 +76,7 @@ func (h *HTTPFetcher) SetUserAgent(ua string) {
 
 // EnableRetries causes requests which result in a non-permanent error to be retried with up to maxRetries attempts.
 func (h *HTTPFetcher) EnableRetries(maxRetries uint) {
-	h.backOff = []backoff.RetryOption{backoff.WithBackOff(backoff.NewExponentialBackOff()), backoff.WithMaxTries(10)}
+	h.backOff = []backoff.RetryOption{backoff.WithBackOff(backoff.NewExponentialBackOff()), backoff.WithMaxTries(maxRetries)}
 }
 
 func (h HTTPFetcher) fetch(ctx context.Context, p string) ([]byte, error) {
@@ -151,6 +152,12 @@ func (h HTTPFetcher) ReadIssuer(ctx context.Context, hash []byte) ([]byte, error
 	return h.fetch(ctx, ctIssuerPath(hash))
 }
 
+// ReadArchivedCheckpoint returns the checkpoint of the given size from the
+// log's checkpoint archive, if the log has one.
+func (h HTTPFetcher) ReadArchivedCheckpoint(ctx context.Context, size uint64) ([]byte, error) {
+	return h.fetch(ctx, ctCheckpointArchivePath(size))
+}
+
 // FileFetcher knows how to fetch log artifacts from a filesystem rooted at Root.
 type FileFetcher struct {
 	Root              string
@@ -198,6 +205,12 @@ func (f FileFetcher) ReadIssuer(ctx context.Context, hash []byte) ([]byte, error
 	return os.ReadFile(path.Join(f.Root, ctIssuerPath(hash)))
 }
 
+// ReadArchivedCheckpoint returns the checkpoint of the given size from the
+// log's checkpoint archive, if the log has one.
+func (f FileFetcher) ReadArchivedCheckpoint(_ context.Context, size uint64) ([]byte, error) {
+	return os.ReadFile(path.Join(f.Root, ctCheckpointArchivePath(size)))
+}
+
 func ctEntriesPath(n uint64, p uint8) string {
 	return fmt.Sprintf("tile/data/%s", layout.NWithSuffix(0, n, p))
 }
@@ -205,3 +218,7 @@ func ctEntriesPath(n uint64, p uint8) string {
 func ctIssuerPath(hash []byte) string {
 	return fmt.Sprintf("issuer/%s", hex.EncodeToString(hash))
 }
+
+func ctCheckpointArchivePath(size uint64) string {
+	return fmt.Sprintf("%s%d", staticct.CheckpointsPrefix, size)
+}
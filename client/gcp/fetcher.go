@@ -16,13 +16,15 @@ package gcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
 
 	gcs "cloud.google.com/go/storage"
 	"github.com/transparency-dev/tessera/api/layout"
-	"github.com/transparency-dev/tesseract/internal/client"
+	"github.com/transparency-dev/tesseract/client"
 )
 
 // NewGSFetcher creates a new GSFetcher for the Google Cloud Storage bucket, using
@@ -52,6 +54,10 @@ type GSFetcher struct {
 
 func (f GSFetcher) fetch(ctx context.Context, p string) ([]byte, error) {
 	r, err := f.c.Bucket(f.bucket).Object(p).NewReader(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		// Callers rely on os.ErrNotExist, e.g. to fall back from partial to full tiles.
+		return nil, fmt.Errorf("getObject: object %q not found in bucket %q: %w", p, f.bucket, os.ErrNotExist)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("getObject: failed to create reader for object %q in bucket %q: %w", p, f.bucket, err)
 	}
@@ -79,3 +85,7 @@ func (f GSFetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]by
 		return f.fetch(ctx, fmt.Sprintf("tile/data/%s", layout.NWithSuffix(0, i, p)))
 	})
 }
+
+func (f GSFetcher) ReadIssuer(ctx context.Context, hash []byte) ([]byte, error) {
+	return f.fetch(ctx, fmt.Sprintf("issuer/%x", hash))
+}
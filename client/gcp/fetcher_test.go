@@ -0,0 +1,113 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+const testBucket = "bucket"
+
+func TestGSFetcher(t *testing.T) {
+	objs := map[string][]byte{
+		layout.CheckpointPath:     []byte("checkpoint"),
+		layout.TilePath(0, 1, 0):  []byte("tile"),
+		"tile/data/001":           []byte("bundle"),
+		"issuer/0102030405060708": []byte("issuer"),
+		layout.TilePath(1, 0, 2):  []byte("partial tile"),
+	}
+	initial := []fakestorage.Object{}
+	for name, content := range objs {
+		initial = append(initial, fakestorage.Object{
+			ObjectAttrs: fakestorage.ObjectAttrs{BucketName: testBucket, Name: name},
+			Content:     content,
+		})
+	}
+	srv, err := fakestorage.NewServerWithOptions(fakestorage.Options{
+		InitialObjects: initial,
+		NoListener:     true,
+	})
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	defer srv.Stop()
+
+	f, err := NewGSFetcher(t.Context(), testBucket, srv.Client())
+	if err != nil {
+		t.Fatalf("NewGSFetcher: %v", err)
+	}
+	for _, test := range []struct {
+		name     string
+		read     func(context.Context) ([]byte, error)
+		want     []byte
+		notExist bool
+	}{
+		{
+			name: "checkpoint",
+			read: f.ReadCheckpoint,
+			want: []byte("checkpoint"),
+		},
+		{
+			name: "tile",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadTile(ctx, 0, 1, 0) },
+			want: []byte("tile"),
+		},
+		{
+			name: "partial tile",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadTile(ctx, 1, 0, 2) },
+			want: []byte("partial tile"),
+		},
+		{
+			name: "entry bundle falls back to full bundle",
+			read: func(ctx context.Context) ([]byte, error) { return f.ReadEntryBundle(ctx, 1, 5) },
+			want: []byte("bundle"),
+		},
+		{
+			name: "issuer",
+			read: func(ctx context.Context) ([]byte, error) {
+				return f.ReadIssuer(ctx, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			want: []byte("issuer"),
+		},
+		{
+			name:     "missing issuer",
+			read:     func(ctx context.Context) ([]byte, error) { return f.ReadIssuer(ctx, []byte{1}) },
+			notExist: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.read(t.Context())
+			if test.notExist {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("got err %v, want os.ErrNotExist", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
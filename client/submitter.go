@@ -0,0 +1,303 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a single submission attempt.
+	DefaultTimeout = 30 * time.Second
+
+	// defaultRetryAfter is how long to wait before retrying when a log
+	// pushes back without a Retry-After header.
+	defaultRetryAfter = 10 * time.Second
+)
+
+// SCT is a Signed Certificate Timestamp returned by a log, as defined in
+// RFC6962 Section 4.1, in the JSON format of add-chain responses.
+type SCT struct {
+	// Version is the version of the SCT structure, 0 for v1.
+	Version uint8 `json:"sct_version"`
+	// ID is the log ID, the SHA-256 hash of the log's public key.
+	ID []byte `json:"id"`
+	// Timestamp is the time the SCT was issued at, in milliseconds since the
+	// epoch.
+	Timestamp uint64 `json:"timestamp"`
+	// Extensions are the base64 encoded CT extensions of the SCT.
+	Extensions string `json:"extensions"`
+	// Signature is the TLS encoded digitally-signed struct of the log's
+	// signature.
+	Signature []byte `json:"signature"`
+	// LeafIndex is the index of the entry in the log, from the SCT's
+	// leaf_index extension. It is only set on verified SCTs.
+	LeafIndex uint64 `json:"-"`
+}
+
+// StatusError is returned when a log responds to a submission with a status
+// code other than 200.
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("log returned status %d: %q", e.StatusCode, e.Body)
+}
+
+// NewHTTPClient returns an HTTP client to submit to logs with.
+//
+// The client negotiates HTTP/2 with logs served over HTTPS. If forceHTTP2 is
+// true, the client only uses HTTP/2, including with logs served over plain
+// HTTP.
+func NewHTTPClient(forceHTTP2 bool) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	if forceHTTP2 {
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP2(true)
+		t.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Client{Transport: t}
+}
+
+// NewSubmitter creates a new Submitter for the log rooted at logURL, with
+// pubKey, using the provided HTTP client.
+//
+// logURL is the log's submission prefix, e.g. "https://log.example.com/".
+// c may be nil, in which case a client from NewHTTPClient(false) is used.
+func NewSubmitter(logURL *url.URL, pubKey crypto.PublicKey, c *http.Client) (*Submitter, error) {
+	if pubKey == nil {
+		return nil, errors.New("log public key is nil")
+	}
+	if _, err := x509.MarshalPKIXPublicKey(pubKey); err != nil {
+		return nil, fmt.Errorf("unsupported log public key: %v", err)
+	}
+	u := *logURL
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	if c == nil {
+		c = NewHTTPClient(false)
+	}
+	return &Submitter{
+		c:         c,
+		logURL:    &u,
+		pubKey:    pubKey,
+		timeout:   DefaultTimeout,
+		backOff:   []backoff.RetryOption{backoff.WithMaxTries(1)},
+		userAgent: "TesseraCT client",
+	}, nil
+}
+
+// Submitter submits certificate chains to a log, and verifies the SCTs it
+// returns.
+type Submitter struct {
+	c          *http.Client
+	logURL     *url.URL
+	pubKey     crypto.PublicKey
+	timeout    time.Duration
+	authHeader string
+	backOff    []backoff.RetryOption
+	userAgent  string
+}
+
+// SetAuthorizationHeader sets the value to be used with an Authorization: header
+// for every request made by this submitter.
+func (s *Submitter) SetAuthorizationHeader(v string) {
+	s.authHeader = v
+}
+
+// SetUserAgent sets the user agent to use when sending requests.
+func (s *Submitter) SetUserAgent(ua string) {
+	s.userAgent = ua
+}
+
+// SetTimeout sets the timeout of a single submission attempt. Defaults to
+// DefaultTimeout.
+func (s *Submitter) SetTimeout(d time.Duration) {
+	s.timeout = d
+}
+
+// EnableRetries causes submissions which fail with a non-permanent error to be
+// retried, with up to maxTries attempts in total.
+//
+// When the log pushes back, retries wait for as long as the log asks in its
+// Retry-After header.
+func (s *Submitter) EnableRetries(maxTries uint) {
+	s.backOff = []backoff.RetryOption{backoff.WithBackOff(backoff.NewExponentialBackOff()), backoff.WithMaxTries(maxTries)}
+}
+
+// AddChain submits chain to the log's add-chain endpoint, and returns the
+// verified SCT.
+//
+// chain starts with the certificate to log, followed by its issuers.
+func (s *Submitter) AddChain(ctx context.Context, chain []*x509.Certificate) (*SCT, error) {
+	return s.submit(ctx, rfc6962.AddChainPath, chain)
+}
+
+// AddPreChain submits chain to the log's add-pre-chain endpoint, and returns
+// the verified SCT.
+//
+// chain starts with the precertificate to log, followed by its issuers.
+func (s *Submitter) AddPreChain(ctx context.Context, chain []*x509.Certificate) (*SCT, error) {
+	return s.submit(ctx, rfc6962.AddPreChainPath, chain)
+}
+
+func (s *Submitter) submit(ctx context.Context, path string, chain []*x509.Certificate) (*SCT, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("empty chain")
+	}
+	req := rfc6962.AddChainRequest{}
+	for _, c := range chain {
+		req.Chain = append(req.Chain, c.Raw)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+	u, err := s.logURL.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %v", err)
+	}
+	sct, err := backoff.Retry(ctx, func() (*SCT, error) {
+		return s.post(ctx, u.String(), body)
+	}, s.backOff...)
+	if err != nil {
+		return nil, err
+	}
+	if sct.LeafIndex, err = VerifySCT(s.pubKey, chain, sct); err != nil {
+		return nil, fmt.Errorf("invalid SCT: %v", err)
+	}
+	return sct, nil
+}
+
+// post makes a single submission attempt.
+func (s *Submitter) post(ctx context.Context, u string, body []byte) (*SCT, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, backoff.Permanent(fmt.Errorf("NewRequestWithContext(%q): %v", u, err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authHeader != "" {
+		req.Header.Add("Authorization", s.authHeader)
+	}
+	if s.userAgent != "" {
+		req.Header.Add("User-Agent", s.userAgent)
+	}
+	r, err := s.c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("post(%q): %w", u, err)
+	}
+	defer func() {
+		// Drain all bytes left in the body and close it to allow socket reuse
+		_, _ = io.Copy(io.Discard, r.Body)
+		if err := r.Body.Close(); err != nil {
+			slog.ErrorContext(ctx, "resp.Body.Close()", slog.Any("error", err))
+		}
+	}()
+	respBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", err)
+	}
+	switch r.StatusCode {
+	case http.StatusOK:
+		// All good, continue below
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		// The log pushed back, or is temporarily unavailable.
+		return nil, fmt.Errorf("post(%q): %w: %w", u, &StatusError{StatusCode: r.StatusCode, Body: respBody}, &backoff.RetryAfterError{Duration: retryAfter(r.Header.Get("Retry-After"), defaultRetryAfter)})
+	case http.StatusInternalServerError:
+		// Everything else will be retried
+		return nil, fmt.Errorf("post(%q): %w", u, &StatusError{StatusCode: r.StatusCode, Body: respBody})
+	default:
+		// Should not retry for any other status code, the chain was rejected.
+		return nil, backoff.Permanent(fmt.Errorf("post(%q): %w", u, &StatusError{StatusCode: r.StatusCode, Body: respBody}))
+	}
+	sct := &SCT{}
+	if err := json.Unmarshal(respBody, sct); err != nil {
+		return nil, backoff.Permanent(fmt.Errorf("can't parse add-chain response: %v", err))
+	}
+	return sct, nil
+}
+
+// retryAfter parses a Retry-After header value, in seconds or as an HTTP date.
+// It returns defaultDur if the value can't be parsed.
+func retryAfter(v string, defaultDur time.Duration) time.Duration {
+	if v == "" {
+		return defaultDur
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second
+	}
+	if d, err := http.ParseTime(v); err == nil {
+		return max(time.Until(d), 0)
+	}
+	return defaultDur
+}
+
+// VerifySCT checks that sct was issued for chain by the log with pubKey, and
+// returns the index of the entry in the log, from the SCT's leaf_index
+// extension.
+//
+// chain is the chain submitted to the log, starting with the certificate or
+// precertificate. Precertificate chains must include the precertificate's
+// issuer.
+func VerifySCT(pubKey crypto.PublicKey, chain []*x509.Certificate, sct *SCT) (uint64, error) {
+	if len(chain) == 0 {
+		return 0, errors.New("empty chain")
+	}
+	isPrecert, err := x509util.IsPrecertificate(chain[0])
+	if err != nil {
+		return 0, fmt.Errorf("failed to check if leaf is a precertificate: %v", err)
+	}
+	entry, err := x509util.EntryFromChain(chain, isPrecert, sct.Timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build entry from chain: %v", err)
+	}
+	defer x509util.ReturnEntry(entry)
+	var ikh [32]byte
+	if isPrecert {
+		copy(ikh[:], entry.IssuerKeyHash)
+	}
+	resp := &rfc6962.AddChainResponse{
+		SCTVersion: rfc6962.Version(sct.Version),
+		ID:         sct.ID,
+		Timestamp:  sct.Timestamp,
+		Extensions: sct.Extensions,
+		Signature:  sct.Signature,
+	}
+	return staticct.VerifySCT(pubKey, resp, isPrecert, entry.Certificate, ikh)
+}
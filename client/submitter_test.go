@@ -0,0 +1,208 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/tesseracttest"
+)
+
+func mustParseURL(t *testing.T, s string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", s, err)
+	}
+	return u
+}
+
+func TestSubmitter(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	s, err := client.NewSubmitter(mustParseURL(t, l.URL()), l.PublicKey, nil)
+	if err != nil {
+		t.Fatalf("client.NewSubmitter(): %v", err)
+	}
+
+	for i, tc := range []struct {
+		name   string
+		issue  func(testing.TB) []*x509.Certificate
+		submit func(context.Context, []*x509.Certificate) (*client.SCT, error)
+	}{
+		{name: "add-chain", issue: l.IssueChain, submit: s.AddChain},
+		{name: "add-pre-chain", issue: l.IssuePrecertChain, submit: s.AddPreChain},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := tc.issue(t)
+			sct, err := tc.submit(t.Context(), chain)
+			if err != nil {
+				t.Fatalf("submit(): %v", err)
+			}
+			if sct.LeafIndex != uint64(i) {
+				t.Errorf("SCT leaf index = %d, want %d", sct.LeafIndex, i)
+			}
+			l.AssertSCTVerifies(t, chain, sct)
+			if idx, err := client.VerifySCT(l.PublicKey, chain, sct); err != nil || idx != uint64(i) {
+				t.Errorf("client.VerifySCT() = %d, %v, want %d, nil", idx, err, i)
+			}
+
+			// SCTs can be rebuilt from their fields, e.g. when stored by a CA.
+			stored := &client.SCT{
+				Version:    sct.Version,
+				ID:         sct.ID,
+				Timestamp:  sct.Timestamp,
+				Extensions: sct.Extensions,
+				Signature:  sct.Signature,
+			}
+			if idx, err := client.VerifySCT(l.PublicKey, chain, stored); err != nil || idx != uint64(i) {
+				t.Errorf("client.VerifySCT() of a rebuilt SCT = %d, %v, want %d, nil", idx, err, i)
+			}
+			stored.Timestamp++
+			if _, err := client.VerifySCT(l.PublicKey, chain, stored); err == nil {
+				t.Error("client.VerifySCT() of an SCT with another timestamp succeeded")
+			}
+		})
+	}
+}
+
+func TestSubmitterErrors(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		pubKey     any
+		chain      []*x509.Certificate
+		wantStatus int
+	}{
+		{
+			name:   "wrong public key",
+			pubKey: otherKey.Public(),
+			chain:  l.IssueChain(t),
+		},
+		{
+			name:       "rejected chain",
+			pubKey:     l.PublicKey,
+			chain:      l.IssueChain(t)[:1],
+			wantStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := client.NewSubmitter(mustParseURL(t, l.URL()), tc.pubKey, nil)
+			if err != nil {
+				t.Fatalf("client.NewSubmitter(): %v", err)
+			}
+			_, err = s.AddChain(t.Context(), tc.chain)
+			if err == nil {
+				t.Fatal("AddChain(): got nil error, want error")
+			}
+			var statusErr *client.StatusError
+			if errors.As(err, &statusErr) != (tc.wantStatus != 0) || (tc.wantStatus != 0 && statusErr.StatusCode != tc.wantStatus) {
+				t.Errorf("AddChain(): got error %v, want status %d", err, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSubmitterPushback(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	var pushbacks atomic.Int32
+	pushbacks.Store(2)
+	proxy := httputil.NewSingleHostReverseProxy(mustParseURL(t, l.URL()))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pushbacks.Add(-1) >= 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	s, err := client.NewSubmitter(mustParseURL(t, srv.URL), l.PublicKey, nil)
+	if err != nil {
+		t.Fatalf("client.NewSubmitter(): %v", err)
+	}
+	var statusErr *client.StatusError
+	if _, err := s.AddChain(t.Context(), l.IssueChain(t)); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("AddChain() without retries: got error %v, want status %d", err, http.StatusTooManyRequests)
+	}
+
+	// The remaining pushback is retried.
+	s.EnableRetries(3)
+	if _, err := s.AddChain(t.Context(), l.IssueChain(t)); err != nil {
+		t.Fatalf("AddChain() with retries: %v", err)
+	}
+}
+
+func TestSubmitterTimeout(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(done) })
+
+	s, err := client.NewSubmitter(mustParseURL(t, srv.URL), l.PublicKey, nil)
+	if err != nil {
+		t.Fatalf("client.NewSubmitter(): %v", err)
+	}
+	s.SetTimeout(50 * time.Millisecond)
+	if _, err := s.AddChain(t.Context(), l.IssueChain(t)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AddChain(): got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSubmitterHTTP2(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	proxy := httputil.NewSingleHostReverseProxy(mustParseURL(t, l.URL()))
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			http.Error(w, "HTTP/2 only", http.StatusHTTPVersionNotSupported)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	s, err := client.NewSubmitter(mustParseURL(t, srv.URL), l.PublicKey, client.NewHTTPClient(true))
+	if err != nil {
+		t.Fatalf("client.NewSubmitter(): %v", err)
+	}
+	if _, err := s.AddChain(t.Context(), l.IssueChain(t)); err != nil {
+		t.Fatalf("AddChain() over HTTP/2: %v", err)
+	}
+}
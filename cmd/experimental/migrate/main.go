@@ -0,0 +1,367 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// migrate is a command-line tool for migrating data from a static-ct
+// compliant log into a TesseraCT log instance, on GCP, AWS or POSIX storage.
+//
+// Entry bundles are copied along with the issuers their entries reference,
+// and the migrated tree is checked against the source checkpoint's root hash.
+// An interrupted migration is resumed by running the tool again with the same
+// flags: entry bundles already in the target are then read from there, rather
+// than from the source.
+//
+// With --source_rfc6962, the source is an RFC 6962 log read through its
+// get-sth and get-entries endpoints, e.g. a retired log, and the migrated
+// tree is checked against the root hash of its STH. Imported entries keep their
+// original SCT extensions, without a leaf_index, so check them with cmd/fsck
+// --deep --imported_rfc6962.
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/internal/source"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/storage"
+)
+
+var (
+	targetType = flag.String("target", "", "Storage to migrate the log to: gcp, aws or posix.")
+
+	sourceURL          = flag.String("source_url", "", "Base monitoring URL for the source log, or gs://bucket, s3://bucket or file:///path/to/log/ to read it directly from its storage.")
+	sourceRFC6962      = flag.Bool("source_rfc6962", false, "Set to true if --source_url is the base URL of an RFC 6962 log, e.g. https://ct.example.com/logs/2019/, to import it through its get-sth and get-entries endpoints.")
+	sourceOrigin       = flag.String("source_origin", "", "Origin of the source log. Required with --source_public_key, unless --source_rfc6962 is set.")
+	sourcePublicKey    = flag.String("source_public_key", "", "The source log's public key in base64 encoded DER format. If set, the signature of the source checkpoint, or STH, is verified.")
+	numWorkers         = flag.Uint("num_workers", 30, "Number of migration worker goroutines.")
+	persistentAntispam = flag.Bool("antispam", false, "Set to true to populate the target's persistent antispam storage.")
+	antispamBatchSize  = flag.Uint("antispam_batch_size", 0, "Maximum number of antispam rows to insert per batch update, or 0 for the target's default. 1500 gives good performance on GCP with 300 Spanner PU and above, smaller values may be required for smaller allocs.")
+	clientHTTPTimeout  = flag.Duration("client_http_timeout", 30*time.Second, "Timeout for outgoing HTTP requests")
+	progressInterval   = flag.Duration("progress_interval", 10*time.Second, "How often to log the progress of the migration.")
+	slogLevel          = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+)
+
+const (
+	userAgent = "TesseraCT migrate"
+)
+
+func main() {
+	flag.Parse()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	src := sourceFromFlags(ctx)
+	cp := sourceCheckpoint(ctx, src)
+	t := targetFromFlags(ctx)
+
+	opts := tessera.NewMigrationOptions().WithCTLayout()
+	if t.antispam != nil {
+		opts.WithAntispam(t.antispam)
+	}
+	m, err := tessera.NewMigrationTarget(ctx, t.driver, opts)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create MigrationTarget", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	c := &bundleCopier{
+		src:              src,
+		readTargetBundle: t.readEntryBundle,
+		issuers:          t.issuers,
+	}
+	progressCtx, stopProgress := context.WithCancel(ctx)
+	go c.logProgress(progressCtx, cp.Size, *progressInterval)
+
+	slog.InfoContext(ctx, "Migrating log", slog.String("target", *targetType), slog.Uint64("size", cp.Size), slog.String("root", base64.StdEncoding.EncodeToString(cp.Hash)))
+	start := time.Now()
+	// Migrate only returns successfully once the migrated tree matches the
+	// source root hash.
+	err = m.Migrate(ctx, *numWorkers, cp.Size, cp.Hash, c.ReadEntryBundle)
+	stopProgress()
+	if err != nil {
+		if ctx.Err() != nil {
+			slog.ErrorContext(ctx, "Migration interrupted, run again with the same flags to resume", slog.Any("error", err))
+		} else {
+			slog.ErrorContext(ctx, "Migrate failed", slog.Any("error", err))
+		}
+		os.Exit(1)
+	}
+
+	slog.InfoContext(ctx, "Migration complete, root hash matches the source checkpoint", slog.Uint64("size", cp.Size), slog.Duration("duration", time.Since(start).Truncate(time.Second)), slog.Uint64("issuers_copied", c.issuersCopied.Load()))
+	if n := c.issuersMissing.Load(); n > 0 {
+		slog.WarnContext(ctx, "Some issuers are missing from the source log, use cmd/repair_issuers to backfill them", slog.Uint64("missing", n))
+	}
+}
+
+// bundleCopier reads the entry bundles to migrate, and copies the issuers
+// referenced by their entries to the target's issuer storage before returning
+// them. Every entry bundle handed to the migration target therefore has its
+// issuers stored.
+//
+// Entry bundles already in the target, written by an interrupted run, are
+// read from there instead of from the source.
+type bundleCopier struct {
+	src              source.Fetcher
+	readTargetBundle func(ctx context.Context, i uint64, p uint8) ([]byte, error)
+	issuers          storage.IssuerStorage
+
+	// issuersSeen contains the fingerprints of the issuers already stored,
+	// or missing from the source.
+	issuersSeen sync.Map
+
+	fromSource, fromTarget        atomic.Uint64
+	issuersCopied, issuersMissing atomic.Uint64
+}
+
+// ReadEntryBundle returns the entry bundle at index i, with partial size p.
+func (c *bundleCopier) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	if b, err := c.readTargetBundle(ctx, i, p); err == nil {
+		c.fromTarget.Add(1)
+		return b, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		slog.DebugContext(ctx, "Failed to read entry bundle from target, reading it from source", slog.Uint64("index", i), slog.Any("error", err))
+	}
+	b, err := c.src.ReadEntryBundle(ctx, i, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entry bundle %d from source: %v", i, err)
+	}
+	if err := c.copyIssuers(ctx, b); err != nil {
+		return nil, fmt.Errorf("failed to copy issuers of entry bundle %d: %v", i, err)
+	}
+	c.fromSource.Add(1)
+	return b, nil
+}
+
+// copyIssuers copies the issuers referenced by the entries of bundle from
+// the source to the target, unless they've been copied already.
+func (c *bundleCopier) copyIssuers(ctx context.Context, bundle []byte) error {
+	eb := staticct.EntryBundle{}
+	if err := eb.UnmarshalText(bundle); err != nil {
+		return fmt.Errorf("failed to parse entry bundle: %v", err)
+	}
+	toCopy := map[[32]byte]bool{}
+	for j, raw := range eb.Entries {
+		e := staticct.Entry{}
+		if err := e.UnmarshalText(raw); err != nil {
+			return fmt.Errorf("failed to parse entry %d: %v", j, err)
+		}
+		for _, fp := range e.FingerprintsChain {
+			if _, ok := c.issuersSeen.Load(fp); !ok {
+				toCopy[fp] = true
+			}
+		}
+	}
+	if len(toCopy) == 0 {
+		return nil
+	}
+
+	kvs := make([]storage.KV, 0, len(toCopy))
+	fps := make([][32]byte, 0, len(toCopy))
+	for fp := range toCopy {
+		der, err := c.src.ReadIssuer(ctx, fp[:])
+		if errors.Is(err, os.ErrNotExist) {
+			if _, loaded := c.issuersSeen.LoadOrStore(fp, true); !loaded {
+				slog.WarnContext(ctx, "Issuer missing from source log", slog.String("fp", hex.EncodeToString(fp[:])))
+				c.issuersMissing.Add(1)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read issuer %x: %v", fp, err)
+		}
+		if sha256.Sum256(der) != fp {
+			return fmt.Errorf("issuer %x served by the source doesn't match its fingerprint", fp)
+		}
+		kvs = append(kvs, storage.KV{K: []byte(hex.EncodeToString(fp[:])), V: der})
+		fps = append(fps, fp)
+	}
+	if len(kvs) == 0 {
+		return nil
+	}
+	if err := c.issuers.AddIfNotExist(ctx, kvs); err != nil {
+		return fmt.Errorf("failed to write issuers: %v", err)
+	}
+	// Issuers are only marked as seen once they're stored, so that no other
+	// bundle referencing them can be returned before then.
+	for _, fp := range fps {
+		if _, loaded := c.issuersSeen.LoadOrStore(fp, true); !loaded {
+			c.issuersCopied.Add(1)
+		}
+	}
+	return nil
+}
+
+// logProgress logs how many of the entry bundles covering size entries have
+// been migrated, every interval, until ctx is done.
+func (c *bundleCopier) logProgress(ctx context.Context, size uint64, interval time.Duration) {
+	total := (size + layout.EntryBundleWidth - 1) / layout.EntryBundleWidth
+	if total == 0 || interval <= 0 {
+		return
+	}
+	start := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		fromSource, fromTarget := c.fromSource.Load(), c.fromTarget.Load()
+		done := fromSource + fromTarget
+		attrs := []any{
+			slog.Uint64("bundles", done),
+			slog.Uint64("total", total),
+			slog.String("percent", fmt.Sprintf("%.2f", float64(done)*100/float64(total))),
+			slog.Uint64("from_source", fromSource),
+			slog.Uint64("from_target", fromTarget),
+			slog.Uint64("issuers_copied", c.issuersCopied.Load()),
+			slog.Uint64("issuers_missing", c.issuersMissing.Load()),
+		}
+		if fromSource > 0 && done < total {
+			// Bundles read back from the target are comparatively free, so
+			// the rate only accounts for those read from the source.
+			perBundle := time.Since(start) / time.Duration(fromSource)
+			attrs = append(attrs, slog.Duration("eta", (time.Duration(total-done)*perBundle).Truncate(time.Second)))
+		}
+		slog.InfoContext(ctx, "Progress", attrs...)
+	}
+}
+
+// sourceFromFlags returns a fetcher for the log at --source_url.
+func sourceFromFlags(ctx context.Context) source.Fetcher {
+	srcURL, err := url.Parse(*sourceURL)
+	if err != nil || *sourceURL == "" {
+		slog.ErrorContext(ctx, "Invalid --source_url", slog.Any("arg", *sourceURL), slog.Any("error", err))
+		os.Exit(1)
+	}
+	hc := &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:        int(*numWorkers) * 2,
+			MaxIdleConnsPerHost: int(*numWorkers),
+			DisableKeepAlives:   false,
+		},
+		Timeout: *clientHTTPTimeout,
+	}
+	if *sourceRFC6962 {
+		f, err := source.NewRFC6962Fetcher(srcURL, hc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create RFC 6962 source fetcher", slog.Any("error", err))
+			os.Exit(1)
+		}
+		f.SetUserAgent(userAgent)
+		return f
+	}
+	src, err := source.New(ctx, srcURL, hc)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create source fetcher", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if h, ok := src.(*client.HTTPFetcher); ok {
+		h.EnableRetries(10)
+		h.SetUserAgent(userAgent)
+	}
+	return src
+}
+
+// sourceCheckpoint returns the latest checkpoint of the source log, or the
+// checkpoint matching its latest STH if it's an RFC 6962 log, verified with
+// --source_public_key if set.
+func sourceCheckpoint(ctx context.Context, src source.Fetcher) *log.Checkpoint {
+	pub := sourcePublicKeyFromFlags(ctx)
+	if f, ok := src.(*source.RFC6962Fetcher); ok {
+		sth, err := f.STH(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to fetch source STH", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if pub == nil {
+			slog.WarnContext(ctx, "--source_public_key isn't set, the signature of the source STH won't be verified")
+		} else if err := staticct.VerifySTH(pub, sth); err != nil {
+			slog.ErrorContext(ctx, "Failed to verify source STH", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return &log.Checkpoint{Origin: *sourceOrigin, Size: sth.TreeSize, Hash: sth.SHA256RootHash}
+	}
+
+	cpRaw, err := src.ReadCheckpoint(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to fetch source checkpoint", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if pub == nil {
+		// The migrated tree is still checked against the root hash of this
+		// checkpoint, but nothing proves that the source log signed it.
+		slog.WarnContext(ctx, "--source_public_key isn't set, the signature of the source checkpoint won't be verified")
+		cp := &log.Checkpoint{}
+		if _, err := cp.Unmarshal(cpRaw); err != nil {
+			slog.ErrorContext(ctx, "Failed to parse source checkpoint", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return cp
+	}
+	if *sourceOrigin == "" {
+		slog.ErrorContext(ctx, "--source_origin must be set with --source_public_key")
+		os.Exit(1)
+	}
+	v, err := staticct.NewCheckpointVerifier(*sourceOrigin, pub)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error creating checkpoint verifier", slog.Any("error", err))
+		os.Exit(1)
+	}
+	cp, _, _, err := log.ParseCheckpoint(cpRaw, *sourceOrigin, v)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to verify source checkpoint", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return cp
+}
+
+// sourcePublicKeyFromFlags returns the public key from --source_public_key,
+// or nil if it isn't set.
+func sourcePublicKeyFromFlags(ctx context.Context) crypto.PublicKey {
+	if *sourcePublicKey == "" {
+		return nil
+	}
+	der, err := base64.StdEncoding.DecodeString(*sourcePublicKey)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error decoding --source_public_key", slog.Any("error", err))
+		os.Exit(1)
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error parsing --source_public_key", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return pub
+}
@@ -0,0 +1,283 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	aaws "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dustin/go-humanize"
+	"github.com/go-sql-driver/mysql"
+	"github.com/transparency-dev/tessera"
+	taws "github.com/transparency-dev/tessera/storage/aws"
+	aws_as "github.com/transparency-dev/tessera/storage/aws/antispam"
+	tgcp "github.com/transparency-dev/tessera/storage/gcp"
+	gcp_as "github.com/transparency-dev/tessera/storage/gcp/antispam"
+	tposix "github.com/transparency-dev/tessera/storage/posix"
+	posix_as "github.com/transparency-dev/tessera/storage/posix/antispam"
+	"github.com/transparency-dev/tesseract/client"
+	caws "github.com/transparency-dev/tesseract/client/aws"
+	cgcp "github.com/transparency-dev/tesseract/client/gcp"
+	"github.com/transparency-dev/tesseract/storage"
+	"github.com/transparency-dev/tesseract/storage/aws"
+	"github.com/transparency-dev/tesseract/storage/gcp"
+	"github.com/transparency-dev/tesseract/storage/posix"
+)
+
+var (
+	// POSIX target flags.
+	storageDir                 = flag.String("storage_dir", "", "Path to the directory in which to store the migrated log, with --target=posix.")
+	antispamBlockCacheSize     = flag.String("antispam_block_cache_size", "768MB", "Amount of RAM to allocate for the POSIX antispam block cache, set to zero to disable.")
+	antispamIndexCacheSize     = flag.String("antispam_index_cache_size", "768MB", "Amount of RAM to allocate for the POSIX antispam index cache, set to zero for unlimited.")
+	antispamCompactionInterval = flag.Duration("antispam_compaction_interval", posix_as.DefaultCompactionInterval, "Interval between GC/compaction runs on the POSIX antispam index.")
+
+	// GCP and AWS target flags.
+	bucket = flag.String("bucket", "", "Name of the GCS or S3 bucket to store the migrated log in, with --target=gcp or --target=aws.")
+
+	// GCP target flags.
+	spanner = flag.String("spanner", "", "Spanner resource URI ('projects/.../...'), with --target=gcp.")
+
+	// AWS target flags.
+	dbName         = flag.String("db_name", "", "AuroraDB name, with --target=aws.")
+	antispamDBName = flag.String("antispam_db_name", "", "AuroraDB antispam name, with --target=aws and --antispam.")
+	dbHost         = flag.String("db_host", "", "AuroraDB host, with --target=aws.")
+	dbPort         = flag.Int("db_port", 3306, "AuroraDB port, with --target=aws.")
+	dbUser         = flag.String("db_user", "", "AuroraDB user, with --target=aws.")
+	dbPassword     = flag.String("db_password", "", "AuroraDB password, with --target=aws.")
+	dbMaxConns     = flag.Int("db_max_conns", 0, "Maximum connections to the database, defaults to 0, i.e unlimited")
+	dbMaxIdle      = flag.Int("db_max_idle_conns", 2, "Maximum idle database connections in the connection pool, defaults to 2")
+	usePathStyle   = flag.Bool("s3_use_path_style", false, "Whether to force the AWS S3 client to use path-style bucket references, probably only useful for on-prem deployments")
+)
+
+// target is the storage a log is migrated to.
+type target struct {
+	driver tessera.Driver
+	// antispam is nil unless --antispam is set.
+	antispam tessera.Antispam
+	issuers  storage.IssuerStorage
+	// readEntryBundle reads the entry bundles already in the target. It
+	// returns an error wrapping os.ErrNotExist for those which aren't.
+	readEntryBundle func(ctx context.Context, i uint64, p uint8) ([]byte, error)
+}
+
+// targetFromFlags returns the target selected by --target.
+func targetFromFlags(ctx context.Context) *target {
+	var t *target
+	var err error
+	switch *targetType {
+	case "posix":
+		t, err = newPOSIXTarget(ctx)
+	case "gcp":
+		t, err = newGCPTarget(ctx)
+	case "aws":
+		t, err = newAWSTarget(ctx)
+	default:
+		slog.ErrorContext(ctx, "--target must be one of gcp, aws or posix", slog.String("target", *targetType))
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create target storage", slog.String("target", *targetType), slog.Any("error", err))
+		os.Exit(1)
+	}
+	return t
+}
+
+func newPOSIXTarget(ctx context.Context) (*target, error) {
+	if *storageDir == "" {
+		return nil, fmt.Errorf("--storage_dir must be set")
+	}
+	driver, err := tposix.New(ctx, tposix.Config{Path: *storageDir})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new POSIX storage driver: %v", err)
+	}
+	issuers, err := posix.NewIssuerStorage(ctx, *storageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create POSIX issuer storage: %v", err)
+	}
+	t := &target{
+		driver:          driver,
+		issuers:         issuers,
+		readEntryBundle: client.FileFetcher{Root: *storageDir}.ReadEntryBundle,
+	}
+	if !*persistentAntispam {
+		return t, nil
+	}
+	indexCacheBytes, err := humanize.ParseBytes(*antispamIndexCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid antispam index cache size: %v", err)
+	}
+	blockCacheBytes, err := humanize.ParseBytes(*antispamBlockCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid antispam block cache size: %v", err)
+	}
+	asOpts := posix_as.AntispamOpts{
+		MaxBatchSize:       batchSize(10000),
+		CompactionInterval: *antispamCompactionInterval,
+		BadgerOptions: func(o badger.Options) badger.Options {
+			return o.
+				WithIndexCacheSize(int64(indexCacheBytes)).
+				WithBlockCacheSize(int64(blockCacheBytes))
+		},
+	}
+	t.antispam, err = posix_as.NewAntispam(ctx, filepath.Join(*storageDir, ".state", "antispam"), asOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new POSIX antispam storage: %v", err)
+	}
+	return t, nil
+}
+
+func newGCPTarget(ctx context.Context) (*target, error) {
+	if *bucket == "" {
+		return nil, fmt.Errorf("--bucket must be set")
+	}
+	if *spanner == "" {
+		return nil, fmt.Errorf("--spanner must be set")
+	}
+	driver, err := tgcp.New(ctx, tgcp.Config{Bucket: *bucket, Spanner: *spanner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new GCP storage driver: %v", err)
+	}
+	issuers, err := gcp.NewIssuerStorage(ctx, *bucket, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS issuer storage: %v", err)
+	}
+	f, err := cgcp.NewGSFetcher(ctx, *bucket, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS fetcher: %v", err)
+	}
+	t := &target{
+		driver:          driver,
+		issuers:         issuers,
+		readEntryBundle: f.ReadEntryBundle,
+	}
+	if !*persistentAntispam {
+		return t, nil
+	}
+	// Persistent antispam is currently experimental on GCP, so there's no
+	// OpenTofu or documentation yet!
+	// 1500 appears to give good performance for migrating logs, but you may
+	// need to lower it if you have less than 300 Spanner PU available.
+	// (Consider temporarily raising your Spanner CPU quota to be at least
+	// this amount for the duration of the migration.)
+	t.antispam, err = gcp_as.NewAntispam(ctx, fmt.Sprintf("%s-antispam", *spanner), gcp_as.AntispamOpts{MaxBatchSize: batchSize(1500)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new GCP antispam storage: %v", err)
+	}
+	return t, nil
+}
+
+func newAWSTarget(ctx context.Context) (*target, error) {
+	if *bucket == "" {
+		return nil, fmt.Errorf("--bucket must be set")
+	}
+	if *dbName == "" {
+		return nil, fmt.Errorf("--db_name must be set")
+	}
+	if *dbHost == "" {
+		return nil, fmt.Errorf("--db_host must be set")
+	}
+	if *dbUser == "" {
+		return nil, fmt.Errorf("--db_user must be set")
+	}
+	// Empty password isn't an option with AuroraDB MySQL.
+	if *dbPassword == "" {
+		return nil, fmt.Errorf("--db_password must be set")
+	}
+	var s3Opts func(o *s3.Options)
+	var awsConfig *aaws.Config
+	if *usePathStyle {
+		s3Opts = func(o *s3.Options) {
+			o.UsePathStyle = true
+			o.BaseEndpoint = aaws.String(os.Getenv("AWS_ENDPOINT_URL_S3"))
+			o.Credentials = credentials.NewStaticCredentialsProvider(os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), "")
+		}
+		awsConfig = &aaws.Config{
+			Region: os.Getenv("AWS_DEFAULT_REGION"),
+		}
+	}
+
+	driver, err := taws.New(ctx, taws.Config{
+		Bucket:       *bucket,
+		DSN:          auroraConfig(*dbName).FormatDSN(),
+		MaxOpenConns: *dbMaxConns,
+		MaxIdleConns: *dbMaxIdle,
+		SDKConfig:    awsConfig,
+		S3Options:    s3Opts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new AWS storage driver: %v", err)
+	}
+	issuers, err := aws.NewIssuerStorage(ctx, aws.Options{
+		Bucket:    *bucket,
+		SDKConfig: awsConfig,
+		S3Options: s3Opts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 issuer storage: %v", err)
+	}
+	var s3Client *s3.Client
+	if awsConfig != nil {
+		s3Client = s3.NewFromConfig(*awsConfig, s3Opts)
+	}
+	f, err := caws.NewS3Fetcher(ctx, *bucket, s3Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 fetcher: %v", err)
+	}
+	t := &target{
+		driver:          driver,
+		issuers:         issuers,
+		readEntryBundle: f.ReadEntryBundle,
+	}
+	if !*persistentAntispam {
+		return t, nil
+	}
+	if *antispamDBName == "" {
+		return nil, fmt.Errorf("--antispam_db_name must be set with --antispam")
+	}
+	t.antispam, err = aws_as.NewAntispam(ctx, auroraConfig(*antispamDBName).FormatDSN(), aws_as.AntispamOpts{MaxBatchSize: batchSize(0)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new AWS antispam storage: %v", err)
+	}
+	return t, nil
+}
+
+// auroraConfig returns the configuration of the AuroraDB database db.
+func auroraConfig(db string) *mysql.Config {
+	return &mysql.Config{
+		User:                    *dbUser,
+		Passwd:                  *dbPassword,
+		Net:                     "tcp",
+		Addr:                    fmt.Sprintf("%s:%d", *dbHost, *dbPort),
+		DBName:                  db,
+		AllowCleartextPasswords: true,
+		AllowNativePasswords:    true,
+	}
+}
+
+// batchSize returns --antispam_batch_size, or def if it's not set.
+func batchSize(def uint) uint {
+	if *antispamBatchSize == 0 {
+		return def
+	}
+	return *antispamBatchSize
+}
@@ -0,0 +1,275 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/internal/lax509"
+	"github.com/transparency-dev/tesseract/internal/logger"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+)
+
+// deepChecker re-validates the contents of every entry in the log, beyond
+// what's needed to check the log's Merkle structure:
+//   - the leaf_index SCT extension must match the entry's position in the log,
+//     unless the entry was imported from an RFC 6962 log and has no extensions,
+//   - timestamps must be plausible,
+//   - precertificate TBSs must match the logged precertificate with the CT poison removed,
+//   - IssuerKeyHash must match the SPKI hash of the precertificate's issuer,
+//   - fingerprint chains must form a valid path to one of roots, if set.
+type deepChecker struct {
+	r          *reporter
+	readIssuer func(context.Context, []byte) ([]byte, error)
+	// roots is the set of roots chains must verify to, or nil to skip chain verification.
+	roots *lax509.CertPool
+	// tolerance is how far in the future, or before the latest one in
+	// their entry bundle, timestamps can be.
+	tolerance time.Duration
+	// importedRFC6962 accepts entries without extensions, which were imported
+	// from an RFC 6962 log.
+	importedRFC6962 bool
+
+	// issuers caches parsed issuers by fingerprint, as issuerResults.
+	issuers sync.Map
+}
+
+// errIssuerUnavailable is returned for issuers which couldn't be read.
+//
+// These are reported by checkIssuersTask, rather than for every entry.
+var errIssuerUnavailable = errors.New("issuer unavailable")
+
+type issuerResult struct {
+	cert *x509.Certificate
+	err  error
+}
+
+// newDeepChecker returns a deepChecker which verifies chains to the roots in
+// rootsPEMFile, or skips chain verification if it's empty.
+func newDeepChecker(r *reporter, readIssuer func(context.Context, []byte) ([]byte, error), rootsPEMFile string, tolerance time.Duration, importedRFC6962 bool) (*deepChecker, error) {
+	d := &deepChecker{
+		r:               r,
+		readIssuer:      readIssuer,
+		tolerance:       tolerance,
+		importedRFC6962: importedRFC6962,
+	}
+	if rootsPEMFile != "" {
+		roots, err := x509util.NewPEMCertPool(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create roots pool: %v", err)
+		}
+		if err := roots.AppendCertsFromPEMFile(rootsPEMFile); err != nil {
+			return nil, fmt.Errorf("failed to read roots from %q: %v", rootsPEMFile, err)
+		}
+		d.roots = roots.CertPool()
+	}
+	return d, nil
+}
+
+// checkBundle checks all the entries of the bundle at index i, with partial
+// size p.
+//
+// Problems are reported, and never returned as errors, so that the rest of
+// the log can still be checked.
+func (d *deepChecker) checkBundle(ctx context.Context, i uint64, p uint8, bundle []byte) {
+	resource := entryBundlePath(i, p)
+	eb := staticct.EntryBundle{}
+	if err := eb.UnmarshalText(bundle); err != nil {
+		d.r.report(ctx, problem{Kind: kindCorrupt, Index: bundleFirstIndex(i), Resource: resource, Error: fmt.Sprintf("invalid entry bundle: %v", err)})
+		return
+	}
+	var latest uint64
+	for j, raw := range eb.Entries {
+		idx := i*layout.EntryBundleWidth + uint64(j)
+		e := staticct.Entry{}
+		if err := e.UnmarshalText(raw); err != nil {
+			d.r.report(ctx, problem{Kind: kindCorrupt, Index: &idx, Resource: resource, Error: fmt.Sprintf("invalid entry: %v", err)})
+			continue
+		}
+		for _, m := range d.checkEntry(ctx, idx, &e, latest) {
+			d.r.report(ctx, problem{Kind: kindCorrupt, Index: &idx, Resource: resource, Error: m})
+		}
+		latest = max(latest, e.Timestamp)
+	}
+}
+
+// checkEntry returns a message for each problem found with the entry e at
+// index idx.
+//
+// latest is the latest timestamp of the entries before e in its bundle.
+func (d *deepChecker) checkEntry(ctx context.Context, idx uint64, e *staticct.Entry, latest uint64) []string {
+	msgs := []string{}
+	switch {
+	case e.RawExtensions == "":
+		// Entries imported from RFC 6962 logs have no extensions.
+		if !d.importedRFC6962 {
+			msgs = append(msgs, "no leaf_index SCT extension, set --imported_rfc6962 if the log was imported from an RFC 6962 log")
+		}
+	case e.LeafIndex != idx:
+		msgs = append(msgs, fmt.Sprintf("leaf_index SCT extension is %d", e.LeafIndex))
+	}
+
+	ts := time.UnixMilli(int64(e.Timestamp))
+	if ts.After(time.Now().Add(d.tolerance)) {
+		msgs = append(msgs, fmt.Sprintf("timestamp %s is in the future", ts.UTC()))
+	}
+	if latest > e.Timestamp && time.Duration(latest-e.Timestamp)*time.Millisecond > d.tolerance {
+		msgs = append(msgs, fmt.Sprintf("timestamp %s is more than %s before the one of a previous entry in its bundle", ts.UTC(), d.tolerance))
+	}
+
+	leafDER := e.Certificate
+	if e.IsPrecert {
+		leafDER = e.Precertificate
+	}
+	leaf, err := x509.ParseCertificate(leafDER)
+	if err != nil {
+		return append(msgs, fmt.Sprintf("failed to parse leaf certificate: %v", err))
+	}
+	chain := []*x509.Certificate{leaf}
+	for _, fp := range e.FingerprintsChain {
+		c, err := d.issuer(ctx, fp)
+		if errors.Is(err, errIssuerUnavailable) {
+			logger.DebugExtraContext(ctx, "Skipping chain checks", slog.Uint64("index", idx), slog.Any("error", err))
+			return msgs
+		}
+		if err != nil {
+			return append(msgs, fmt.Sprintf("invalid issuer %x: %v", fp, err))
+		}
+		chain = append(chain, c)
+	}
+
+	if e.IsPrecert {
+		// This takes care of pre-issuers, which RemoveCTPoison alone wouldn't.
+		want, err := x509util.EntryFromChain(chain, true, e.Timestamp)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to rebuild precertificate entry: %v", err))
+		} else {
+			if !bytes.Equal(want.Certificate, e.Certificate) {
+				msgs = append(msgs, "TBS doesn't match the precertificate with its CT poison removed")
+			}
+			if !bytes.Equal(want.IssuerKeyHash, e.IssuerKeyHash) {
+				msgs = append(msgs, fmt.Sprintf("IssuerKeyHash is %x, but issuer's SPKI hash is %x", e.IssuerKeyHash, want.IssuerKeyHash))
+			}
+			x509util.ReturnEntry(want)
+		}
+	}
+
+	if d.roots != nil {
+		if err := d.verifyChain(chain); err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+	return msgs
+}
+
+// verifyChain checks that chain, in this order, forms a valid path to one of
+// d.roots.
+func (d *deepChecker) verifyChain(chain []*x509.Certificate) error {
+	intermediates := lax509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+	verifiedChains, err := lax509.Verify(chain[0], lax509.VerifyOptions{
+		Roots:         d.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify chain: %v", err)
+	}
+	// Logged chains may or may not include the root.
+	for _, vc := range verifiedChains {
+		if len(vc) < len(chain) || len(vc) > len(chain)+1 {
+			continue
+		}
+		ok := true
+		for i, c := range chain {
+			if !c.Equal(vc[i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("chain verifies, but not in the logged order")
+}
+
+// issuer returns the parsed issuer with fingerprint fp.
+func (d *deepChecker) issuer(ctx context.Context, fp [32]byte) (*x509.Certificate, error) {
+	if r, ok := d.issuers.Load(fp); ok {
+		return r.(issuerResult).cert, r.(issuerResult).err
+	}
+	r := issuerResult{}
+	der, err := d.readIssuer(ctx, fp[:])
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		r.err = fmt.Errorf("%w: %v", errIssuerUnavailable, err)
+	case err != nil:
+		// Don't cache other errors, they might be transient.
+		return nil, fmt.Errorf("%w: %v", errIssuerUnavailable, err)
+	case sha256.Sum256(der) != fp:
+		r.err = fmt.Errorf("issuer doesn't match its fingerprint")
+	default:
+		r.cert, r.err = x509.ParseCertificate(der)
+	}
+	d.issuers.Store(fp, r)
+	return r.cert, r.err
+}
+
+// deepFetcher is a fetcher which passes every entry bundle it reads to a
+// deepChecker.
+type deepFetcher struct {
+	fetcher
+	d *deepChecker
+}
+
+func (f *deepFetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	b, err := f.fetcher.ReadEntryBundle(ctx, i, p)
+	if err == nil {
+		f.d.checkBundle(ctx, i, p, b)
+	}
+	return b, err
+}
+
+// deepCheckerFromFlags returns a deepChecker reading issuers from src if
+// --deep is set, or nil otherwise.
+func deepCheckerFromFlags(r *reporter, src fetcher) *deepChecker {
+	if !*deep {
+		return nil
+	}
+	ctx := context.Background()
+	if *rootsPEMFile == "" {
+		slog.WarnContext(ctx, "--roots_pem_file is not set, chains won't be verified")
+	}
+	d, err := newDeepChecker(r, src.ReadIssuer, *rootsPEMFile, *timestampTolerance, *importedRFC6962)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create deep checker", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return d
+}
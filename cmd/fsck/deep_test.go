@@ -0,0 +1,316 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/lax509"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+	"github.com/transparency-dev/tesseract/tesseracttest/chains"
+)
+
+// newEntry returns the entry logging chain at index idx, as read from an
+// entry bundle.
+func newEntry(t *testing.T, chain []*x509.Certificate, ts time.Time, idx uint64) *staticct.Entry {
+	t.Helper()
+	isPrecert, err := x509util.IsPrecertificate(chain[0])
+	if err != nil {
+		t.Fatalf("IsPrecertificate(): %v", err)
+	}
+	ce, err := x509util.EntryFromChain(chain, isPrecert, uint64(ts.UnixMilli()))
+	if err != nil {
+		t.Fatalf("EntryFromChain(): %v", err)
+	}
+	e := &staticct.Entry{}
+	if err := e.UnmarshalText(ce.LeafData(idx)); err != nil {
+		t.Fatalf("UnmarshalText(): %v", err)
+	}
+	return e
+}
+
+func TestCheckEntry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	f, err := chains.NewFactory(nil)
+	if err != nil {
+		t.Fatalf("NewFactory(): %v", err)
+	}
+	root, err := f.Root()
+	if err != nil {
+		t.Fatalf("Root(): %v", err)
+	}
+	inter, err := f.Intermediate(root)
+	if err != nil {
+		t.Fatalf("Intermediate(): %v", err)
+	}
+	otherInter, err := f.Intermediate(root)
+	if err != nil {
+		t.Fatalf("Intermediate(): %v", err)
+	}
+	preIssuer, err := f.PreIssuer(inter)
+	if err != nil {
+		t.Fatalf("PreIssuer(): %v", err)
+	}
+	otherRoot, err := f.Root()
+	if err != nil {
+		t.Fatalf("Root(): %v", err)
+	}
+	certChain, err := f.Chain(inter)
+	if err != nil {
+		t.Fatalf("Chain(): %v", err)
+	}
+	precertChain, err := f.PrecertChain(inter)
+	if err != nil {
+		t.Fatalf("PrecertChain(): %v", err)
+	}
+	preIssuedChain, err := f.PrecertChain(preIssuer)
+	if err != nil {
+		t.Fatalf("PrecertChain(): %v", err)
+	}
+
+	issuers := map[[32]byte][]byte{}
+	for _, c := range []*x509.Certificate{root.Cert, inter.Cert, otherInter.Cert, preIssuer.Cert} {
+		issuers[sha256.Sum256(c.Raw)] = c.Raw
+	}
+	// badFP is the fingerprint of an issuer whose stored certificate is
+	// another one.
+	badFP := sha256.Sum256([]byte("bad"))
+	issuers[badFP] = otherInter.Cert.Raw
+	readIssuer := func(_ context.Context, fp []byte) ([]byte, error) {
+		if der, ok := issuers[[32]byte(fp)]; ok {
+			return der, nil
+		}
+		return nil, fmt.Errorf("issuer %x: %w", fp, os.ErrNotExist)
+	}
+	roots := lax509.NewCertPool()
+	roots.AddCert(root.Cert)
+	otherRoots := lax509.NewCertPool()
+	otherRoots.AddCert(otherRoot.Cert)
+
+	const idx = 300
+	for _, test := range []struct {
+		desc string
+		// entry returns the entry to check, at index idx.
+		entry           func() *staticct.Entry
+		latest          time.Time
+		roots           *lax509.CertPool
+		importedRFC6962 bool
+		// want holds substrings of the expected problems, in order.
+		want []string
+	}{
+		{
+			desc:  "certificate",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now, idx) },
+			roots: roots,
+		},
+		{
+			desc:  "precertificate",
+			entry: func() *staticct.Entry { return newEntry(t, precertChain, now, idx) },
+			roots: roots,
+		},
+		{
+			desc:  "pre-issued precertificate",
+			entry: func() *staticct.Entry { return newEntry(t, preIssuedChain, now, idx) },
+			roots: roots,
+		},
+		{
+			desc:  "no chain verification",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now, idx) },
+		},
+		{
+			desc:  "wrong leaf_index",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now, idx+1) },
+			roots: roots,
+			want:  []string{"leaf_index SCT extension is 301"},
+		},
+		{
+			desc: "no leaf_index",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.RawExtensions, e.LeafIndex = "", 0
+				return e
+			},
+			roots: roots,
+			want:  []string{"no leaf_index SCT extension"},
+		},
+		{
+			desc: "no leaf_index, imported from RFC 6962 log",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.RawExtensions, e.LeafIndex = "", 0
+				return e
+			},
+			roots:           roots,
+			importedRFC6962: true,
+		},
+		{
+			desc:  "timestamp in the future",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now.Add(2*time.Hour), idx) },
+			roots: roots,
+			want:  []string{"is in the future"},
+		},
+		{
+			desc:  "timestamp in the future within tolerance",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now.Add(30*time.Minute), idx) },
+			roots: roots,
+		},
+		{
+			desc:   "timestamp before a previous entry",
+			entry:  func() *staticct.Entry { return newEntry(t, certChain, now.Add(-2*time.Hour), idx) },
+			latest: now,
+			roots:  roots,
+			want:   []string{"before the one of a previous entry"},
+		},
+		{
+			desc:   "timestamp before a previous entry within tolerance",
+			entry:  func() *staticct.Entry { return newEntry(t, certChain, now.Add(-30*time.Minute), idx) },
+			latest: now,
+			roots:  roots,
+		},
+		{
+			desc: "TBS of another precertificate",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, precertChain, now, idx)
+				e.Certificate = newEntry(t, preIssuedChain, now, idx).Certificate
+				return e
+			},
+			roots: roots,
+			want:  []string{"TBS doesn't match"},
+		},
+		{
+			desc: "pre-issued TBS with the pre-issuer as issuer",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, preIssuedChain, now, idx)
+				tbs, err := x509util.RemoveCTPoison(preIssuedChain[0].RawTBSCertificate)
+				if err != nil {
+					t.Fatalf("RemoveCTPoison(): %v", err)
+				}
+				e.Certificate = tbs
+				return e
+			},
+			roots: roots,
+			want:  []string{"TBS doesn't match"},
+		},
+		{
+			desc: "pre-issued with the pre-issuer's IssuerKeyHash",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, preIssuedChain, now, idx)
+				h := sha256.Sum256(preIssuer.Cert.RawSubjectPublicKeyInfo)
+				e.IssuerKeyHash = h[:]
+				return e
+			},
+			roots: roots,
+			want:  []string{"IssuerKeyHash is"},
+		},
+		{
+			desc: "IssuerKeyHash of another issuer",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, precertChain, now, idx)
+				h := sha256.Sum256(otherInter.Cert.RawSubjectPublicKeyInfo)
+				e.IssuerKeyHash = h[:]
+				return e
+			},
+			roots: roots,
+			want:  []string{"IssuerKeyHash is"},
+		},
+		{
+			desc: "chain in another order",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.FingerprintsChain[0], e.FingerprintsChain[1] = e.FingerprintsChain[1], e.FingerprintsChain[0]
+				return e
+			},
+			roots: roots,
+			want:  []string{"chain verifies, but not in the logged order"},
+		},
+		{
+			desc: "chain with another issuer",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.FingerprintsChain[0] = sha256.Sum256(otherInter.Cert.Raw)
+				return e
+			},
+			roots: roots,
+			want:  []string{"failed to verify chain"},
+		},
+		{
+			desc:  "chain to another root",
+			entry: func() *staticct.Entry { return newEntry(t, certChain, now, idx) },
+			roots: otherRoots,
+			want:  []string{"failed to verify chain"},
+		},
+		{
+			desc: "unavailable issuer",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.FingerprintsChain[0] = sha256.Sum256([]byte("missing"))
+				return e
+			},
+			roots: roots,
+		},
+		{
+			desc: "issuer not matching its fingerprint",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.FingerprintsChain[0] = badFP
+				return e
+			},
+			roots: roots,
+			want:  []string{"doesn't match its fingerprint"},
+		},
+		{
+			desc: "invalid leaf",
+			entry: func() *staticct.Entry {
+				e := newEntry(t, certChain, now, idx)
+				e.Certificate = []byte("not a certificate")
+				return e
+			},
+			roots: roots,
+			want:  []string{"failed to parse leaf certificate"},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			d := &deepChecker{
+				r:               &reporter{},
+				readIssuer:      readIssuer,
+				roots:           test.roots,
+				tolerance:       time.Hour,
+				importedRFC6962: test.importedRFC6962,
+			}
+			var latest uint64
+			if !test.latest.IsZero() {
+				latest = uint64(test.latest.UnixMilli())
+			}
+			got := d.checkEntry(ctx, idx, test.entry(), latest)
+			if len(got) != len(test.want) {
+				t.Fatalf("checkEntry() = %q, want %d problems matching %q", got, len(test.want), test.want)
+			}
+			for i, w := range test.want {
+				if !strings.Contains(got[i], w) {
+					t.Errorf("checkEntry()[%d] = %q, want it to contain %q", i, got[i], w)
+				}
+			}
+		})
+	}
+}
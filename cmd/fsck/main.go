@@ -13,6 +13,11 @@
 // limitations under the License.
 
 // fsck is a command-line tool for checking the integrity of a static-ct based log.
+//
+// It exits with status 0 if no problems were found, 2 if inconsistencies were
+// found in the log, 3 if some resources couldn't be fetched but no
+// inconsistencies were found, and 1 if it couldn't run. When a witness policy
+// is set, a checkpoint which doesn't satisfy it is an inconsistency.
 package main
 
 import (
@@ -30,13 +35,15 @@ import (
 	"sync"
 	"time"
 
-	tdnote "github.com/transparency-dev/formats/note"
 	"github.com/transparency-dev/merkle/rfc6962"
 	"github.com/transparency-dev/tessera/api/layout"
 	"github.com/transparency-dev/tessera/fsck"
+	"github.com/transparency-dev/tesseract/client"
 	"github.com/transparency-dev/tesseract/cmd/fsck/internal/tui"
-	"github.com/transparency-dev/tesseract/internal/client"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
 	"github.com/transparency-dev/tesseract/internal/logger"
+	"github.com/transparency-dev/tesseract/internal/source"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
 	"golang.org/x/crypto/cryptobyte"
 
 	"golang.org/x/mod/sumdb/note"
@@ -44,7 +51,7 @@ import (
 )
 
 var (
-	monitoringURL    = flag.String("monitoring_url", "", "Base tlog-tiles URL")
+	monitoringURL    = flag.String("monitoring_url", "", "Base tlog-tiles URL, or gs://bucket, s3://bucket or file:///path/to/log/ to read the log directly from its storage")
 	bearerToken      = flag.String("bearer_token", "", "The bearer token for authorizing HTTP requests to the storage URL, if needed")
 	N                = flag.Uint("N", 1, "The number of workers to use when fetching/comparing resources")
 	origin           = flag.String("origin", "", "Origin of the log to check")
@@ -53,8 +60,26 @@ var (
 	bundleCompressed = flag.Bool("bundle_compressed", false, "Enable decompression of entry bundles, useful for Sunlight logs")
 	ui               = flag.Bool("ui", true, "Set to true to use a TUI to display progress, or false for logging")
 	slogLevel        = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+
+	witnessPolicyFile   = flag.String("witness_policy_file", "", "Path to a witness policy file. If set, only checkpoints whose cosignatures satisfy the policy are checked, and the cosignature of each witness is logged. See cmd/tesseract/README.md#policy-file.")
+	checkpointSourceURL flagutil.MultiString
+
+	deep               = flag.Bool("deep", false, "Set to true to also re-validate the contents of every entry: leaf indices, timestamps, precertificate TBSs, issuer key hashes and chains")
+	rootsPEMFile       = flag.String("roots_pem_file", "", "Path to the file containing the roots that chains must verify to in --deep mode. If empty, chains aren't verified.")
+	timestampTolerance = flag.Duration("timestamp_tolerance", 24*time.Hour, "How far in the future, or before a previous entry in the same bundle, a timestamp can be in --deep mode")
+	importedRFC6962    = flag.Bool("imported_rfc6962", false, "Set to true if the log holds entries imported from an RFC 6962 log, e.g. with cmd/experimental/migrate --source_rfc6962. In --deep mode, entries without a leaf_index SCT extension are then accepted.")
+
+	reportFile       = flag.String("report", "", "Path to a JSONL file to append every problem found to, with its kind, entry index, resource path and error")
+	startIndex       = flag.Uint64("start", 0, "Index of the first entry to check. Setting --start, --end or --progress_file checks the log without the TUI.")
+	endIndex         = flag.Uint64("end", 0, "Index after the last entry to check, or 0 to check up to the log's checkpoint")
+	progressFile     = flag.String("progress_file", "", "Path to a file to periodically save progress to, and to resume an interrupted check of the same --start and --end range from")
+	progressInterval = flag.Duration("progress_interval", 30*time.Second, "How often to log and save progress, when checking a range of the log")
 )
 
+func init() {
+	flag.Var(&checkpointSourceURL, "checkpoint_source_url", "Root URL of a distributor of cosigned checkpoints for the log (can be specified multiple times), e.g. https://distributor.example.com/log/ (optional, requires --witness_policy_file)")
+}
+
 const (
 	userAgent = "TesseraCT fsck"
 )
@@ -68,44 +93,107 @@ func main() {
 	flag.Parse()
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
 	ctx, cancel := context.WithCancel(context.Background())
-	src := fetcherFromFlags()
+	r, err := newReporter(*reportFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create reporter", slog.Any("error", err))
+		os.Exit(exitError)
+	}
 	v := verifierFromFlags()
+	rf := &reportingFetcher{fetcher: fetcherFromFlags(), r: r}
+	src := witnessedFetcherFromFlags(rf, v)
+	if w, ok := src.(*witnessedFetcher); ok {
+		// There's no point checking a tree the witnesses haven't seen.
+		if err := w.checkCosignatures(ctx); err != nil {
+			if rf.failures.Load() == 0 {
+				r.report(ctx, problem{Kind: kindCorrupt, Resource: layout.CheckpointPath, Error: err.Error()})
+			}
+			finish(ctx, r)
+		}
+	}
+	if d := deepCheckerFromFlags(r, src); d != nil {
+		src = &deepFetcher{fetcher: src, d: d}
+	}
 	lsc := newLogStateCollector(*N)
-	f := fsck.New(*origin, v, src, lsc.merkleLeafHasher(), fsck.Opts{N: *N})
+
+	// Tessera's fsck checks the whole log, and reports its progress to the
+	// TUI. Ranges are checked by a rangeChecker instead.
+	var f *fsck.Fsck
+	check := func(ctx context.Context) error {
+		rc := &rangeChecker{
+			src:              src,
+			hasher:           lsc.merkleLeafHasher(),
+			r:                r,
+			N:                *N,
+			progressFile:     *progressFile,
+			progressInterval: *progressInterval,
+		}
+		return rc.Check(ctx, v, *origin, *startIndex, *endIndex)
+	}
+	if *startIndex == 0 && *endIndex == 0 && *progressFile == "" {
+		f = fsck.New(*origin, v, src, lsc.merkleLeafHasher(), fsck.Opts{N: *N})
+		check = f.Check
+	}
 	eg := errgroup.Group{}
 	eg.Go(func() error {
 		defer lsc.Close()
 		defer cancel()
-		return f.Check(ctx)
+		return check(ctx)
 
 	})
 	eg.Go(func() error {
-		return lsc.checkIssuersTask(ctx, src.ReadIssuer, *N)
+		lsc.checkIssuersTask(ctx, src.ReadIssuer, *N, r)
+		return nil
 	})
 
-	if *ui {
+	if *ui && f != nil {
 		if err := tui.RunApp(ctx, f); err != nil {
 			slog.ErrorContext(ctx, "App exited", slog.Any("error", err))
 		}
 		// User may have exited the UI, cancel the context to signal to everything else.
 		cancel()
 	} else {
+	wait:
 		for {
 			select {
 			case <-ctx.Done():
-				return
+				break wait
 			case <-time.After(time.Second):
-				slog.DebugContext(ctx, "Ranges", slog.Any("status", f.Status()))
+				if f != nil {
+					slog.DebugContext(ctx, "Ranges", slog.Any("status", f.Status()))
+				}
 			}
 		}
 	}
 
 	if err := eg.Wait(); err != nil {
-		slog.ErrorContext(ctx, "FAILED", slog.Any("error", err))
-		os.Exit(1)
+		switch {
+		case errors.Is(err, context.Canceled):
+			slog.ErrorContext(ctx, "Check interrupted", slog.Any("error", err))
+			os.Exit(exitError)
+		case rf.failures.Load() > 0:
+			// The check most likely failed because of these, which have
+			// already been reported.
+			slog.WarnContext(ctx, "Check failed", slog.Any("error", err))
+		default:
+			r.report(ctx, problem{Kind: kindCorrupt, Error: err.Error()})
+		}
+	}
+	finish(ctx, r)
+}
+
+// finish closes r, and exits with the status matching the problems reported.
+func finish(ctx context.Context, r *reporter) {
+	if err := r.Close(); err != nil {
+		slog.ErrorContext(ctx, "Failed to close report", slog.Any("error", err))
+	}
+	if code := r.exitCode(); code != exitOK {
+		corrupt, fetch := r.counts()
+		slog.ErrorContext(ctx, "FAILED", slog.Int("inconsistencies", corrupt), slog.Int("fetch_failures", fetch))
+		os.Exit(code)
 	}
 
 	slog.InfoContext(ctx, "OK")
+	os.Exit(exitOK)
 }
 
 // logStateCollector tracks state of the target log which needs to be later checked.
@@ -130,29 +218,14 @@ func (l *logStateCollector) Close() {
 	close(l.issuersToCheck)
 }
 
-// checkIssuersTask reads looks up discovered issuer fingerprints in the target log's issuer CAS.
+// checkIssuersTask reads looks up discovered issuer fingerprints in the target log's issuer CAS, and
+// reports those which can't be read.
 //
 // This is a long-running function, it will only exit once Close has been called, and all remaining fingerprints in the
 // issuersToCheck channel have been checked.
-func (l *logStateCollector) checkIssuersTask(ctx context.Context, readIssuer func(context.Context, []byte) ([]byte, error), N uint) error {
+func (l *logStateCollector) checkIssuersTask(ctx context.Context, readIssuer func(context.Context, []byte) ([]byte, error), N uint, r *reporter) {
 	slog.InfoContext(ctx, "Checking issuers CAS")
 
-	// done will be closed when the function is ready to return
-	done := make(chan struct{})
-	// errC carries any errors encountered when checking for issuers.
-	errC := make(chan error)
-
-	// Start aggregating any errors coming over errC into a slice of errors - we'll Join() any errors in there
-	// when we return.
-	errs := []error{}
-	go func() {
-		// Signal that we're done draining errors when we return.
-		defer close(done)
-		for e := range errC {
-			errs = append(errs, e)
-		}
-	}()
-
 	// Kick off a bunch of workers to do the actual checks.
 	wg := sync.WaitGroup{}
 	for range N {
@@ -161,8 +234,7 @@ func (l *logStateCollector) checkIssuersTask(ctx context.Context, readIssuer fun
 			defer wg.Done()
 			for fp := range l.issuersToCheck {
 				if _, err := readIssuer(ctx, fp); err != nil {
-					slog.WarnContext(ctx, "Couldn't fetch issuer", slog.String("fp", fmt.Sprintf("%x", fp)), slog.Any("error", err))
-					errC <- fmt.Errorf("couldn't fetch issuer for %x: %v", fp, err)
+					r.reportFetch(ctx, issuerPath(fp), nil, fmt.Errorf("couldn't fetch issuer, see cmd/repair_issuers to backfill it: %w", err))
 					continue
 				}
 				logger.DebugExtraContext(ctx, "Issuer is present", slog.String("fp", fmt.Sprintf("%x", fp)))
@@ -170,14 +242,6 @@ func (l *logStateCollector) checkIssuersTask(ctx context.Context, readIssuer fun
 		}()
 	}
 	wg.Wait()
-
-	// Workers are done, so let the aggregator know there are no more errors to collect.
-	close(errC)
-
-	// Wait for the aggregator to drain all errors
-	<-done
-	// And return them.
-	return errors.Join(errs...)
 }
 
 // addIssuers adds the issuers in the provided byte string to the set of issuer to be checked.
@@ -297,19 +361,13 @@ func copyUint24LengthPrefixed(from *cryptobyte.String, to *cryptobyte.Builder) b
 }
 
 func fetcherFromFlags() fetcher {
+	ctx := context.Background()
 	logURL, err := url.Parse(*monitoringURL)
 	if err != nil {
-		slog.ErrorContext(context.Background(), "Invalid --storage_url", slog.String("url", *monitoringURL), slog.Any("error", err))
+		slog.ErrorContext(ctx, "Invalid --monitoring_url", slog.String("url", *monitoringURL), slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	if logURL.Scheme == "file" {
-		return &client.FileFetcher{
-			Root:              logURL.Path,
-			DecompressBundles: *bundleCompressed,
-		}
-	}
-
 	hc := &http.Client{
 		Transport: &http.Transport{
 			MaxIdleConns:        int(*N),
@@ -318,23 +376,125 @@ func fetcherFromFlags() fetcher {
 		},
 		Timeout: 30 * time.Second,
 	}
-	src, err := client.NewHTTPFetcher(logURL, hc)
+	src, err := source.New(ctx, logURL, hc)
 	if err != nil {
-		slog.ErrorContext(context.Background(), "Failed to create HTTP fetcher", slog.Any("error", err))
+		slog.ErrorContext(ctx, "Failed to create fetcher", slog.String("url", *monitoringURL), slog.Any("error", err))
 		os.Exit(1)
 	}
-	src.EnableRetries(10)
-	ua := userAgent
-	if *userAgentInfo != "" {
-		ua = fmt.Sprintf("%s (%s)", userAgent, *userAgentInfo)
-	}
-	src.SetUserAgent(ua)
-	if *bearerToken != "" {
-		src.SetAuthorizationHeader(fmt.Sprintf("Bearer %s", *bearerToken))
+	switch f := src.(type) {
+	case *client.FileFetcher:
+		f.DecompressBundles = *bundleCompressed
+	case *client.HTTPFetcher:
+		f.EnableRetries(10)
+		f.SetUserAgent(userAgentFromFlags())
+		if *bearerToken != "" {
+			f.SetAuthorizationHeader(fmt.Sprintf("Bearer %s", *bearerToken))
+		}
 	}
 	return src
 }
 
+// witnessedFetcher is a fetcher which returns a checkpoint agreed upon by
+// consensus, rather than the one served by the log.
+//
+// The checkpoint is only fetched once, so that the tree which is checked is
+// the one whose cosignatures were checked.
+type witnessedFetcher struct {
+	fetcher
+	consensus client.ConsensusCheckpointFunc
+	policy    client.WitnessPolicy
+	logSigV   note.Verifier
+
+	once  sync.Once
+	cpRaw []byte
+	err   error
+}
+
+func (f *witnessedFetcher) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	f.once.Do(func() {
+		_, f.cpRaw, _, f.err = f.consensus(ctx, f.logSigV, *origin)
+	})
+	return f.cpRaw, f.err
+}
+
+// checkCosignatures logs which witnesses cosigned the checkpoint to check,
+// and how long ago they did.
+//
+// It returns an error if no checkpoint satisfies the witness policy.
+func (f *witnessedFetcher) checkCosignatures(ctx context.Context) error {
+	cpRaw, err := f.ReadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	cs, err := client.Cosignatures(f.policy, cpRaw)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, c := range cs {
+		if !c.Signed {
+			slog.WarnContext(ctx, "Witness didn't cosign checkpoint", slog.String("witness", c.Name), slog.String("url", c.URL))
+			continue
+		}
+		slog.InfoContext(ctx, "Witness cosigned checkpoint", slog.String("witness", c.Name), slog.String("url", c.URL), slog.Time("timestamp", c.Timestamp), slog.Duration("age", now.Sub(c.Timestamp).Truncate(time.Second)))
+	}
+	return nil
+}
+
+// witnessedFetcherFromFlags wraps src so that only checkpoints satisfying
+// --witness_policy_file are checked, if set.
+//
+// Checkpoints are then fetched from the log and from each
+// --checkpoint_source_url.
+func witnessedFetcherFromFlags(src fetcher, logSigV note.Verifier) fetcher {
+	ctx := context.Background()
+	if *witnessPolicyFile == "" {
+		if len(checkpointSourceURL) > 0 {
+			slog.ErrorContext(ctx, "--checkpoint_source_url requires --witness_policy_file")
+			os.Exit(1)
+		}
+		return src
+	}
+	p, err := os.ReadFile(*witnessPolicyFile)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read witness policy file", slog.String("path", *witnessPolicyFile), slog.Any("error", err))
+		os.Exit(1)
+	}
+	wp, err := client.ParseWitnessPolicy(p)
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid witness policy", slog.Any("error", err))
+		os.Exit(1)
+	}
+	fs := []client.CheckpointFetcherFunc{src.ReadCheckpoint}
+	for _, s := range checkpointSourceURL {
+		u, err := url.Parse(s)
+		if err != nil {
+			slog.ErrorContext(ctx, "Invalid --checkpoint_source_url", slog.String("url", s), slog.Any("error", err))
+			os.Exit(1)
+		}
+		f, err := client.NewHTTPFetcher(u, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create HTTP fetcher", slog.String("url", s), slog.Any("error", err))
+			os.Exit(1)
+		}
+		f.SetUserAgent(userAgentFromFlags())
+		fs = append(fs, f.ReadCheckpoint)
+	}
+	return &witnessedFetcher{
+		fetcher:   src,
+		consensus: client.WitnessedConsensus(wp, fs...),
+		policy:    wp,
+		logSigV:   logSigV,
+	}
+}
+
+func userAgentFromFlags() string {
+	if *userAgentInfo != "" {
+		return fmt.Sprintf("%s (%s)", userAgent, *userAgentInfo)
+	}
+	return userAgent
+}
+
 func verifierFromFlags() note.Verifier {
 	ctx := context.Background()
 	if *origin == "" {
@@ -356,18 +516,13 @@ func verifierFromFlags() note.Verifier {
 		os.Exit(1)
 	}
 
-	verifierKey, err := tdnote.RFC6962VerifierString(*origin, pub)
-	if err != nil {
-		slog.ErrorContext(ctx, "Error creating RFC6962 verifier string", slog.Any("error", err))
-		os.Exit(1)
-	}
-	logSigV, err := tdnote.NewVerifier(verifierKey)
+	logSigV, err := staticct.NewCheckpointVerifier(*origin, pub)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error creating verifier", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	slog.InfoContext(ctx, "Using verifier", slog.String("key", verifierKey))
+	slog.InfoContext(ctx, "Using verifier", slog.String("name", logSigV.Name()), slog.String("key_hash", fmt.Sprintf("%08x", logSigV.KeyHash())))
 
 	return logSigV
 }
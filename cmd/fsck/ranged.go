@@ -0,0 +1,319 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/tessera/api"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/client"
+	"golang.org/x/mod/sumdb/note"
+	"golang.org/x/sync/errgroup"
+)
+
+// bundlesPerChunk is the number of consecutive entry bundles checked together
+// by a rangeChecker worker, and the granularity at which progress is saved.
+const bundlesPerChunk = 64
+
+// progress is the state persisted by a rangeChecker, to resume a check.
+type progress struct {
+	Origin string `json:"origin"`
+	// Start and End are the --start and --end flags of the check, which is
+	// only resumed with the same ones.
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// Size and Hash are the ones of the checkpoint entries were checked against.
+	Size uint64 `json:"size"`
+	Hash []byte `json:"hash"`
+	// Next is the index of the first entry which might not have been checked
+	// yet. All the entries before it, from the start of the range, have been.
+	Next uint64 `json:"next"`
+}
+
+// rangeChecker checks that the entry bundles covering a range of the log
+// match their level 0 tiles, and are committed to by the log's checkpoint.
+//
+// Unlike Tessera's fsck, it can check a subset of the log, and persist its
+// progress so that an interrupted check can be resumed.
+type rangeChecker struct {
+	src    fetcher
+	hasher func([]byte) ([][]byte, error)
+	r      *reporter
+	N      uint
+	// progressFile is where progress is persisted every progressInterval,
+	// if set.
+	progressFile     string
+	progressInterval time.Duration
+
+	cp log.Checkpoint
+	// start and endFlag are the requested range, as passed to Check.
+	start       uint64
+	endFlag     uint64
+	firstBundle uint64
+	endBundle   uint64
+	end         uint64
+	checked     atomic.Uint64
+
+	mu sync.Mutex
+	// nextChunk is the first chunk which hasn't been fully checked.
+	nextChunk uint64
+	// doneChunks holds the chunks after nextChunk which have been fully checked.
+	doneChunks map[uint64]bool
+}
+
+// Check checks the entries in [start, end) of the log, or up to the size of
+// its checkpoint if end is 0.
+//
+// If progressFile holds the progress of a previous check of the log, entries
+// which have already been checked are skipped.
+func (c *rangeChecker) Check(ctx context.Context, v note.Verifier, origin string, start, end uint64) error {
+	cp, _, _, err := client.FetchCheckpoint(ctx, c.src.ReadCheckpoint, v, origin)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	c.cp = *cp
+	c.start, c.endFlag = start, end
+	if end == 0 || end > cp.Size {
+		end = cp.Size
+	}
+	if next, ok := c.resume(ctx, origin); ok && next > start {
+		slog.InfoContext(ctx, "Resuming check", slog.Uint64("from", next))
+		start = next
+	}
+	if start >= end {
+		slog.InfoContext(ctx, "Nothing to check", slog.Uint64("start", start), slog.Uint64("end", end))
+		return nil
+	}
+	c.end = end
+	c.firstBundle = start / layout.EntryBundleWidth
+	c.endBundle = (end + layout.EntryBundleWidth - 1) / layout.EntryBundleWidth
+	c.doneChunks = map[uint64]bool{}
+	slog.InfoContext(ctx, "Checking range", slog.Uint64("start", start), slog.Uint64("end", end), slog.Uint64("checkpoint_size", cp.Size))
+
+	done := make(chan struct{})
+	go func() {
+		t := time.NewTicker(c.progressInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				c.saveProgress(ctx, origin)
+			}
+		}
+	}()
+
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(int(c.N))
+	for k := uint64(0); c.firstBundle+k*bundlesPerChunk < c.endBundle; k++ {
+		eg.Go(func() error {
+			if c.checkChunk(ectx, k) {
+				c.chunkDone(k)
+			}
+			return ectx.Err()
+		})
+	}
+	err = eg.Wait()
+	close(done)
+	c.saveProgress(ctx, origin)
+	return err
+}
+
+// checkChunk checks the entry bundles of the k-th chunk of the range, and
+// returns whether they could all be fetched.
+func (c *rangeChecker) checkChunk(ctx context.Context, k uint64) bool {
+	fetchErrs := 0
+	// tiles holds the level 0 tiles read while checking this chunk, so that
+	// they're not read again to build inclusion proofs.
+	tiles := map[uint64][]byte{}
+	readTile := func(ctx context.Context, l, i uint64, p uint8) ([]byte, error) {
+		if t, ok := tiles[i]; ok && l == 0 {
+			return t, nil
+		}
+		t, err := c.src.ReadTile(ctx, l, i, p)
+		if err != nil {
+			fetchErrs++
+		}
+		return t, err
+	}
+	pb, err := client.NewProofBuilder(ctx, c.cp, readTile)
+	if err != nil {
+		if fetchErrs == 0 {
+			c.r.report(ctx, problem{Kind: kindCorrupt, Resource: layout.CheckpointPath, Error: fmt.Sprintf("checkpoint isn't consistent with tiles: %v", err)})
+		}
+		return false
+	}
+
+	from := c.firstBundle + k*bundlesPerChunk
+	to := min(from+bundlesPerChunk, c.endBundle)
+	for i := from; i < to; i++ {
+		p := layout.PartialTileSize(0, i, c.cp.Size)
+		bundle, err := c.src.ReadEntryBundle(ctx, i, p)
+		if err != nil {
+			fetchErrs++
+			continue
+		}
+		tileRaw, err := readTile(ctx, 0, i, p)
+		if err != nil {
+			continue
+		}
+		tiles[i] = tileRaw
+		c.checkBundle(ctx, pb, i, p, bundle, tileRaw, &fetchErrs)
+		delete(tiles, i)
+		c.checked.Add(1)
+	}
+	return fetchErrs == 0
+}
+
+// checkBundle checks that the leaf hashes of bundle i match the ones in
+// its level 0 tile, and that they're committed to by the checkpoint.
+func (c *rangeChecker) checkBundle(ctx context.Context, pb *client.ProofBuilder, i uint64, p uint8, bundle, tileRaw []byte, fetchErrs *int) {
+	first := i * layout.EntryBundleWidth
+	hashes, err := c.hasher(bundle)
+	if err != nil {
+		c.r.report(ctx, problem{Kind: kindCorrupt, Index: &first, Resource: entryBundlePath(i, p), Error: fmt.Sprintf("invalid entry bundle: %v", err)})
+		return
+	}
+	tile := api.HashTile{}
+	if err := tile.UnmarshalText(tileRaw); err != nil {
+		c.r.report(ctx, problem{Kind: kindCorrupt, Index: &first, Resource: layout.TilePath(0, i, p), Error: fmt.Sprintf("invalid tile: %v", err)})
+		return
+	}
+	if len(hashes) != len(tile.Nodes) {
+		c.r.report(ctx, problem{Kind: kindCorrupt, Index: &first, Resource: entryBundlePath(i, p), Error: fmt.Sprintf("entry bundle has %d entries, but its tile has %d hashes", len(hashes), len(tile.Nodes))})
+		return
+	}
+	for j, h := range hashes {
+		if !bytes.Equal(h, tile.Nodes[j]) {
+			idx := first + uint64(j)
+			c.r.report(ctx, problem{Kind: kindCorrupt, Index: &idx, Resource: layout.TilePath(0, i, p), Error: fmt.Sprintf("tile has leaf hash %x, but entry hashes to %x", tile.Nodes[j], h)})
+		}
+	}
+
+	// The inclusion proof of the first entry of the bundle starts with hashes
+	// from its tile, so checking it also checks the rest of the tile.
+	before := *fetchErrs
+	hs, err := pb.InclusionProof(ctx, first)
+	if err != nil {
+		if *fetchErrs == before {
+			c.r.report(ctx, problem{Kind: kindCorrupt, Index: &first, Resource: layout.TilePath(0, i, p), Error: fmt.Sprintf("failed to build inclusion proof: %v", err)})
+		}
+		return
+	}
+	if err := proof.VerifyInclusion(rfc6962.DefaultHasher, first, c.cp.Size, hashes[0], hs, c.cp.Hash); err != nil {
+		c.r.report(ctx, problem{Kind: kindCorrupt, Index: &first, Resource: layout.TilePath(0, i, p), Error: fmt.Sprintf("tile isn't committed to by the checkpoint: %v", err)})
+	}
+}
+
+// chunkDone records that chunk k has been fully checked.
+func (c *rangeChecker) chunkDone(k uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.doneChunks[k] = true
+	for c.doneChunks[c.nextChunk] {
+		delete(c.doneChunks, c.nextChunk)
+		c.nextChunk++
+	}
+}
+
+// next returns the index of the first entry which might not have been
+// checked yet.
+func (c *rangeChecker) next() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return min((c.firstBundle+c.nextChunk*bundlesPerChunk)*layout.EntryBundleWidth, c.end)
+}
+
+// resume returns where to resume the check from, if progressFile holds the
+// progress of a previous check of the same range of the log, consistent with
+// c.cp.
+func (c *rangeChecker) resume(ctx context.Context, origin string) (uint64, bool) {
+	if c.progressFile == "" {
+		return 0, false
+	}
+	b, err := os.ReadFile(c.progressFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false
+	}
+	p := progress{}
+	if err == nil {
+		err = json.Unmarshal(b, &p)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Ignoring unreadable progress file", slog.String("path", c.progressFile), slog.Any("error", err))
+		return 0, false
+	}
+	if p.Origin != origin {
+		slog.WarnContext(ctx, "Ignoring progress file of another log", slog.String("path", c.progressFile), slog.String("origin", p.Origin))
+		return 0, false
+	}
+	if p.Start != c.start || p.End != c.endFlag {
+		slog.WarnContext(ctx, "Ignoring progress file of a check of another range", slog.String("path", c.progressFile), slog.Uint64("start", p.Start), slog.Uint64("end", p.End))
+		return 0, false
+	}
+	// Entries were checked against the checkpoint of the previous run, which
+	// must be consistent with the one of this run for them to be skipped.
+	prev := log.Checkpoint{Origin: p.Origin, Size: p.Size, Hash: p.Hash}
+	if err := client.CheckConsistency(ctx, c.src.ReadTile, []log.Checkpoint{prev, c.cp}); err != nil {
+		c.r.report(ctx, problem{Kind: kindCorrupt, Resource: layout.CheckpointPath, Error: fmt.Sprintf("checkpoint isn't consistent with the one of size %d of the previous run: %v", p.Size, err)})
+		return 0, false
+	}
+	return p.Next, true
+}
+
+// saveProgress atomically writes the current progress to progressFile, if set.
+func (c *rangeChecker) saveProgress(ctx context.Context, origin string) {
+	next := c.next()
+	slog.InfoContext(ctx, "Progress", slog.Uint64("next", next), slog.Uint64("end", c.end), slog.Uint64("bundles_checked", c.checked.Load()))
+	if c.progressFile == "" {
+		return
+	}
+	b, err := json.Marshal(progress{Origin: origin, Start: c.start, End: c.endFlag, Size: c.cp.Size, Hash: c.cp.Hash, Next: next})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to marshal progress", slog.Any("error", err))
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.progressFile), ".progress-")
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to save progress", slog.Any("error", err))
+		return
+	}
+	_, err = tmp.Write(b)
+	if cErr := tmp.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.progressFile)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		slog.ErrorContext(ctx, "Failed to save progress", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/merkle/testonly"
+	"github.com/transparency-dev/tessera/api"
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+// tileFetcher serves the level 0 tiles of a tree smaller than a tile.
+type tileFetcher struct {
+	tree *testonly.Tree
+}
+
+func (f tileFetcher) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (f tileFetcher) ReadTile(ctx context.Context, l, i uint64, p uint8) ([]byte, error) {
+	if l != 0 || i != 0 || uint64(p) > f.tree.Size() {
+		return nil, fmt.Errorf("tile %d/%d.p/%d: %w", l, i, p, os.ErrNotExist)
+	}
+	t := api.HashTile{}
+	for j := range uint64(p) {
+		t.Nodes = append(t.Nodes, f.tree.LeafHash(j))
+	}
+	return t.MarshalText()
+}
+
+func (f tileFetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (f tileFetcher) ReadIssuer(ctx context.Context, fp []byte) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func TestResume(t *testing.T) {
+	ctx := context.Background()
+	tree := testonly.New(rfc6962.DefaultHasher)
+	for i := range 10 {
+		tree.AppendData([]byte{byte(i)})
+	}
+	const origin = "example.com/log"
+	cp := log.Checkpoint{Origin: origin, Size: 10, Hash: tree.Hash()}
+
+	for _, test := range []struct {
+		desc        string
+		progress    *progress
+		raw         string
+		start, end  uint64
+		wantNext    uint64
+		wantOK      bool
+		wantCorrupt int
+	}{
+		{
+			desc: "no progress file",
+		},
+		{
+			desc: "unreadable progress file",
+			raw:  "not JSON",
+		},
+		{
+			desc:     "same range",
+			progress: &progress{Origin: origin, Start: 2, End: 8, Size: 10, Hash: tree.Hash(), Next: 5},
+			start:    2,
+			end:      8,
+			wantNext: 5,
+			wantOK:   true,
+		},
+		{
+			desc:     "consistent smaller checkpoint",
+			progress: &progress{Origin: origin, Size: 6, Hash: tree.HashAt(6), Next: 4},
+			wantNext: 4,
+			wantOK:   true,
+		},
+		{
+			desc:     "another log",
+			progress: &progress{Origin: "example.com/other", Size: 10, Hash: tree.Hash(), Next: 5},
+		},
+		{
+			desc:     "another start",
+			progress: &progress{Origin: origin, Start: 1, End: 8, Size: 10, Hash: tree.Hash(), Next: 5},
+			start:    2,
+			end:      8,
+		},
+		{
+			desc:     "another end",
+			progress: &progress{Origin: origin, Start: 2, Size: 10, Hash: tree.Hash(), Next: 5},
+			start:    2,
+			end:      8,
+		},
+		{
+			desc:        "inconsistent checkpoint",
+			progress:    &progress{Origin: origin, Size: 6, Hash: tree.HashAt(5), Next: 4},
+			wantCorrupt: 1,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "progress.json")
+			raw := []byte(test.raw)
+			if test.progress != nil {
+				var err error
+				if raw, err = json.Marshal(test.progress); err != nil {
+					t.Fatalf("Marshal(): %v", err)
+				}
+			}
+			if len(raw) > 0 {
+				if err := os.WriteFile(path, raw, 0o644); err != nil {
+					t.Fatalf("WriteFile(): %v", err)
+				}
+			}
+			c := &rangeChecker{
+				src:          tileFetcher{tree: tree},
+				r:            &reporter{},
+				progressFile: path,
+				cp:           cp,
+				start:        test.start,
+				endFlag:      test.end,
+			}
+			next, ok := c.resume(ctx, origin)
+			if next != test.wantNext || ok != test.wantOK {
+				t.Errorf("resume() = %d, %t, want %d, %t", next, ok, test.wantNext, test.wantOK)
+			}
+			if corrupt, _ := c.r.counts(); corrupt != test.wantCorrupt {
+				t.Errorf("resume() reported %d problems, want %d", corrupt, test.wantCorrupt)
+			}
+		})
+	}
+}
+
+func TestSaveProgress(t *testing.T) {
+	ctx := context.Background()
+	tree := testonly.New(rfc6962.DefaultHasher)
+	for i := range 10 {
+		tree.AppendData([]byte{byte(i)})
+	}
+	path := filepath.Join(t.TempDir(), "progress.json")
+	cp := log.Checkpoint{Origin: "example.com/log", Size: 10, Hash: tree.Hash()}
+	c := &rangeChecker{
+		src:          tileFetcher{tree: tree},
+		r:            &reporter{},
+		progressFile: path,
+		cp:           cp,
+		start:        2,
+		endFlag:      8,
+		end:          8,
+		doneChunks:   map[uint64]bool{},
+	}
+	c.chunkDone(0)
+	c.saveProgress(ctx, cp.Origin)
+
+	// Another check of the same range resumes from the saved progress.
+	r := &rangeChecker{
+		src:          c.src,
+		r:            &reporter{},
+		progressFile: path,
+		cp:           cp,
+		start:        2,
+		endFlag:      8,
+	}
+	if next, ok := r.resume(ctx, cp.Origin); !ok || next != 8 {
+		t.Errorf("resume() = %d, %t, want 8, true", next, ok)
+	}
+	// One of another range doesn't.
+	r.endFlag = 0
+	if _, ok := r.resume(ctx, cp.Origin); ok {
+		t.Error("resume() of another range succeeded")
+	}
+}
+
+func TestChunkDone(t *testing.T) {
+	chunkEntries := uint64(bundlesPerChunk * layout.EntryBundleWidth)
+	c := &rangeChecker{
+		firstBundle: 3,
+		endBundle:   3 + 3*bundlesPerChunk,
+		end:         3*layout.EntryBundleWidth + 3*chunkEntries - 10,
+		doneChunks:  map[uint64]bool{},
+	}
+	first := uint64(3 * layout.EntryBundleWidth)
+
+	for _, step := range []struct {
+		done     uint64
+		wantNext uint64
+	}{
+		// Chunks completed out of order don't move next forward, until all
+		// the ones before them are done.
+		{done: 1, wantNext: first},
+		{done: 0, wantNext: first + 2*chunkEntries},
+		{done: 2, wantNext: c.end},
+	} {
+		c.chunkDone(step.done)
+		if got := c.next(); got != step.wantNext {
+			t.Errorf("after chunkDone(%d): next() = %d, want %d", step.done, got, step.wantNext)
+		}
+	}
+	if len(c.doneChunks) != 0 {
+		t.Errorf("doneChunks = %v, want empty", c.doneChunks)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+// Exit codes of fsck.
+const (
+	// exitOK means that no problems were found.
+	exitOK = 0
+	// exitError means that fsck couldn't run, e.g. because of invalid flags.
+	exitError = 1
+	// exitCorrupt means that inconsistencies were found in the log.
+	exitCorrupt = 2
+	// exitFetchFailed means that no inconsistencies were found, but some
+	// resources couldn't be fetched, so the log might not be fully checked.
+	exitFetchFailed = 3
+)
+
+type problemKind string
+
+const (
+	// kindCorrupt is an inconsistency in the log, including missing resources.
+	kindCorrupt problemKind = "corrupt"
+	// kindFetch is a failure to fetch a resource, other than it not existing.
+	kindFetch problemKind = "fetch"
+)
+
+// problem is an entry of the --report file.
+type problem struct {
+	Kind problemKind `json:"kind"`
+	// Index is the index of the first entry affected by the problem, if any.
+	Index *uint64 `json:"index,omitempty"`
+	// Resource is the path of the resource with the problem, relative to the
+	// log's monitoring URL, if any.
+	Resource string `json:"resource,omitempty"`
+	Error    string `json:"error"`
+}
+
+// reporter logs problems, counts them, and writes them to a JSONL file if set.
+type reporter struct {
+	mu      sync.Mutex
+	f       *os.File
+	enc     *json.Encoder
+	corrupt int
+	fetch   int
+}
+
+// newReporter returns a reporter which appends problems to the file at path,
+// or only logs them if path is empty.
+//
+// Problems are appended, so that a resumed check adds to the report of the
+// interrupted one.
+func newReporter(path string) (*reporter, error) {
+	r := &reporter{}
+	if path == "" {
+		return r, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open report file: %v", err)
+	}
+	r.f, r.enc = f, json.NewEncoder(f)
+	return r, nil
+}
+
+func (r *reporter) report(ctx context.Context, p problem) {
+	attrs := []any{slog.String("kind", string(p.Kind)), slog.String("resource", p.Resource), slog.String("error", p.Error)}
+	if p.Index != nil {
+		attrs = append(attrs, slog.Uint64("index", *p.Index))
+	}
+	slog.WarnContext(ctx, "Found problem", attrs...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch p.Kind {
+	case kindCorrupt:
+		r.corrupt++
+	case kindFetch:
+		r.fetch++
+	}
+	if r.enc != nil {
+		if err := r.enc.Encode(p); err != nil {
+			slog.ErrorContext(ctx, "Failed to write problem to report", slog.Any("error", err))
+		}
+	}
+}
+
+// reportFetch reports err, returned when fetching resource, as missing
+// resource if it's os.ErrNotExist, and as a fetch failure otherwise.
+func (r *reporter) reportFetch(ctx context.Context, resource string, index *uint64, err error) {
+	p := problem{Kind: kindFetch, Index: index, Resource: resource, Error: err.Error()}
+	if errors.Is(err, os.ErrNotExist) {
+		p.Kind = kindCorrupt
+	}
+	r.report(ctx, p)
+}
+
+// counts returns the number of inconsistencies and fetch failures reported
+// so far.
+func (r *reporter) counts() (corrupt, fetch int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.corrupt, r.fetch
+}
+
+// exitCode returns the exit code corresponding to the problems reported.
+func (r *reporter) exitCode() int {
+	corrupt, fetch := r.counts()
+	switch {
+	case corrupt > 0:
+		return exitCorrupt
+	case fetch > 0:
+		return exitFetchFailed
+	default:
+		return exitOK
+	}
+}
+
+// Close closes the report file, if any.
+func (r *reporter) Close() error {
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Close()
+}
+
+// reportingFetcher is a fetcher which reports failures to read checkpoints,
+// tiles and entry bundles.
+//
+// Issuers are reported by checkIssuersTask instead, which reads each of them
+// only once.
+type reportingFetcher struct {
+	fetcher
+	r *reporter
+	// failures is the number of failures reported so far.
+	failures atomic.Int64
+}
+
+func (f *reportingFetcher) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	b, err := f.fetcher.ReadCheckpoint(ctx)
+	if err != nil {
+		f.reportFetch(ctx, layout.CheckpointPath, nil, err)
+	}
+	return b, err
+}
+
+func (f *reportingFetcher) ReadTile(ctx context.Context, l, i uint64, p uint8) ([]byte, error) {
+	b, err := f.fetcher.ReadTile(ctx, l, i, p)
+	if err != nil {
+		var idx *uint64
+		if l == 0 {
+			idx = bundleFirstIndex(i)
+		}
+		f.reportFetch(ctx, layout.TilePath(l, i, p), idx, err)
+	}
+	return b, err
+}
+
+func (f *reportingFetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	b, err := f.fetcher.ReadEntryBundle(ctx, i, p)
+	if err != nil {
+		f.reportFetch(ctx, entryBundlePath(i, p), bundleFirstIndex(i), err)
+	}
+	return b, err
+}
+
+func (f *reportingFetcher) reportFetch(ctx context.Context, resource string, index *uint64, err error) {
+	f.failures.Add(1)
+	f.r.reportFetch(ctx, resource, index, err)
+}
+
+// bundleFirstIndex returns the index of the first entry of bundle i.
+func bundleFirstIndex(i uint64) *uint64 {
+	idx := i * layout.EntryBundleWidth
+	return &idx
+}
+
+// entryBundlePath returns the path of an entry bundle in a static-ct log.
+func entryBundlePath(i uint64, p uint8) string {
+	return fmt.Sprintf("tile/data/%s", layout.NWithSuffix(0, i, p))
+}
+
+// issuerPath returns the path of an issuer in a static-ct log.
+func issuerPath(fp []byte) string {
+	return fmt.Sprintf("issuer/%s", hex.EncodeToString(fp))
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// remote_signer is a signing daemon which holds TesseraCT log keys, and signs
+// SCTs and checkpoints on behalf of TesseraCT servers over mTLS.
+//
+// It only signs well-formed RFC 6962 CertificateTimestamp and
+// TreeHeadSignature structures, and writes an audit record for every request.
+package main
+
+import (
+	"context"
+	"crypto"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+	"github.com/transparency-dev/tesseract/internal/signer"
+	"github.com/transparency-dev/tesseract/internal/signer/remote"
+)
+
+func init() {
+	flag.Var(&privKeyFiles, "private_key", "Path to a PEM encoded log private key. May be specified multiple times to serve several logs.")
+}
+
+var (
+	privKeyFiles flagutil.MultiString
+
+	httpEndpoint = flag.String("http_endpoint", "localhost:6965", "Endpoint for HTTPS (host:port).")
+	tlsCert      = flag.String("tls_cert", "", "Path to the PEM encoded server certificate.")
+	tlsKey       = flag.String("tls_key", "", "Path to the PEM encoded server certificate private key.")
+	clientCA     = flag.String("client_ca", "", "Path to a PEM file containing the CA certificates which issue TesseraCT client certificates.")
+	rateLimit    = flag.Float64("rate_limit", 0, "Maximum sustained number of signatures per second, across all keys. Zero disables rate limiting.")
+	rateBurst    = flag.Int("rate_burst", 100, "Maximum number of signatures which can be issued in a burst when rate limiting is enabled.")
+	maxClockSkew = flag.Duration("max_clock_skew", 5*time.Minute, "Refuse to sign structures whose timestamp is further than this from the current time. Zero disables the check.")
+	auditLog     = flag.String("audit_log", "", "Path to a file to append JSON audit records to. If unset, audit records are written to stderr.")
+	slogLevel    = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+
+	if *tlsCert == "" || *tlsKey == "" || *clientCA == "" {
+		slog.ErrorContext(ctx, "Must specify --tls_cert, --tls_key and --client_ca")
+		os.Exit(1)
+	}
+	if len(privKeyFiles) == 0 {
+		slog.ErrorContext(ctx, "Must specify at least one --private_key")
+		os.Exit(1)
+	}
+
+	var signers []crypto.Signer
+	for _, f := range privKeyFiles {
+		s, err := signerFromFile(f)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load private key", slog.String("path", f), slog.Any("error", err))
+			os.Exit(1)
+		}
+		id, err := remote.LogID(s.Public())
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to compute log ID", slog.String("path", f), slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Loaded log key", slog.String("path", f), slog.String("log_id", fmt.Sprintf("%x", id)))
+		signers = append(signers, s)
+	}
+
+	audit := slog.Default()
+	if *auditLog != "" {
+		f, err := os.OpenFile(*auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to open audit log", slog.String("path", *auditLog), slog.Any("error", err))
+			os.Exit(1)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				slog.ErrorContext(ctx, "Failed to close audit log", slog.Any("error", err))
+			}
+		}()
+		audit = slog.New(slog.NewJSONHandler(f, nil))
+	}
+
+	srv, err := remote.NewServer(signers, remote.ServerOpts{
+		RateLimit:    *rateLimit,
+		RateBurst:    *rateBurst,
+		MaxClockSkew: *maxClockSkew,
+		AuditLog:     audit,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create signing server", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	tlsConfig, err := remote.ServerTLSConfig(*tlsCert, *tlsKey, *clientCA)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create TLS config", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	httpSrv := http.Server{
+		Addr:              *httpEndpoint,
+		Handler:           srv,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    8 << 10, // 8 KiB
+	}
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-sigs
+		slog.WarnContext(ctx, "Signal received", slog.Any("signal", sig))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpSrv.Shutdown(ctx); err != nil {
+			slog.ErrorContext(ctx, "srv.Shutdown()", slog.Any("error", err))
+		}
+	}()
+
+	slog.InfoContext(ctx, "**** Remote signer starting ****", slog.String("endpoint", *httpEndpoint))
+	// Certificates are already part of tlsConfig.
+	if err := httpSrv.ListenAndServeTLS("", ""); !errors.Is(err, http.ErrServerClosed) {
+		slog.ErrorContext(ctx, "Server exited", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+// signerFromFile reads a PEM encoded ECDSA or RSA private key from path.
+func signerFromFile(path string) (crypto.Signer, error) {
+	r, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(r)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM")
+	}
+	return signer.ParsePrivateKeyPEM(block)
+}
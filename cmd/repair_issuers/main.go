@@ -0,0 +1,415 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// repair_issuers is a command-line tool for finding the issuers referenced by
+// a static-ct based log which are missing from its issuer storage, and for
+// backfilling them from secondary sources.
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+	"github.com/transparency-dev/tesseract/internal/source"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/storage"
+	"github.com/transparency-dev/tesseract/storage/aws"
+	"github.com/transparency-dev/tesseract/storage/gcp"
+	"github.com/transparency-dev/tesseract/storage/mysql"
+	"github.com/transparency-dev/tesseract/storage/posix"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	monitoringURL = flag.String("monitoring_url", "", "Base tlog-tiles URL of the log to repair, e.g. https://log.example.com/, or gs://bucket, s3://bucket or file:///path/to/log/ to read it directly from its storage")
+	N             = flag.Uint("N", 8, "The number of workers to use when fetching entry bundles and issuers")
+	dryRun        = flag.Bool("dry_run", false, "Set to true to only report missing issuers, and whether they could be backfilled, without writing them")
+	slogLevel     = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+
+	// Issuer storage of the log, exactly one must be set.
+	storageDir = flag.String("storage_dir", "", "Path to the root directory of a POSIX log")
+	gcsBucket  = flag.String("gcs_bucket", "", "Name of the GCS bucket of a GCP log")
+	s3Bucket   = flag.String("s3_bucket", "", "Name of the S3 bucket of an AWS log")
+	mysqlURI   = flag.String("mysql_uri", "", "Connection string of the MySQL database of a MySQL log")
+
+	// Secondary sources of issuers.
+	sourceLogURL flagutil.MultiString
+	sourcePEMDir = flag.String("source_pem_dir", "", "Path to a directory of PEM encoded certificates to backfill issuers from")
+	ccadbCSV     = flag.String("ccadb_csv", "", "URL or path of a CCADB CSV report with a PEM certificate column to backfill issuers from, e.g. https://ccadb.my.salesforce-sites.com/ccadb/AllCertificatePEMsCSVFormat")
+)
+
+func init() {
+	flag.Var(&sourceLogURL, "source_log_url", "Base monitoring URL, or gs://, s3:// or file:// storage URL, of another static-ct log to backfill issuers from, via its issuer/ endpoint (can be specified multiple times)")
+}
+
+const (
+	userAgent = "TesseraCT repair_issuers"
+)
+
+func main() {
+	flag.Parse()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+	ctx := context.Background()
+
+	src := fetcherFromFlags(*monitoringURL)
+	dst := issuerStorageFromFlags(ctx)
+	sources := sourcesFromFlags(ctx)
+
+	fps, err := collectFingerprints(ctx, src)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to collect issuer fingerprints from the log", slog.Any("error", err))
+		os.Exit(1)
+	}
+	keys, err := dst.List(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list issuers in storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	stored := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		stored[string(k)] = true
+	}
+	missing := [][32]byte{}
+	for fp := range fps {
+		if !stored[hex.EncodeToString(fp[:])] {
+			missing = append(missing, fp)
+		}
+	}
+	slog.InfoContext(ctx, "Found issuers", slog.Int("referenced", len(fps)), slog.Int("stored", len(keys)), slog.Int("missing", len(missing)))
+
+	var repaired, unresolved atomic.Int64
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(int(*N))
+	for _, fp := range missing {
+		eg.Go(func() error {
+			key := hex.EncodeToString(fp[:])
+			der, from, err := sources.find(ctx, fp)
+			if err != nil {
+				slog.WarnContext(ctx, "Couldn't find missing issuer in any source", slog.String("fp", key), slog.Any("error", err))
+				unresolved.Add(1)
+				return nil
+			}
+			if *dryRun {
+				slog.InfoContext(ctx, "Would backfill missing issuer", slog.String("fp", key), slog.String("source", from))
+				repaired.Add(1)
+				return nil
+			}
+			if err := dst.AddIfNotExist(ctx, []storage.KV{{K: []byte(key), V: der}}); err != nil {
+				slog.WarnContext(ctx, "Failed to write missing issuer", slog.String("fp", key), slog.Any("error", err))
+				unresolved.Add(1)
+				return nil
+			}
+			slog.InfoContext(ctx, "Backfilled missing issuer", slog.String("fp", key), slog.String("source", from))
+			repaired.Add(1)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	slog.InfoContext(ctx, "Done", slog.Bool("dry_run", *dryRun), slog.Int("missing", len(missing)), slog.Int64("repaired", repaired.Load()), slog.Int64("unresolved", unresolved.Load()))
+	if unresolved.Load() > 0 {
+		os.Exit(1)
+	}
+}
+
+// collectFingerprints returns the fingerprints of all the issuers referenced
+// by the entries of the log's latest checkpoint.
+func collectFingerprints(ctx context.Context, src source.Fetcher) (map[[32]byte]bool, error) {
+	cpRaw, err := src.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	// Issuers are verified by their hash, so there is no need to trust the
+	// checkpoint.
+	cp := &log.Checkpoint{}
+	if _, err := cp.Unmarshal(cpRaw); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %v", err)
+	}
+	slog.InfoContext(ctx, "Collecting issuer fingerprints", slog.Uint64("size", cp.Size))
+
+	var mu sync.Mutex
+	fps := map[[32]byte]bool{}
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(int(*N))
+	for i := range (cp.Size + layout.EntryBundleWidth - 1) / layout.EntryBundleWidth {
+		eg.Go(func() error {
+			bundle, err := client.GetEntryBundle(ctx, src.ReadEntryBundle, i, cp.Size)
+			if err != nil {
+				return err
+			}
+			for j, raw := range bundle.Entries {
+				e := staticct.Entry{}
+				if err := e.UnmarshalText(raw); err != nil {
+					return fmt.Errorf("failed to parse entry %d of bundle %d: %v", j, i, err)
+				}
+				mu.Lock()
+				for _, fp := range e.FingerprintsChain {
+					fps[fp] = true
+				}
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return fps, nil
+}
+
+// issuerSources looks up issuers in secondary sources, in order.
+type issuerSources struct {
+	// local holds certificates from local PEM files and CCADB, by sha256.
+	local map[[32]byte]localCert
+	logs  []sourceLog
+}
+
+type sourceLog struct {
+	url string
+	f   source.Fetcher
+}
+
+type localCert struct {
+	der    []byte
+	source string
+}
+
+// find returns the DER certificate with the sha256 fp, and where it was found.
+//
+// Certificates from logs are only returned if their hash matches fp.
+func (s *issuerSources) find(ctx context.Context, fp [32]byte) ([]byte, string, error) {
+	if c, ok := s.local[fp]; ok {
+		return c.der, c.source, nil
+	}
+	errs := []error{}
+	for _, l := range s.logs {
+		der, err := l.f.ReadIssuer(ctx, fp[:])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if sha256.Sum256(der) != fp {
+			errs = append(errs, fmt.Errorf("issuer served by %s doesn't match its fingerprint", l.url))
+			continue
+		}
+		return der, l.url, nil
+	}
+	if len(errs) == 0 {
+		return nil, "", errors.New("no source has it")
+	}
+	return nil, "", errors.Join(errs...)
+}
+
+// addPEMs adds the certificates in the PEM encoded data to s.local.
+func (s *issuerSources) addPEMs(data []byte, source string) int {
+	n := 0
+	for {
+		var b *pem.Block
+		b, data = pem.Decode(data)
+		if b == nil {
+			return n
+		}
+		if b.Type != "CERTIFICATE" {
+			continue
+		}
+		s.local[sha256.Sum256(b.Bytes)] = localCert{der: b.Bytes, source: source}
+		n++
+	}
+}
+
+func sourcesFromFlags(ctx context.Context) *issuerSources {
+	s := &issuerSources{local: map[[32]byte]localCert{}}
+	if *sourcePEMDir != "" {
+		files, err := os.ReadDir(*sourcePEMDir)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to read --source_pem_dir", slog.Any("error", err))
+			os.Exit(1)
+		}
+		n := 0
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			p := filepath.Join(*sourcePEMDir, f.Name())
+			data, err := os.ReadFile(p)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to read PEM file", slog.String("path", p), slog.Any("error", err))
+				os.Exit(1)
+			}
+			n += s.addPEMs(data, p)
+		}
+		slog.InfoContext(ctx, "Loaded certificates from PEM directory", slog.Int("certificates", n))
+	}
+	if *ccadbCSV != "" {
+		n, err := s.addCCADB(ctx, *ccadbCSV)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load --ccadb_csv", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Loaded certificates from CCADB", slog.Int("certificates", n))
+	}
+	for _, u := range sourceLogURL {
+		s.logs = append(s.logs, sourceLog{url: u, f: fetcherFromFlags(u)})
+	}
+	if len(s.local) == 0 && len(s.logs) == 0 && !*dryRun {
+		slog.ErrorContext(ctx, "At least one of --source_pem_dir, --ccadb_csv or --source_log_url must be set, unless --dry_run is set")
+		os.Exit(1)
+	}
+	return s
+}
+
+// addCCADB adds the certificates of a CCADB CSV report, read from a URL or a
+// local path, to s.local.
+func (s *issuerSources) addCCADB(ctx context.Context, loc string) (int, error) {
+	var r io.Reader
+	if strings.HasPrefix(loc, "http://") || strings.HasPrefix(loc, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("User-Agent", userAgent)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch %q: %v", loc, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("failed to fetch %q: %s", loc, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		b, err := os.ReadFile(loc)
+		if err != nil {
+			return 0, err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	col := -1
+	for i, h := range header {
+		if strings.Contains(strings.ToUpper(h), "PEM") {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return 0, fmt.Errorf("no PEM column in CSV header %q", header)
+	}
+	n := 0
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("failed to read CSV record: %v", err)
+		}
+		n += s.addPEMs([]byte(rec[col]), "ccadb")
+	}
+}
+
+func issuerStorageFromFlags(ctx context.Context) interface {
+	storage.IssuerStorage
+	storage.IssuerKeyLister
+} {
+	set := 0
+	for _, f := range []string{*storageDir, *gcsBucket, *s3Bucket, *mysqlURI} {
+		if f != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		slog.ErrorContext(ctx, "Exactly one of --storage_dir, --gcs_bucket, --s3_bucket or --mysql_uri must be set")
+		os.Exit(1)
+	}
+	var s interface {
+		storage.IssuerStorage
+		storage.IssuerKeyLister
+	}
+	var err error
+	switch {
+	case *storageDir != "":
+		s, err = posix.NewIssuerStorage(ctx, *storageDir)
+	case *gcsBucket != "":
+		s, err = gcp.NewIssuerStorage(ctx, *gcsBucket, nil)
+	case *s3Bucket != "":
+		s, err = aws.NewIssuerStorage(ctx, aws.Options{Bucket: *s3Bucket})
+	case *mysqlURI != "":
+		var db *sql.DB
+		db, err = sql.Open("mysql", *mysqlURI)
+		if err == nil {
+			s, err = mysql.NewIssuerStorage(ctx, db)
+		}
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to initialize issuer storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return s
+}
+
+func fetcherFromFlags(u string) source.Fetcher {
+	ctx := context.Background()
+	logURL, err := url.Parse(u)
+	if err != nil || u == "" {
+		slog.ErrorContext(ctx, "Invalid log URL", slog.String("url", u), slog.Any("error", err))
+		os.Exit(1)
+	}
+	hc := &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:        int(*N),
+			MaxIdleConnsPerHost: int(*N),
+			DisableKeepAlives:   false,
+		},
+		Timeout: 30 * time.Second,
+	}
+	f, err := source.New(ctx, logURL, hc)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create fetcher", slog.String("url", u), slog.Any("error", err))
+		os.Exit(1)
+	}
+	if h, ok := f.(*client.HTTPFetcher); ok {
+		h.EnableRetries(10)
+		h.SetUserAgent(userAgent)
+	}
+	return f
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/csv"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCert returns a new self-signed DER certificate with the given name.
+func newCert(t *testing.T, name string) []byte {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, k.Public(), k)
+	if err != nil {
+		t.Fatalf("CreateCertificate(): %v", err)
+	}
+	return der
+}
+
+func pemCert(der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// issuerLog is a source.Fetcher which only serves issuers, by fingerprint.
+type issuerLog struct {
+	issuers map[[32]byte][]byte
+}
+
+func (l issuerLog) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (l issuerLog) ReadTile(ctx context.Context, _, _ uint64, _ uint8) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (l issuerLog) ReadEntryBundle(ctx context.Context, _ uint64, _ uint8) ([]byte, error) {
+	return nil, os.ErrNotExist
+}
+
+func (l issuerLog) ReadIssuer(ctx context.Context, hash []byte) ([]byte, error) {
+	if der, ok := l.issuers[[32]byte(hash)]; ok {
+		return der, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	local, good, bad := newCert(t, "local"), newCert(t, "good"), newCert(t, "bad")
+	localFP, goodFP, badFP := sha256.Sum256(local), sha256.Sum256(good), sha256.Sum256(bad)
+	missingFP := sha256.Sum256([]byte("missing"))
+
+	s := &issuerSources{
+		local: map[[32]byte]localCert{localFP: {der: local, source: "local.pem"}},
+		logs: []sourceLog{
+			// This log serves another certificate than the requested one.
+			{url: "https://liar.example.com/", f: issuerLog{issuers: map[[32]byte][]byte{badFP: good}}},
+			{url: "https://log.example.com/", f: issuerLog{issuers: map[[32]byte][]byte{goodFP: good, badFP: bad}}},
+		},
+	}
+
+	for _, test := range []struct {
+		desc       string
+		fp         [32]byte
+		wantDER    []byte
+		wantSource string
+		wantErr    bool
+	}{
+		{
+			desc:       "local",
+			fp:         localFP,
+			wantDER:    local,
+			wantSource: "local.pem",
+		},
+		{
+			desc:       "log",
+			fp:         goodFP,
+			wantDER:    good,
+			wantSource: "https://log.example.com/",
+		},
+		{
+			desc:       "fingerprint mismatch skipped",
+			fp:         badFP,
+			wantDER:    bad,
+			wantSource: "https://log.example.com/",
+		},
+		{
+			desc:    "missing",
+			fp:      missingFP,
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			der, source, err := s.find(ctx, test.fp)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("find() = %v, want error: %t", err, test.wantErr)
+			}
+			if string(der) != string(test.wantDER) || source != test.wantSource {
+				t.Errorf("find() = %x, %q, want %x, %q", der, source, test.wantDER, test.wantSource)
+			}
+		})
+	}
+
+	// With only the lying log, the mismatch is reported.
+	s.logs = s.logs[:1]
+	if _, _, err := s.find(ctx, badFP); err == nil || !strings.Contains(err.Error(), "doesn't match its fingerprint") {
+		t.Errorf("find() = %v, want fingerprint mismatch error", err)
+	}
+}
+
+func TestAddPEMs(t *testing.T) {
+	a, b := newCert(t, "a"), newCert(t, "b")
+	data := pemCert(a) + string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")})) + pemCert(b)
+
+	s := &issuerSources{local: map[[32]byte]localCert{}}
+	if got, want := s.addPEMs([]byte(data), "test.pem"), 2; got != want {
+		t.Errorf("addPEMs() = %d, want %d", got, want)
+	}
+	for _, der := range [][]byte{a, b} {
+		if c, ok := s.local[sha256.Sum256(der)]; !ok || string(c.der) != string(der) || c.source != "test.pem" {
+			t.Errorf("local[%x] = %v, %t, want certificate from test.pem", sha256.Sum256(der), c, ok)
+		}
+	}
+}
+
+func TestAddCCADB(t *testing.T) {
+	ctx := context.Background()
+	a, b := newCert(t, "a"), newCert(t, "b")
+
+	for _, test := range []struct {
+		desc    string
+		records [][]string
+		want    int
+		wantErr bool
+	}{
+		{
+			desc: "PEM column",
+			records: [][]string{
+				{"CA Owner", "SHA-256 Fingerprint", "PEM Info"},
+				{"Owner A", "aa", pemCert(a)},
+				{"Owner B", "bb", pemCert(b)},
+			},
+			want: 2,
+		},
+		{
+			desc: "lower case PEM column",
+			records: [][]string{
+				{"Certificate Name", "X.509 Certificate (pem)"},
+				{"A", pemCert(a)},
+			},
+			want: 1,
+		},
+		{
+			desc: "no PEM column",
+			records: [][]string{
+				{"CA Owner", "Certificate"},
+				{"Owner A", pemCert(a)},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ccadb.csv")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatalf("Create(): %v", err)
+			}
+			w := csv.NewWriter(f)
+			if err := w.WriteAll(test.records); err != nil {
+				t.Fatalf("WriteAll(): %v", err)
+			}
+			if err := f.Close(); err != nil {
+				t.Fatalf("Close(): %v", err)
+			}
+
+			s := &issuerSources{local: map[[32]byte]localCert{}}
+			n, err := s.addCCADB(ctx, path)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("addCCADB() = %v, want error: %t", err, test.wantErr)
+			}
+			if n != test.want || len(s.local) != test.want {
+				t.Errorf("addCCADB() = %d with %d local certificates, want %d", n, len(s.local), test.want)
+			}
+		})
+	}
+}
@@ -15,18 +15,17 @@
 package main
 
 import (
-	"crypto"
-	"crypto/ecdsa"
-	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
+
+	"github.com/transparency-dev/tesseract/internal/signer"
 )
 
-// NewLocalSigner creates a new signer that uses the ECDSA P-256 key pair from
+// NewLocalSigner creates a new signer that uses the ECDSA or RSA key pair from
 // local disk files for signing digests.
-func NewLocalSigner(publicKeyFile, privateKeyFile string) (*ECDSAWithSHA256Signer, error) {
+func NewLocalSigner(publicKeyFile, privateKeyFile string) (*signer.SHA256Signer, error) {
 	// Read public key
 	publicKeyPEM, err := os.ReadFile(publicKeyFile)
 	if err != nil {
@@ -41,22 +40,11 @@ func NewLocalSigner(publicKeyFile, privateKeyFile string) (*ECDSAWithSHA256Signe
 		return nil, fmt.Errorf("extra data after decoding public key PEM: %v", rest)
 	}
 
-	var publicKey crypto.PublicKey
-	switch publicPemBlock.Type {
-	case "PUBLIC KEY":
-		publicKey, err = x509.ParsePKIXPublicKey(publicPemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported public key PEM type: %s", publicPemBlock.Type)
-	}
+	publicKey, err := signer.ParsePublicKeyPEM(publicPemBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("the public key is not an ECDSA key")
-	}
-
 	// Read private key
 	privateKeyPEM, err := os.ReadFile(privateKeyFile)
 	if err != nil {
@@ -71,24 +59,10 @@ func NewLocalSigner(publicKeyFile, privateKeyFile string) (*ECDSAWithSHA256Signe
 		return nil, fmt.Errorf("extra data after decoding private key PEM: %v", rest)
 	}
 
-	var ecdsaPrivateKey *ecdsa.PrivateKey
-	switch privatePemBlock.Type {
-	case "EC PRIVATE KEY":
-		ecdsaPrivateKey, err = x509.ParseECPrivateKey(privatePemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported private key PEM type: %s", privatePemBlock.Type)
-	}
+	privateKey, err := signer.ParsePrivateKeyPEM(privatePemBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the correctness of the signer key pair
-	if !ecdsaPrivateKey.PublicKey.Equal(ecdsaPublicKey) {
-		return nil, errors.New("signer key pair doesn't match")
-	}
-
-	return &ECDSAWithSHA256Signer{
-		publicKey:  ecdsaPublicKey,
-		privateKey: ecdsaPrivateKey,
-	}, nil
+	return signer.NewSHA256Signer(publicKey, privateKey)
 }
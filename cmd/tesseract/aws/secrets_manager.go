@@ -16,51 +16,19 @@ package main
 
 import (
 	"context"
-	"crypto"
-	"crypto/ecdsa"
-	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"io"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/transparency-dev/tesseract/internal/signer"
 )
 
-// TODO: Move ECDSAWithSHA256Signer to internal signer package.
-// ECDSAWithSHA256Signer implements crypto.Signer using AWS Secrets Manager.
-// Only crypto.SHA256 and ECDSA are supported.
-type ECDSAWithSHA256Signer struct {
-	publicKey  *ecdsa.PublicKey
-	privateKey *ecdsa.PrivateKey
-}
-
-// Public returns the public key stored in the Signer object.
-func (s *ECDSAWithSHA256Signer) Public() crypto.PublicKey {
-	return s.publicKey
-}
-
-// Sign signs digest with the private key stored in AWS Secrets Manager.
-func (s *ECDSAWithSHA256Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
-	// Verify hash function and digest bytes length.
-	if opts == nil {
-		return nil, errors.New("opts cannot be nil")
-	}
-	if opts.HashFunc() != crypto.SHA256 {
-		return nil, fmt.Errorf("unsupported hash func: %v", opts.HashFunc())
-	}
-	if len(digest) != opts.HashFunc().Size() {
-		return nil, fmt.Errorf("digest bytes length %d does not match hash function bytes length %d", len(digest), opts.HashFunc().Size())
-	}
-
-	return ecdsa.SignASN1(rand, s.privateKey, digest)
-}
-
-// NewSecretsManagerSigner creates a new signer that uses the ECDSA P-256 key pair in
+// NewSecretsManagerSigner creates a new signer that uses the ECDSA or RSA key pair in
 // AWS Secrets Manager for signing digests.
-func NewSecretsManagerSigner(ctx context.Context, publicKeySecretName, privateKeySecretName string) (*ECDSAWithSHA256Signer, error) {
+func NewSecretsManagerSigner(ctx context.Context, publicKeySecretName, privateKeySecretName string) (*signer.SHA256Signer, error) {
 	sdkConfig, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load default AWS configuration: %v", err)
@@ -70,51 +38,26 @@ func NewSecretsManagerSigner(ctx context.Context, publicKeySecretName, privateKe
 	client := secretsmanager.NewFromConfig(sdkConfig)
 
 	// Public Key
-	var publicKey crypto.PublicKey
 	pemBlock, err := secretPEM(ctx, client, publicKeySecretName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public key secret PEM (%s): %w", publicKeySecretName, err)
 	}
-	switch pemBlock.Type {
-	case "PUBLIC KEY":
-		publicKey, err = x509.ParsePKIXPublicKey(pemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported PEM type: %s", pemBlock.Type)
-	}
+	publicKey, err := signer.ParsePublicKeyPEM(pemBlock)
 	if err != nil {
 		return nil, err
 	}
-	var ecdsaPublicKey *ecdsa.PublicKey
-	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("the public key stored in Secret Manager is not an ECDSA key")
-	}
 
 	// Private Key
-	var ecdsaPrivateKey *ecdsa.PrivateKey
 	pemBlock, err = secretPEM(ctx, client, privateKeySecretName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get private key secret PEM (%s): %w", privateKeySecretName, err)
 	}
-	switch pemBlock.Type {
-	case "EC PRIVATE KEY":
-		ecdsaPrivateKey, err = x509.ParseECPrivateKey(pemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported PEM type: %s", pemBlock.Type)
-	}
+	privateKey, err := signer.ParsePrivateKeyPEM(pemBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the correctness of the signer key pair
-	if !ecdsaPrivateKey.PublicKey.Equal(ecdsaPublicKey) {
-		return nil, errors.New("signer key pair doesn't match")
-	}
-
-	return &ECDSAWithSHA256Signer{
-		publicKey:  ecdsaPublicKey,
-		privateKey: ecdsaPrivateKey,
-	}, nil
+	return signer.NewSHA256Signer(publicKey, privateKey)
 }
 
 func secretPEM(ctx context.Context, client *secretsmanager.Client, secretName string) (*pem.Block, error) {
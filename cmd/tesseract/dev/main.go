@@ -0,0 +1,139 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// dev runs an ephemeral TesseraCT log for development.
+//
+// The log is stored in a temporary directory, uses a generated key, and trusts
+// a generated test root. dev writes the files needed to talk to the log to
+// --output_dir: the log public key, the test root, and sample add-chain and
+// add-pre-chain request bodies.
+//
+// DO NOT use this binary to run a production log.
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/transparency-dev/tesseract/devlog"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+)
+
+var (
+	httpEndpoint       = flag.String("http_endpoint", "localhost:6962", "Endpoint for HTTP (host:port).")
+	origin             = flag.String("origin", devlog.DefaultOrigin, "Origin of the log, for checkpoints.")
+	storageDir         = flag.String("storage_dir", "", "Path to store the log in. If unset, the log is stored in a temporary directory which is removed on exit.")
+	outputDir          = flag.String("output_dir", "", "If set, path to write the log public key, test root and sample request bodies to.")
+	checkpointInterval = flag.Duration("checkpoint_interval", devlog.DefaultCheckpointInterval, "Interval between publishing checkpoints when the log has grown.")
+	slogLevel          = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+
+	l, err := devlog.Start(ctx, devlog.Options{
+		Dir:                *storageDir,
+		Addr:               *httpEndpoint,
+		Origin:             *origin,
+		CheckpointInterval: *checkpointInterval,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to start development log", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	pubPEM, err := publicKeyPEM(l)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode log public key", slog.Any("error", err))
+		_ = l.Close()
+		os.Exit(1)
+	}
+	if *outputDir != "" {
+		if err := writeOutputs(l, pubPEM, *outputDir); err != nil {
+			slog.ErrorContext(ctx, "Failed to write outputs", slog.Any("error", err))
+			_ = l.Close()
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("Origin:      %s\n", l.Origin)
+	fmt.Printf("URL:         %s\n", l.URL)
+	fmt.Printf("Public key:  %s", pubPEM)
+	fmt.Printf("Storage dir: %s\n", l.Dir)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigs
+	slog.WarnContext(ctx, "Signal received", slog.Any("signal", sig))
+	if err := l.Close(); err != nil {
+		slog.ErrorContext(ctx, "Failed to close development log", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+// writeOutputs writes the log public key, the test root, and sample add-chain
+// and add-pre-chain request bodies to dir.
+func writeOutputs(l *devlog.Log, pubPEM []byte, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %q: %v", dir, err)
+	}
+	files := map[string][]byte{
+		"log_public_key.pem": pubPEM,
+		"root.pem":           pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: l.Root.Raw}),
+	}
+	for name, issue := range map[string]func() ([]*x509.Certificate, error){
+		"add-chain.json":     l.IssueChain,
+		"add-pre-chain.json": l.IssuePrecertChain,
+	} {
+		chain, err := issue()
+		if err != nil {
+			return fmt.Errorf("failed to issue chain: %v", err)
+		}
+		req := rfc6962.AddChainRequest{}
+		for _, c := range chain {
+			req.Chain = append(req.Chain, c.Raw)
+		}
+		files[name], err = json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %v", name, err)
+		}
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %q: %v", p, err)
+		}
+		slog.Info("Wrote file", slog.String("path", p))
+	}
+	return nil
+}
+
+// publicKeyPEM returns the PEM encoded public key of l.
+func publicKeyPEM(l *devlog.Log) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(l.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
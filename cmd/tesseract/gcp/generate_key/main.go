@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// generate_key is a tool for generating an ECDSA key pair and storing it
+// generate_key is a tool for generating an ECDSA or RSA key pair and storing it
 // in GCP Secret Manager. This tool is intended to be used for creating
 // signing keys for TesseraCT static CT logs.
 package main
@@ -22,6 +22,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"flag"
@@ -37,12 +38,19 @@ import (
 const (
 	kuLog     = "log"
 	kuWitness = "witness"
+
+	ktECDSA = "ecdsa"
+	ktRSA   = "rsa"
+
+	// rsaKeyBits is the size of generated RSA log keys.
+	rsaKeyBits = 2048
 )
 
 var (
 	projectID = flag.String("project_id", os.Getenv("GOOGLE_CLOUD_PROJECT"), "GCP Project ID in which to store the secret key.")
 	origin    = flag.String("log_origin", "", "The origin of the log this key will be used with. The Secret Manager resource names will be derived from thiss tring, and have '{key_usage}-secret' and '{key_usage}-public' suffixes added.")
 	keyUsage  = flag.String("key_usage", kuLog, "Type of key to create: '"+kuLog+"' or '"+kuWitness+"'. The created key names will include the key usage.")
+	keyType   = flag.String("key_type", ktECDSA, "Algorithm of the log key to create: '"+ktECDSA+"' (P-256) or '"+ktRSA+"' (2048 bits). Ignored for witness keys.")
 )
 
 func main() {
@@ -71,7 +79,14 @@ func main() {
 
 	switch ku := strings.ToLower(*keyUsage); ku {
 	case kuLog:
-		sec, pub = genECDSAKeypairPEM()
+		switch kt := strings.ToLower(*keyType); kt {
+		case ktECDSA:
+			sec, pub = genECDSAKeypairPEM()
+		case ktRSA:
+			sec, pub = genRSAKeypairPEM()
+		default:
+			exit("Unsupported --key_type %q", kt)
+		}
 	case kuWitness:
 		sec, pub = genEd25519KeypairNote()
 	}
@@ -153,6 +168,24 @@ func genECDSAKeypairPEM() (string, string) {
 	return string(secPEM), string(pubPEM)
 }
 
+// genRSAKeypairPEM generates an RSA key pair and returns PEM representations of
+// the private and public keys encoded as PKCS #1 and PKIX Public Key respectively.
+func genRSAKeypairPEM() (string, string) {
+	secK, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		exit("Failed to generate key pair: %v", err)
+	}
+	secPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(secK)})
+
+	pubPKIX, err := x509.MarshalPKIXPublicKey(secK.Public())
+	if err != nil {
+		exit("Failed to marshal public key: %v", err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubPKIX})
+
+	return string(secPEM), string(pubPEM)
+}
+
 // genEd25519KeypairNote generates an Ed25519 key pair and returns note vkey representations of
 // the private and public keys respectively.
 func genEd25519KeypairNote() (string, string) {
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"flag"
 	"fmt"
@@ -26,7 +27,6 @@ import (
 	"os"
 
 	"os/signal"
-	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -42,7 +42,9 @@ import (
 	tgcp "github.com/transparency-dev/tessera/storage/gcp"
 	gcp_as "github.com/transparency-dev/tessera/storage/gcp/antispam"
 	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
 	"github.com/transparency-dev/tesseract/internal/logger"
+	"github.com/transparency-dev/tesseract/internal/signer/remote"
 	"github.com/transparency-dev/tesseract/storage"
 	"github.com/transparency-dev/tesseract/storage/gcp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -54,6 +56,7 @@ import (
 func init() {
 	flag.Var(&notAfterStart, "not_after_start", "Start of the range of acceptable NotAfter values, inclusive. Leaving this unset or empty implies no lower bound to the range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
 	flag.Var(&notAfterLimit, "not_after_limit", "Cut off point of notAfter dates - only notAfter dates strictly *before* notAfterLimit will be accepted. Leaving this unset or empty means no upper bound on the accepted range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
+	flag.Var(&notBeforeRL, "rate_limit_old_not_before", "Optionally rate limits submissions with old notBefore dates. Expects a value of with the format: \"<go duration>:<rate limit>\", e.g. \"30d:50\" would impose a limit of 50 certs/s on submissions whose notBefore date is >= 30days old.")
 	flag.Var(&additionalSigners, "additional_signer_private_key_secret_name", "Private key secret name for additional Ed25519 checkpoint signatures, may be supplied multiple times. Format: projects/{projectId}/secrets/{secretName}/versions/{secretVersion}.")
 	flag.Var(&rootsRejectFingerprints, "roots_reject_fingerprints", "Hex-encoded SHA-256 fingerprint of a root certificate to reject. May be specified multiple times.")
 	flag.Float64Var(&dedupRL, "rate_limit_dedup", 100, "Rate limit for resolving duplicate submissions, in requests per second - i.e. duplicate requests for already integrated entries, which need to be fetched from the log storage by TesseraCT to extract their timestamp. When 0, all duplicate submissions are rejected. When negative, no rate limit is applied.")
@@ -62,20 +65,25 @@ func init() {
 
 // Global flags that affect all log instances.
 var (
-	notAfterStart     timestampFlag
-	notAfterLimit     timestampFlag
-	additionalSigners multiStringFlag
+	notAfterStart     flagutil.Timestamp
+	notAfterLimit     flagutil.Timestamp
+	notBeforeRL       = tesseract.NotBeforeRLFlag{RL: &tesseract.NotBeforeRL{AgeThreshold: 28 * time.Hour, RateLimit: 500}}
+	additionalSigners flagutil.MultiString
 	dedupRL           float64
 
+	// Configuration file flags
+	configFile     = flag.String("config", "", "(Optional) Path to a JSON configuration file. Other formats, such as YAML, aren't supported. Flags set on the command line override values from the file. See cmd/tesseract/README.md#Configuration-file.")
+	validateConfig = flag.Bool("validate_config", false, "If true, checks the configuration and exits without starting the log.")
+
 	// Functionality flags
 	httpEndpoint             = flag.String("http_endpoint", "localhost:6962", "Endpoint for HTTP (host:port).")
 	maskInternalErrors       = flag.Bool("mask_internal_errors", false, "Don't return error strings with Internal Server Error HTTP responses.")
 	origin                   = flag.String("origin", "", "Origin of the log, for checkpoints. This MUST match the log's submission prefix as per https://c2sp.org/static-ct-api.")
 	pathPrefix               = flag.String("path_prefix", "", "Prefix to use on endpoints URL paths: HOST:PATH_PREFIX/ct/v1/ENDPOINT.")
 	rootsPemFile             = flag.String("roots_pem_file", "", "Path to the file containing root certificates that are acceptable to the log.")
-	rootsRemoteFetchURLs     multiStringFlag
+	rootsRemoteFetchURLs     flagutil.MultiString
 	rootsRemoteFetchInterval = flag.Duration("roots_remote_fetch_interval", time.Duration(0), "Interval between two fetches from roots_fetch_url, e.g. \"1h\".")
-	rootsRejectFingerprints  multiStringFlag
+	rootsRejectFingerprints  flagutil.MultiString
 	rejectExpired            = flag.Bool("reject_expired", false, "If true then the certificate validity period will be checked against the current time during the validation of submissions. This will cause expired certificates to be rejected.")
 	rejectUnexpired          = flag.Bool("reject_unexpired", false, "If true then TesseraCT rejects certificates that are either currently valid or not yet valid.")
 	extKeyUsages             = flag.String("ext_key_usages", "Any", "If set, will restrict the set of such usages that the server will accept. By default, 'Any' accepts all chains. Accepted values are defined in internal/ct.")
@@ -84,7 +92,9 @@ var (
 	enablePublicationAwaiter = flag.Bool("enable_publication_awaiter", true, "If true, waits for the submitted certificate to be covered by a published checkpoint before responding to an add-* request.")
 	witnessPolicyFile        = flag.String("witness_policy_file", "", "(Optional) Path to the file containing the witness policy in the format described at https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md")
 	witnessTimeout           = flag.Duration("witness_timeout", tessera.DefaultWitnessTimeout, "Maximum time to wait for witness responses.")
-	notBeforeRL              = flag.String("rate_limit_old_not_before", "28h:500", "Optionally rate limits submissions with old notBefore dates. Expects a value of with the format: \"<go duration>:<rate limit>\", e.g. \"30d:50\" would impose a limit of 50 certs/s on submissions whose notBefore date is >= 30days old.")
+	metadataDescription      = flag.String("metadata_description", "", "Description of the log, published in its metadata document.")
+	metadataMonitoringURL    = flag.String("metadata_monitoring_url", "", "URL prefix of the log's monitoring APIs, published in its metadata document.")
+	metadataMMD              = flag.Duration("metadata_mmd", time.Minute, "Maximum merge delay of the log, published in its metadata document.")
 
 	// Performance flags
 	httpDeadline                = flag.Duration("http_deadline", time.Second*10, "Deadline for HTTP requests.")
@@ -101,6 +111,16 @@ var (
 	clientHTTPMaxIdlePerHost    = flag.Int("client_http_max_idle_per_host", 200, "Maximum number of idle HTTP connections per host for outgoing requests.")
 	garbageCollectionInterval   = flag.Duration("garbage_collection_interval", 10*time.Second, "Interval between scans to remove obsolete partial tiles and entry bundles. Set to 0 to disable.")
 	awaiterPollInterval         = flag.Duration("awaiter_poll_interval", storage.DefaultAwaiterPollInterval, "Interval between two checkpoint polls by the awaiter. Used for antispam, and if enable_publication_awaiter is set, to block add-* requests responses. Must be strictly positive or defaults to DefaultAwaiterPollInterval.")
+	auditInterval               = flag.Duration("audit_interval", time.Minute, "Interval at which the self-auditor checks that newly published checkpoints are consistent with the previous ones. Set to zero to disable.")
+	auditBundleSampleRate       = flag.Float64("audit_bundle_sample_rate", 0.01, "Fraction of new entry bundles the self-auditor re-hashes against tiles, between 0 and 1.")
+	enableCheckpointArchive     = flag.Bool("enable_checkpoint_archive", false, "If true, newly published checkpoints, and their witness cosignatures, are archived under checkpoints/<size>.")
+	checkpointArchiveInterval   = flag.Duration("checkpoint_archive_interval", 0, "Interval at which the latest checkpoint is read to be archived. Defaults to --checkpoint_interval, and must not be longer, so that no checkpoint is replaced before being archived.")
+	checkpointArchiveMaxAge     = flag.Duration("checkpoint_archive_max_age", 0, "How long archived checkpoints are kept for. Set to zero to keep them forever.")
+	mergeDelayPollInterval      = flag.Duration("merge_delay_poll_interval", time.Second, "Interval at which checkpoints are read to measure the merge delay of issued SCTs. Set to zero to disable merge delay tracking.")
+	mergeDelayAlertRatio        = flag.Float64("merge_delay_alert_ratio", 0.8, "Fraction of --metadata_mmd after which SCTs whose entry isn't covered by a checkpoint yet raise an alert.")
+	dedupSCTCacheSize           = flag.Int("dedup_sct_cache_size", storage.DefaultDedupSCTCacheSize, "Number of SCT inputs cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	dedupBundleCacheSize        = flag.Int("dedup_bundle_cache_size", storage.DefaultDedupBundleCacheSize, "Number of full entry bundles cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	enableIssuerQueue           = flag.Bool("enable_issuer_queue", false, "If true, issuer writes are durably queued in Spanner, to be written to GCS asynchronously. Checkpoints are only signed once the issuers of the entries they cover are written. The queue is shared by all the instances of the log.")
 
 	// Infrastructure setup flags
 	bucket                     = flag.String("bucket", "", "Name of the GCS bucket to store the log in.")
@@ -110,6 +130,11 @@ var (
 	spannerConnections         = flag.Int("spanner_connections", 4, "Number of Spanner connections to configure.")
 	signerPublicKeySecretName  = flag.String("signer_public_key_secret_name", "", "Public key secret name for checkpoints and SCTs signer. Format: projects/{projectId}/secrets/{secretName}/versions/{secretVersion}.")
 	signerPrivateKeySecretName = flag.String("signer_private_key_secret_name", "", "Private key secret name for checkpoints and SCTs signer. Format: projects/{projectId}/secrets/{secretName}/versions/{secretVersion}.")
+	remoteSignerURL            = flag.String("remote_signer_url", "", "(Optional) URL of a remote_signer daemon holding the log private key. If set, the log key is not loaded locally and the --remote_signer_* flags must also be set.")
+	remoteSignerPublicKey      = flag.String("remote_signer_public_key", "", "Path to the PEM encoded public key of the log key held by the remote signer.")
+	remoteSignerTLSCert        = flag.String("remote_signer_tls_cert", "", "Path to the PEM encoded client certificate used to authenticate to the remote signer.")
+	remoteSignerTLSKey         = flag.String("remote_signer_tls_key", "", "Path to the PEM encoded private key for --remote_signer_tls_cert.")
+	remoteSignerCA             = flag.String("remote_signer_ca", "", "Path to a PEM file containing the CA certificates which issue the remote signer's certificate.")
 	traceFraction              = flag.Float64("trace_fraction", 0, "Fraction of open-telemetry span traces to sample")
 	otelProjectID              = flag.String("otel_project_id", "", "GCP project ID for OpenTelemetry exporter.")
 	// Prevent exporting expensive metrics by default, see https://github.com/transparency-dev/tesseract/issues/918
@@ -144,6 +169,24 @@ const grpcServiceConfig = `{
 func main() {
 	flag.Parse()
 	ctx := context.Background()
+	if *configFile != "" {
+		cfg, err := tesseract.LoadConfig(*configFile)
+		if err != nil {
+			fatal(ctx, "Failed to load config file", slog.Any("error", err))
+		}
+		if err := cfg.ApplyToFlags(flag.CommandLine, tesseract.BackendGCP); err != nil {
+			fatal(ctx, "Invalid config file", slog.String("path", *configFile), slog.Any("error", err))
+		}
+	}
+
+	chainValidationConfig := chainValidationConfigFromFlags()
+	if *validateConfig {
+		if err := tesseract.CheckConfig(*origin, chainValidationConfig, *witnessPolicyFile); err != nil {
+			fatal(ctx, "Invalid configuration", slog.Any("error", err))
+		}
+		slog.InfoContext(ctx, "Configuration is valid")
+		return
+	}
 
 	initLogging(ctx)
 	defer flushLogs()
@@ -151,9 +194,19 @@ func main() {
 	shutdownOTel := initOTel(ctx, *traceFraction, *origin, *otelProjectID, *dropMetrics)
 	defer shutdownOTel(ctx)
 
-	signer, err := NewSecretManagerSigner(ctx, *signerPublicKeySecretName, *signerPrivateKeySecretName)
-	if err != nil {
-		fatal(ctx, "Can't create secret manager signer", slog.Any("error", err))
+	var signer crypto.Signer
+	if *remoteSignerURL != "" {
+		s, err := remote.NewMTLSSigner(*remoteSignerURL, *remoteSignerPublicKey, *remoteSignerTLSCert, *remoteSignerTLSKey, *remoteSignerCA)
+		if err != nil {
+			fatal(ctx, "Can't create remote signer", slog.Any("error", err))
+		}
+		signer = s
+	} else {
+		s, err := NewSecretManagerSigner(ctx, *signerPublicKeySecretName, *signerPrivateKeySecretName)
+		if err != nil {
+			fatal(ctx, "Can't create secret manager signer", slog.Any("error", err))
+		}
+		signer = s
 	}
 
 	hc := &http.Client{
@@ -177,24 +230,7 @@ func main() {
 		fatal(ctx, "failed to initialize GCS backup storage for remotely fetched roots", slog.Any("error", err))
 	}
 
-	if len(rootsRemoteFetchURLs) == 0 {
-		rootsRemoteFetchURLs = []string{"https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV"}
-	}
-
-	chainValidationConfig := tesseract.ChainValidationConfig{
-		RootsPEMFile:             *rootsPemFile,
-		RootsRemoteFetchURLs:     rootsRemoteFetchURLs,
-		RootsRemoteFetchInterval: *rootsRemoteFetchInterval,
-		RootsRemoteFetchBackup:   fetchedRootsBackupStorage,
-		RejectExpired:            *rejectExpired,
-		RejectUnexpired:          *rejectUnexpired,
-		ExtKeyUsages:             *extKeyUsages,
-		RejectExtensions:         *rejectExtensions,
-		NotAfterStart:            notAfterStart.t,
-		NotAfterLimit:            notAfterLimit.t,
-		AcceptSHA1:               *acceptSHA1,
-		RejectRoots:              rootsRejectFingerprints,
-	}
+	chainValidationConfig.RootsRemoteFetchBackup = fetchedRootsBackupStorage
 	if *acceptSHA1 {
 		slog.InfoContext(ctx, `**** WARNING **** This server will accept chains signed
 using SHA-1 based algorithms. This feature is available to allow chains
@@ -202,10 +238,21 @@ submitted by Chrome's Merge Delay Monitor Root for the time being, but will
 eventually go away. See /internal/lax509/README.md for more information.`)
 	}
 
+	metadataStorage, err := gcp.NewMetadataStorage(ctx, *bucket, gcsClient)
+	if err != nil {
+		fatal(ctx, "failed to initialize GCS metadata storage", slog.Any("error", err))
+	}
+
 	hOpts := tesseract.LogHandlerOpts{
-		NotBeforeRL:       notBeforeRLFromFlags(),
+		NotBeforeRL:       notBeforeRL.RL,
 		DedupRL:           dedupRL,
 		MaxCertChainBytes: *maxCertChainBytes,
+		Metadata: &tesseract.LogMetadataOpts{
+			Description:   *metadataDescription,
+			MonitoringURL: *metadataMonitoringURL,
+			MMD:           *metadataMMD,
+			Storage:       metadataStorage,
+		},
 	}
 	logHandler, err := tesseract.NewLogHandler(ctx, *origin, signer, chainValidationConfig, newGCPStorage(gcsClient, hc), *httpDeadline, *maskInternalErrors, *pathPrefix, hOpts)
 	if err != nil {
@@ -275,6 +322,25 @@ func newGCPStorage(gc *gcs.Client, hc *http.Client) func(ctx context.Context, si
 			return nil, fmt.Errorf("failed to create new Spanner client: %v", err)
 		}
 
+		issuerStorage, err := gcp.NewIssuerStorage(ctx, *bucket, gc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize GCP issuer storage: %v", err)
+		}
+		var issuerQueue *storage.IssuerQueue
+		if *enableIssuerQueue {
+			qs, err := gcp.NewIssuerQueueStorage(ctx, *spannerDB, spannerClient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize GCP issuer queue storage: %v", err)
+			}
+			issuerQueue = storage.NewIssuerQueue(qs, issuerStorage)
+			signer = issuerQueue.WrapSigner(signer)
+		}
+		var signedCheckpoints *storage.SignedCheckpoints
+		if *enableCheckpointArchive {
+			signedCheckpoints = &storage.SignedCheckpoints{}
+			signer = signedCheckpoints.WrapSigner(signer)
+		}
+
 		gcpCfg := tgcp.Config{
 			Bucket:        *bucket,
 			Spanner:       *spannerDB,
@@ -348,63 +414,56 @@ func newGCPStorage(gc *gcs.Client, hc *http.Client) func(ctx context.Context, si
 			return nil, fmt.Errorf("failed to initialize GCP Tessera appender: %v", err)
 		}
 
-		issuerStorage, err := gcp.NewIssuerStorage(ctx, *bucket, gc)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize GCP issuer storage: %v", err)
-		}
-
 		sopts := storage.CTStorageOptions{
-			Appender:            appender,
-			Reader:              reader,
-			IssuerStorage:       issuerStorage,
-			AwaiterPollInterval: *awaiterPollInterval,
-			EnablePubAwaiter:    *enablePublicationAwaiter,
+			Appender:               appender,
+			Reader:                 reader,
+			IssuerStorage:          issuerStorage,
+			AwaiterPollInterval:    *awaiterPollInterval,
+			EnablePubAwaiter:       *enablePublicationAwaiter,
+			AuditInterval:          *auditInterval,
+			AuditBundleSampleRate:  *auditBundleSampleRate,
+			MergeDelayPollInterval: *mergeDelayPollInterval,
+			MMD:                    *metadataMMD,
+			MergeDelayAlertRatio:   *mergeDelayAlertRatio,
+			DedupSCTCacheSize:      *dedupSCTCacheSize,
+			DedupBundleCacheSize:   *dedupBundleCacheSize,
+			IssuerQueue:            issuerQueue,
+		}
+		if *enableCheckpointArchive {
+			checkpointArchive, err := gcp.NewCheckpointArchiveStorage(ctx, *bucket, gc)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize GCP checkpoint archive: %v", err)
+			}
+			sopts.CheckpointArchive = checkpointArchive
+			sopts.CheckpointArchiveInterval = *checkpointArchiveInterval
+			sopts.CheckpointInterval = *checkpointInterval
+			sopts.SignedCheckpoints = signedCheckpoints
+			sopts.CheckpointArchiveMaxAge = *checkpointArchiveMaxAge
 		}
 
 		return storage.NewCTStorage(ctx, &sopts)
 	}
 }
 
-type timestampFlag struct {
-	t *time.Time
-}
-
-func (t *timestampFlag) String() string {
-	if t.t != nil {
-		return t.t.Format(time.RFC3339)
-	}
-	return ""
-}
-
-func (t *timestampFlag) Set(w string) error {
-	if w == "" {
-		return nil
-	}
-	tt, err := time.Parse(time.RFC3339, w)
-	if err != nil {
-		return fmt.Errorf("can't parse %q as RFC3339 timestamp: %v", w, err)
-	}
-	t.t = &tt
-	return nil
-}
-
-func notBeforeRLFromFlags() *tesseract.NotBeforeRL {
-	if *notBeforeRL == "" {
-		return nil
-	}
-	bits := strings.Split(*notBeforeRL, ":")
-	if len(bits) != 2 {
-		fatal(context.Background(), "Invalid format for --rate_limit_old_not_before flag")
-	}
-	a, err := time.ParseDuration(bits[0])
-	if err != nil {
-		fatal(context.Background(), "Invalid age passed to --rate_limit_old_not_before flag", slog.String("age", bits[0]), slog.Any("error", err))
+// chainValidationConfigFromFlags returns the chain validation configuration
+// set by flags. RootsRemoteFetchBackup is left for the caller to set.
+func chainValidationConfigFromFlags() tesseract.ChainValidationConfig {
+	if len(rootsRemoteFetchURLs) == 0 {
+		rootsRemoteFetchURLs = []string{"https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV"}
 	}
-	l, err := strconv.ParseFloat(bits[1], 64)
-	if err != nil {
-		fatal(context.Background(), "Invalid rate limit passed to --rate_limit_old_not_before", slog.String("limit", bits[1]), slog.Any("error", err))
+	return tesseract.ChainValidationConfig{
+		RootsPEMFile:             *rootsPemFile,
+		RootsRemoteFetchURLs:     rootsRemoteFetchURLs,
+		RootsRemoteFetchInterval: *rootsRemoteFetchInterval,
+		RejectExpired:            *rejectExpired,
+		RejectUnexpired:          *rejectUnexpired,
+		ExtKeyUsages:             *extKeyUsages,
+		RejectExtensions:         *rejectExtensions,
+		NotAfterStart:            notAfterStart.T,
+		NotAfterLimit:            notAfterLimit.T,
+		AcceptSHA1:               *acceptSHA1,
+		RejectRoots:              rootsRejectFingerprints,
 	}
-	return &tesseract.NotBeforeRL{AgeThreshold: a, RateLimit: l}
 }
 
 // logFlushers holds cleanup callbacks (e.g. closing the Cloud Logging client)
@@ -16,54 +16,22 @@ package main
 
 import (
 	"context"
-	"crypto"
-	"crypto/ecdsa"
-	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"hash/crc32"
-	"io"
 	"log/slog"
 	"strings"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/transparency-dev/tesseract/internal/signer"
 	"golang.org/x/mod/sumdb/note"
 )
 
-// TODO: Move ECDSAWithSHA256Signer to internal signer package.
-// ECDSAWithSHA256Signer implements crypto.Signer using Google Cloud Secret Manager.
-// Only crypto.SHA256 and ECDSA are supported.
-type ECDSAWithSHA256Signer struct {
-	publicKey  *ecdsa.PublicKey
-	privateKey *ecdsa.PrivateKey
-}
-
-// Public returns the public key stored in the Signer object.
-func (s *ECDSAWithSHA256Signer) Public() crypto.PublicKey {
-	return s.publicKey
-}
-
-// Sign signs digest with the private key stored in Google Cloud Secret Manager.
-func (s *ECDSAWithSHA256Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
-	// Verify hash function and digest bytes length.
-	if opts == nil {
-		return nil, errors.New("opts cannot be nil")
-	}
-	if opts.HashFunc() != crypto.SHA256 {
-		return nil, fmt.Errorf("unsupported hash func: %v", opts.HashFunc())
-	}
-	if len(digest) != opts.HashFunc().Size() {
-		return nil, fmt.Errorf("digest bytes length %d does not match hash function bytes length %d", len(digest), opts.HashFunc().Size())
-	}
-
-	return ecdsa.SignASN1(rand, s.privateKey, digest)
-}
-
-// NewSecretManagerSigner creates a new signer that uses the ECDSA P-256 key pair in
+// NewSecretManagerSigner creates a new signer that uses the ECDSA or RSA key pair in
 // Google Cloud Secret Manager for signing digests.
-func NewSecretManagerSigner(ctx context.Context, publicKeySecretName, privateKeySecretName string) (*ECDSAWithSHA256Signer, error) {
+func NewSecretManagerSigner(ctx context.Context, publicKeySecretName, privateKeySecretName string) (*signer.SHA256Signer, error) {
 	client, err := secretmanager.NewClient(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create secret manager client: %w", err)
@@ -79,51 +47,26 @@ func NewSecretManagerSigner(ctx context.Context, publicKeySecretName, privateKey
 	}
 
 	// Public Key
-	var publicKey crypto.PublicKey
 	pemBlock, err := secretPEM(ctx, client, publicKeySecretName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public key secret PEM (%s): %w", publicKeySecretName, err)
 	}
-	switch pemBlock.Type {
-	case "PUBLIC KEY":
-		publicKey, err = x509.ParsePKIXPublicKey(pemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported PEM type: %s", pemBlock.Type)
-	}
+	publicKey, err := signer.ParsePublicKeyPEM(pemBlock)
 	if err != nil {
 		return nil, err
 	}
-	var ecdsaPublicKey *ecdsa.PublicKey
-	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("the public key stored in Secret Manager is not an ECDSA key")
-	}
 
 	// Private Key
-	var ecdsaPrivateKey *ecdsa.PrivateKey
 	pemBlock, err = secretPEM(ctx, client, privateKeySecretName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get private key secret PEM (%s): %w", privateKeySecretName, err)
 	}
-	switch pemBlock.Type {
-	case "EC PRIVATE KEY":
-		ecdsaPrivateKey, err = x509.ParseECPrivateKey(pemBlock.Bytes)
-	default:
-		return nil, fmt.Errorf("unsupported PEM type: %s", pemBlock.Type)
-	}
+	privateKey, err := signer.ParsePrivateKeyPEM(pemBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the correctness of the signer key pair
-	if !ecdsaPrivateKey.PublicKey.Equal(ecdsaPublicKey) {
-		return nil, errors.New("signer key pair doesn't match")
-	}
-
-	return &ECDSAWithSHA256Signer{
-		publicKey:  ecdsaPublicKey,
-		privateKey: ecdsaPrivateKey,
-	}, nil
+	return signer.NewSHA256Signer(publicKey, privateKey)
 }
 
 func secret(ctx context.Context, client *secretmanager.Client, secretName string) ([]byte, error) {
@@ -180,16 +123,3 @@ func NewSecretManagerNoteSigner(ctx context.Context, privateKeySecretName string
 
 	return note.NewSigner(string(secK))
 }
-
-// multiStringFlag allows a flag to be specified multiple times on the command
-// line, and stores all of these values.
-type multiStringFlag []string
-
-func (ms *multiStringFlag) String() string {
-	return strings.Join(*ms, ",")
-}
-
-func (ms *multiStringFlag) Set(w string) error {
-	*ms = append(*ms, w)
-	return nil
-}
@@ -0,0 +1,381 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// init bootstraps a new TesseraCT log for the POSIX or AWS backends.
+//
+// It generates the log signing key and optional additional note signers,
+// stores them either on local disk or in AWS Secrets Manager, creates the
+// storage layout, seeds it with trusted roots, and prints the values needed to
+// add the log to a CT log list.
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/transparency-dev/tesseract/internal/ccadb"
+	"github.com/transparency-dev/tesseract/internal/ct"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+	"github.com/transparency-dev/tesseract/storage"
+	sAWS "github.com/transparency-dev/tesseract/storage/aws"
+	sPOSIX "github.com/transparency-dev/tesseract/storage/posix"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	backendPOSIX = "posix"
+	backendAWS   = "aws"
+
+	ktECDSA = "ecdsa"
+	ktRSA   = "rsa"
+
+	// rsaKeyBits is the size of generated RSA log keys.
+	rsaKeyBits = 2048
+
+	secretFilePerm = 0o600
+	publicFilePerm = 0o644
+	dirPerm        = 0o755
+)
+
+var (
+	origin            = flag.String("origin", "", "Origin of the log, for checkpoints. This MUST match the log's submission prefix as per https://c2sp.org/static-ct-api.")
+	backend           = flag.String("backend", backendPOSIX, "Backend the log will run on: '"+backendPOSIX+"' or '"+backendAWS+"'.")
+	keyType           = flag.String("key_type", ktECDSA, "Algorithm of the log key to create: '"+ktECDSA+"' (P-256) or '"+ktRSA+"' (2048 bits).")
+	additionalSigners = flag.Int("additional_signers", 0, "Number of Ed25519 note keys to generate for use with --additional_signer.")
+	keyDir            = flag.String("key_dir", ".", "Directory to write keys to. Used with --backend="+backendPOSIX+", and with --backend="+backendAWS+" when --aws_secret_prefix is empty.")
+	awsSecretPrefix   = flag.String("aws_secret_prefix", "", "If set with --backend="+backendAWS+", keys are stored in AWS Secrets Manager under names with this prefix, and '-log-secret', '-log-public', '-signer-N-secret' and '-signer-N-public' suffixes.")
+	storageDir        = flag.String("storage_dir", "", "Path to root of log storage. Required with --backend="+backendPOSIX+".")
+	bucket            = flag.String("bucket", "", "Name of the existing S3 bucket to store the log in. Required with --backend="+backendAWS+".")
+	rootsPemFile      = flag.String("roots_pem_file", "", "Path to a file containing root certificates to seed the log storage with.")
+	rootsFetchURL     = flag.String("roots_fetch_url", "", "CCADB CSV URL to fetch root certificates from to seed the log storage with, e.g. https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV.")
+	description       = flag.String("description", "", "Description of the log for the log list fragment. Defaults to the origin.")
+	submissionURL     = flag.String("submission_url", "", "Submission URL of the log for the log list fragment. Defaults to https://<origin>/.")
+	monitoringURL     = flag.String("monitoring_url", "", "Monitoring URL of the log for the log list fragment.")
+	mmd               = flag.Duration("mmd", 60*time.Second, "Maximum merge delay of the log for the log list fragment.")
+	slogLevel         = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+
+	notAfterStart flagutil.Timestamp
+	notAfterLimit flagutil.Timestamp
+)
+
+func init() {
+	flag.Var(&notAfterStart, "not_after_start", "Start of the log's temporal interval for the log list fragment, RFC3339 format. Must be set with --not_after_limit.")
+	flag.Var(&notAfterLimit, "not_after_limit", "End of the log's temporal interval for the log list fragment, RFC3339 format. Must be set with --not_after_start.")
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+
+	if err := validateFlags(); err != nil {
+		slog.ErrorContext(ctx, "Invalid flags", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	logKey, err := genLogKey(*keyType)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to generate log key", slog.Any("error", err))
+		os.Exit(1)
+	}
+	keys, err := logKeyFiles(logKey)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode log key", slog.Any("error", err))
+		os.Exit(1)
+	}
+	for i := range *additionalSigners {
+		skey, vkey, err := note.GenerateKey(rand.Reader, *origin)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to generate note key", slog.Any("error", err))
+			os.Exit(1)
+		}
+		keys = append(keys,
+			keyFile{name: fmt.Sprintf("signer-%d-secret", i), ext: ".key", value: skey, secret: true},
+			keyFile{name: fmt.Sprintf("signer-%d-public", i), ext: ".pub", value: vkey})
+	}
+
+	if *backend == backendAWS && *awsSecretPrefix != "" {
+		err = storeKeysInSecretsManager(ctx, *awsSecretPrefix, keys)
+	} else {
+		err = storeKeysInDir(ctx, *keyDir, keys)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to store keys", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	rootsStorage, err := newRootsStorage(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to initialize log storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if err := seedRoots(ctx, rootsStorage); err != nil {
+		slog.ErrorContext(ctx, "Failed to seed roots", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	fragment, err := logListFragment(logKey.Public())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to build log list fragment", slog.Any("error", err))
+		os.Exit(1)
+	}
+	fragmentJSON, err := json.MarshalIndent(fragment, "", "  ")
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to marshal log list fragment", slog.Any("error", err))
+		os.Exit(1)
+	}
+	fmt.Printf("Log ID: %s\nPublic key: %s\n\nLog list fragment:\n%s\n", fragment.LogID, fragment.Key, fragmentJSON)
+}
+
+func validateFlags() error {
+	if *origin == "" {
+		return errors.New("--origin must be set")
+	}
+	switch *backend {
+	case backendPOSIX:
+		if *storageDir == "" {
+			return errors.New("--storage_dir must be set with --backend=" + backendPOSIX)
+		}
+	case backendAWS:
+		if *bucket == "" {
+			return errors.New("--bucket must be set with --backend=" + backendAWS)
+		}
+	default:
+		return fmt.Errorf("unsupported --backend %q", *backend)
+	}
+	switch strings.ToLower(*keyType) {
+	case ktECDSA, ktRSA:
+	default:
+		return fmt.Errorf("unsupported --key_type %q", *keyType)
+	}
+	if *additionalSigners < 0 {
+		return errors.New("--additional_signers must not be negative")
+	}
+	if (notAfterStart.T == nil) != (notAfterLimit.T == nil) {
+		return errors.New("--not_after_start and --not_after_limit must be set together")
+	}
+	if notAfterStart.T != nil && !notAfterStart.T.Before(*notAfterLimit.T) {
+		return errors.New("--not_after_start must be before --not_after_limit")
+	}
+	return nil
+}
+
+// genLogKey generates a new log signing key of the given type.
+func genLogKey(kt string) (crypto.Signer, error) {
+	switch strings.ToLower(kt) {
+	case ktECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ktRSA:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("unsupported --key_type %q", kt)
+	}
+}
+
+// keyFile is a named key to be stored by init.
+type keyFile struct {
+	name string
+	// ext is the file extension used when the key is stored on disk.
+	ext    string
+	value  string
+	secret bool
+}
+
+// logKeyFiles returns the PEM encodings of the private and public halves of k,
+// as read by the --private_key and --signer_*_key_* flags of the log binaries.
+func logKeyFiles(k crypto.Signer) ([]keyFile, error) {
+	var sec *pem.Block
+	switch k := k.(type) {
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal private key: %v", err)
+		}
+		sec = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case *rsa.PrivateKey:
+		sec = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", k)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(k.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	return []keyFile{
+		{name: "log-secret", ext: ".pem", value: string(pem.EncodeToMemory(sec)), secret: true},
+		{name: "log-public", ext: ".pem", value: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))},
+	}, nil
+}
+
+// storeKeysInDir writes keys to dir, refusing to overwrite existing files.
+//
+// Secret keys are only readable by the current user.
+func storeKeysInDir(ctx context.Context, dir string, keys []keyFile) error {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("os.MkdirAll(%q): %v", dir, err)
+	}
+	for _, k := range keys {
+		p := filepath.Join(dir, k.name+k.ext)
+		perm := os.FileMode(publicFilePerm)
+		if k.secret {
+			perm = secretFilePerm
+		}
+		f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(k.value); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write %q: %v", p, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to close %q: %v", p, err)
+		}
+		slog.InfoContext(ctx, "Wrote key", slog.String("path", p))
+	}
+	return nil
+}
+
+// storeKeysInSecretsManager creates one AWS Secrets Manager secret per key.
+//
+// Secret creation fails if a secret with the same name already exists.
+func storeKeysInSecretsManager(ctx context.Context, prefix string, keys []keyFile) error {
+	sdkConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load default AWS configuration: %v", err)
+	}
+	client := secretsmanager.NewFromConfig(sdkConfig)
+	for _, k := range keys {
+		name := fmt.Sprintf("%s-%s", prefix, k.name)
+		if _, err := client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+			Name:         aws.String(name),
+			SecretString: aws.String(k.value),
+			Description:  aws.String(fmt.Sprintf("TesseraCT %s key for %s", k.name, *origin)),
+		}); err != nil {
+			return fmt.Errorf("failed to create secret %q: %v", name, err)
+		}
+		slog.InfoContext(ctx, "Created secret", slog.String("name", name))
+	}
+	return nil
+}
+
+// newRootsStorage creates the log storage layout and returns the storage that
+// the log loads its roots from on startup.
+func newRootsStorage(ctx context.Context) (storage.RootsStorage, error) {
+	switch *backend {
+	case backendPOSIX:
+		if _, err := sPOSIX.NewIssuerStorage(ctx, *storageDir); err != nil {
+			return nil, err
+		}
+		return sPOSIX.NewRootsStorage(ctx, *storageDir)
+	case backendAWS:
+		return sAWS.NewRootsStorage(ctx, sAWS.Options{Bucket: *bucket})
+	default:
+		return nil, fmt.Errorf("unsupported backend %q", *backend)
+	}
+}
+
+// seedRoots stores the roots from --roots_pem_file and --roots_fetch_url in s.
+//
+// Roots are keyed by the hex encoded SHA-256 of their DER bytes, as the log does
+// for roots it fetches remotely.
+func seedRoots(ctx context.Context, s storage.RootsStorage) error {
+	var pems [][]byte
+	if *rootsPemFile != "" {
+		pool, err := x509util.NewPEMCertPool(nil)
+		if err != nil {
+			return err
+		}
+		if err := pool.AppendCertsFromPEMFile(*rootsPemFile); err != nil {
+			return fmt.Errorf("failed to read roots from %q: %v", *rootsPemFile, err)
+		}
+		for _, c := range pool.RawCertificates() {
+			pems = append(pems, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}))
+		}
+	}
+	if *rootsFetchURL != "" {
+		rows, err := ccadb.Fetch(ctx, *rootsFetchURL, []string{ccadb.ColPEM})
+		if err != nil {
+			return fmt.Errorf("failed to fetch roots from %q: %v", *rootsFetchURL, err)
+		}
+		for _, r := range rows {
+			if len(r) > 0 {
+				pems = append(pems, r[0])
+			}
+		}
+	}
+
+	kvs := make([]storage.KV, 0, len(pems))
+	for _, p := range pems {
+		block, _ := pem.Decode(p)
+		if block == nil {
+			return errors.New("failed to decode root PEM")
+		}
+		sha := sha256.Sum256(block.Bytes)
+		kvs = append(kvs, storage.KV{K: []byte(hex.EncodeToString(sha[:])), V: p})
+	}
+	if len(kvs) == 0 {
+		slog.WarnContext(ctx, "No roots to seed the log with")
+		return nil
+	}
+	if err := s.AddIfNotExist(ctx, kvs); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Seeded roots", slog.Int("count", len(kvs)))
+	return nil
+}
+
+// logListFragment returns the log list entry for a log with the given public key.
+//
+// The flags it reads must have been checked by validateFlags.
+func logListFragment(pub crypto.PublicKey) (*ct.LogMetadata, error) {
+	opts := ct.MetadataOptions{
+		Description:   *description,
+		MonitoringURL: *monitoringURL,
+		MMD:           *mmd,
+	}
+	if opts.Description == "" {
+		opts.Description = *origin
+	}
+	opts.NotAfterStart, opts.NotAfterLimit = notAfterStart.T, notAfterLimit.T
+	m, err := ct.NewLogMetadata(*origin, pub, opts)
+	if err != nil {
+		return nil, err
+	}
+	if *submissionURL != "" {
+		m.SubmissionURL = *submissionURL
+	}
+	return m, nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/transparency-dev/tesseract/internal/ct"
+)
+
+// setFlags resets the flags of init to their defaults, and then parses args.
+func setFlags(t *testing.T, args ...string) {
+	t.Helper()
+	flag.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, "test.") {
+			return
+		}
+		if err := f.Value.Set(f.DefValue); err != nil {
+			t.Fatalf("failed to reset --%s: %v", f.Name, err)
+		}
+	})
+	notAfterStart.T, notAfterLimit.T = nil, nil
+	if err := flag.CommandLine.Parse(args); err != nil {
+		t.Fatalf("Parse(%v): %v", args, err)
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			desc: "posix",
+			args: []string{"--origin=example.com/log", "--storage_dir=/tmp/log"},
+		},
+		{
+			desc: "aws with temporal interval",
+			args: []string{"--origin=example.com/log", "--backend=aws", "--bucket=log", "--key_type=rsa", "--not_after_start=2026-01-01T00:00:00Z", "--not_after_limit=2027-01-01T00:00:00Z"},
+		},
+		{
+			desc:    "no origin",
+			args:    []string{"--storage_dir=/tmp/log"},
+			wantErr: true,
+		},
+		{
+			desc:    "posix without storage_dir",
+			args:    []string{"--origin=example.com/log", "--bucket=log"},
+			wantErr: true,
+		},
+		{
+			desc:    "aws without bucket",
+			args:    []string{"--origin=example.com/log", "--backend=aws", "--storage_dir=/tmp/log"},
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported backend",
+			args:    []string{"--origin=example.com/log", "--backend=gcp"},
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported key type",
+			args:    []string{"--origin=example.com/log", "--storage_dir=/tmp/log", "--key_type=ed25519"},
+			wantErr: true,
+		},
+		{
+			desc:    "negative additional signers",
+			args:    []string{"--origin=example.com/log", "--storage_dir=/tmp/log", "--additional_signers=-1"},
+			wantErr: true,
+		},
+		{
+			desc:    "only not_after_start",
+			args:    []string{"--origin=example.com/log", "--storage_dir=/tmp/log", "--not_after_start=2026-01-01T00:00:00Z"},
+			wantErr: true,
+		},
+		{
+			desc:    "only not_after_limit",
+			args:    []string{"--origin=example.com/log", "--storage_dir=/tmp/log", "--not_after_limit=2026-01-01T00:00:00Z"},
+			wantErr: true,
+		},
+		{
+			desc:    "not_after_start after not_after_limit",
+			args:    []string{"--origin=example.com/log", "--storage_dir=/tmp/log", "--not_after_start=2027-01-01T00:00:00Z", "--not_after_limit=2026-01-01T00:00:00Z"},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			setFlags(t, test.args...)
+			err := validateFlags()
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("validateFlags() = %v, want error: %t", err, test.wantErr)
+			}
+		})
+	}
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		setFlags(t)
+		if err := flag.Set("not_after_start", "2026-01-01"); err == nil {
+			t.Error("flag.Set(not_after_start, 2026-01-01) succeeded, want error")
+		}
+	})
+}
+
+func TestLogListFragment(t *testing.T) {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(k.Public())
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey(): %v", err)
+	}
+	logID := sha256.Sum256(der)
+	key := base64.StdEncoding.EncodeToString(der)
+
+	for _, test := range []struct {
+		desc string
+		args []string
+		want *ct.LogMetadata
+	}{
+		{
+			desc: "defaults",
+			args: []string{"--origin=example.com/log", "--storage_dir=/tmp/log"},
+			want: &ct.LogMetadata{
+				Description:   "example.com/log",
+				LogID:         base64.StdEncoding.EncodeToString(logID[:]),
+				Key:           key,
+				SubmissionURL: "https://example.com/log/",
+				MMD:           60,
+			},
+		},
+		{
+			desc: "all flags",
+			args: []string{
+				"--origin=example.com/log",
+				"--storage_dir=/tmp/log",
+				"--description=Example log",
+				"--submission_url=https://submit.example.com/",
+				"--monitoring_url=https://monitor.example.com/",
+				"--mmd=24h",
+				"--not_after_start=2026-01-01T00:00:00Z",
+				"--not_after_limit=2027-01-01T00:00:00+01:00",
+			},
+			want: &ct.LogMetadata{
+				Description:   "Example log",
+				LogID:         base64.StdEncoding.EncodeToString(logID[:]),
+				Key:           key,
+				SubmissionURL: "https://submit.example.com/",
+				MonitoringURL: "https://monitor.example.com/",
+				MMD:           86400,
+				TemporalInterval: &ct.TemporalInterval{
+					StartInclusive: "2026-01-01T00:00:00Z",
+					EndExclusive:   "2026-12-31T23:00:00Z",
+				},
+			},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			setFlags(t, test.args...)
+			if err := validateFlags(); err != nil {
+				t.Fatalf("validateFlags(): %v", err)
+			}
+			got, err := logListFragment(k.Public())
+			if err != nil {
+				t.Fatalf("logListFragment(): %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("logListFragment() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The mysql binary runs the CT personality on top of a single MySQL database.
+package main
+
+import (
+	"context"
+	"crypto"
+	"database/sql"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/transparency-dev/tessera"
+	aws_as "github.com/transparency-dev/tessera/storage/aws/antispam"
+	tmysql "github.com/transparency-dev/tessera/storage/mysql"
+	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+	"github.com/transparency-dev/tesseract/internal/signer"
+	"github.com/transparency-dev/tesseract/internal/signer/remote"
+	"github.com/transparency-dev/tesseract/storage"
+	"github.com/transparency-dev/tesseract/storage/mysql"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func init() {
+	flag.Var(&notAfterStart, "not_after_start", "Start of the range of acceptable NotAfter values, inclusive. Leaving this unset or empty implies no lower bound to the range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
+	flag.Var(&notAfterLimit, "not_after_limit", "Cut off point of notAfter dates - only notAfter dates strictly *before* notAfterLimit will be accepted. Leaving this unset or empty means no upper bound on the accepted range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
+	flag.Var(&notBeforeRL, "rate_limit_old_not_before", "Optionally rate limits submissions with old notBefore dates. Expects a value of with the format: \"<go duration>:<rate limit>\", e.g. \"30d:50\" would impose a limit of 50 certs/s on submissions whose notBefore date is >= 30days old.")
+	flag.Var(&rootsRejectFingerprints, "roots_reject_fingerprints", "Hex-encoded SHA-256 fingerprint of a root certificate to reject. May be specified multiple times.")
+	flag.Float64Var(&dedupRL, "rate_limit_dedup", 100, "Rate limit for resolving duplicate submissions, in requests per second - i.e. duplicate requests for already integrated entries, which need to be fetched from the log storage by TesseraCT to extract their timestamp. When 0, all duplicate submissions are rejected. When negative, no rate limit is applied.")
+	flag.Var(&rootsRemoteFetchURLs, "roots_remote_fetch_url", "URL to fetch additional trusted roots from. May be specified multiple times.")
+}
+
+// Global flags that affect all log instances.
+var (
+	notAfterStart           flagutil.Timestamp
+	notAfterLimit           flagutil.Timestamp
+	notBeforeRL             = tesseract.NotBeforeRLFlag{RL: &tesseract.NotBeforeRL{AgeThreshold: 28 * time.Hour, RateLimit: 500}}
+	rootsRejectFingerprints flagutil.MultiString
+	rootsRemoteFetchURLs    flagutil.MultiString
+	dedupRL                 float64
+
+	// Configuration file flags
+	configFile     = flag.String("config", "", "(Optional) Path to a JSON configuration file. Other formats, such as YAML, aren't supported. Flags set on the command line override values from the file. See cmd/tesseract/README.md#Configuration-file.")
+	validateConfig = flag.Bool("validate_config", false, "If true, checks the configuration and exits without starting the log.")
+
+	// Functionality flags
+	httpEndpoint             = flag.String("http_endpoint", "localhost:6962", "Endpoint for HTTP (host:port).")
+	maskInternalErrors       = flag.Bool("mask_internal_errors", false, "Don't return error strings with Internal Server Error HTTP responses.")
+	origin                   = flag.String("origin", "", "Origin of the log, for checkpoints. This MUST match the log's submission prefix as per https://c2sp.org/static-ct-api.")
+	pathPrefix               = flag.String("path_prefix", "", "Prefix to use on endpoints URL paths: HOST:PATH_PREFIX/ct/v1/ENDPOINT. Monitoring APIs are served under the same prefix.")
+	rootsPemFile             = flag.String("roots_pem_file", "", "Path to the file containing root certificates that are acceptable to the log.")
+	rootsRemoteFetchInterval = flag.Duration("roots_remote_fetch_interval", time.Duration(0), "Interval between two fetches from roots_fetch_url, e.g. \"1h\". Set to \"0s\" to disable.")
+	rejectExpired            = flag.Bool("reject_expired", false, "If true then the certificate validity period will be checked against the current time during the validation of submissions. This will cause expired certificates to be rejected.")
+	rejectUnexpired          = flag.Bool("reject_unexpired", false, "If true then TesseraCT rejects certificates that are either currently valid or not yet valid.")
+	extKeyUsages             = flag.String("ext_key_usages", "Any", "If set, will restrict the set of such usages that the server will accept. By default, 'Any' accepts all chains. Accepted values are defined in internal/ct.")
+	rejectExtensions         = flag.String("reject_extension", "", "A list of X.509 extension OIDs, in dotted string form (e.g. '2.3.4.5') which, if present, should cause submissions to be rejected.")
+	acceptSHA1               = flag.Bool("accept_sha1_signing_algorithms", true, "If true, accept chains that use SHA-1 based signing algorithms. This flag will eventually be removed, and such algorithms will be rejected.")
+	enablePublicationAwaiter = flag.Bool("enable_publication_awaiter", true, "If true, waits for the submitted certificate to be covered by a published checkpoint before responding to an add-* request.")
+	witnessPolicyFile        = flag.String("witness_policy_file", "", "(Optional) Path to the file containing the witness policy in the format described at https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md")
+	witnessTimeout           = flag.Duration("witness_timeout", tessera.DefaultWitnessTimeout, "Maximum time to wait for witness responses.")
+	metadataDescription      = flag.String("metadata_description", "", "Description of the log, published in its metadata document.")
+	metadataMonitoringURL    = flag.String("metadata_monitoring_url", "", "URL prefix of the log's monitoring APIs, published in its metadata document.")
+	metadataMMD              = flag.Duration("metadata_mmd", time.Minute, "Maximum merge delay of the log, published in its metadata document.")
+
+	// Performance flags
+	httpDeadline                = flag.Duration("http_deadline", time.Second*10, "Deadline for HTTP requests.")
+	maxCertChainBytes           = flag.Int64("max_cert_chain_bytes", 512<<10, "Maximum size of certificate chain in bytes for add-chain and add-pre-chain endpoints (default: 512 KiB)")
+	inMemoryAntispamCacheSize   = flag.String("inmemory_antispam_cache_size", "256k", "Maximum number of entries to keep in the in-memory antispam cache. Unitless with SI metric prefixes, such as '256k'.")
+	checkpointInterval          = flag.Duration("checkpoint_interval", 1500*time.Millisecond, "Interval between publishing checkpoints when the log has grown")
+	checkpointRepublishInterval = flag.Duration("checkpoint_republish_interval", 30*time.Second, "Interval between republishing a checkpoint for a log which hasn't grown since the previous checkpoint was published")
+	batchMaxSize                = flag.Uint("batch_max_size", tessera.DefaultBatchMaxSize, "Maximum number of entries to process in a single sequencing batch.")
+	batchMaxAge                 = flag.Duration("batch_max_age", tessera.DefaultBatchMaxAge, "Maximum age of entries in a single sequencing batch.")
+	pushbackMaxOutstanding      = flag.Uint("pushback_max_outstanding", tessera.DefaultPushbackMaxOutstanding, "Maximum number of in-flight add requests - i.e. the number of entries with sequence numbers assigned, but which are not yet integrated into the log.")
+	pushbackMaxAntispamLag      = flag.Uint("pushback_max_antispam_lag", aws_as.DefaultPushbackThreshold, "Maximum permitted lag for antispam follower, before log starts returning pushback.")
+	garbageCollectionInterval   = flag.Duration("garbage_collection_interval", 10*time.Second, "Interval between scans to remove obsolete partial tiles and entry bundles. Set to 0 to disable.")
+	awaiterPollInterval         = flag.Duration("awaiter_poll_interval", storage.DefaultAwaiterPollInterval, "Interval between two checkpoint polls by the awaiter. Used for antispam, and if enable_publication_awaiter is set, to block add-* requests responses. Must be strictly positive or defaults to DefaultAwaiterPollInterval.")
+	auditInterval               = flag.Duration("audit_interval", time.Minute, "Interval at which the self-auditor checks that newly published checkpoints are consistent with the previous ones. Set to zero to disable.")
+	auditBundleSampleRate       = flag.Float64("audit_bundle_sample_rate", 0.01, "Fraction of new entry bundles the self-auditor re-hashes against tiles, between 0 and 1.")
+	enableCheckpointArchive     = flag.Bool("enable_checkpoint_archive", false, "If true, newly published checkpoints, and their witness cosignatures, are archived under checkpoints/<size>.")
+	checkpointArchiveInterval   = flag.Duration("checkpoint_archive_interval", 0, "Interval at which the latest checkpoint is read to be archived. Defaults to --checkpoint_interval, and must not be longer, so that no checkpoint is replaced before being archived.")
+	checkpointArchiveMaxAge     = flag.Duration("checkpoint_archive_max_age", 0, "How long archived checkpoints are kept for. Set to zero to keep them forever.")
+	mergeDelayPollInterval      = flag.Duration("merge_delay_poll_interval", time.Second, "Interval at which checkpoints are read to measure the merge delay of issued SCTs. Set to zero to disable merge delay tracking.")
+	mergeDelayAlertRatio        = flag.Float64("merge_delay_alert_ratio", 0.8, "Fraction of --metadata_mmd after which SCTs whose entry isn't covered by a checkpoint yet raise an alert.")
+	dedupSCTCacheSize           = flag.Int("dedup_sct_cache_size", storage.DefaultDedupSCTCacheSize, "Number of SCT inputs cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	dedupBundleCacheSize        = flag.Int("dedup_bundle_cache_size", storage.DefaultDedupBundleCacheSize, "Number of full entry bundles cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	enableIssuerQueue           = flag.Bool("enable_issuer_queue", false, "If true, issuer writes are durably queued in a MySQL table, to be written to the issuers table asynchronously. Checkpoints are only signed once the issuers of the entries they cover are written. The queue is shared by all the instances of the log.")
+
+	// Infrastructure setup flags
+	dbName                = flag.String("db_name", "", "MySQL database name for the log, issuers and roots.")
+	antispamDBName        = flag.String("antispam_db_name", "", "(Optional) MySQL database name for antispam. Antispam is only enabled in memory if unset.")
+	dbHost                = flag.String("db_host", "", "MySQL host")
+	dbPort                = flag.Int("db_port", 3306, "MySQL port")
+	dbUser                = flag.String("db_user", "", "MySQL user")
+	dbPassword            = flag.String("db_password", "", "MySQL password")
+	dbMaxConns            = flag.Int("db_max_conns", 0, "Maximum connections to the database, defaults to 0, i.e unlimited")
+	dbMaxIdle             = flag.Int("db_max_idle_conns", 2, "Maximum idle database connections in the connection pool, defaults to 2")
+	privKeyFile           = flag.String("private_key", "", "Location of private key file. If unset, uses the contents of the LOG_PRIVATE_KEY environment variable.")
+	remoteSignerURL       = flag.String("remote_signer_url", "", "(Optional) URL of a remote_signer daemon holding the log private key. If set, the log key is not loaded locally and the --remote_signer_* flags must also be set.")
+	remoteSignerPublicKey = flag.String("remote_signer_public_key", "", "Path to the PEM encoded public key of the log key held by the remote signer.")
+	remoteSignerTLSCert   = flag.String("remote_signer_tls_cert", "", "Path to the PEM encoded client certificate used to authenticate to the remote signer.")
+	remoteSignerTLSKey    = flag.String("remote_signer_tls_key", "", "Path to the PEM encoded private key for --remote_signer_tls_cert.")
+	remoteSignerCA        = flag.String("remote_signer_ca", "", "Path to a PEM file containing the CA certificates which issue the remote signer's certificate.")
+	slogLevel             = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+)
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+	if *configFile != "" {
+		cfg, err := tesseract.LoadConfig(*configFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load config file", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := cfg.ApplyToFlags(flag.CommandLine, tesseract.BackendMySQL); err != nil {
+			slog.ErrorContext(ctx, "Invalid config file", slog.String("path", *configFile), slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
+
+	chainValidationConfig := chainValidationConfigFromFlags()
+	if *validateConfig {
+		if err := tesseract.CheckConfig(*origin, chainValidationConfig, *witnessPolicyFile); err != nil {
+			slog.ErrorContext(ctx, "Invalid configuration", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Configuration is valid")
+		return
+	}
+
+	signer := signerFromFlags()
+
+	db, err := sql.Open("mysql", mysqlConfig(*dbName).FormatDSN())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to open MySQL database", slog.Any("error", err))
+		os.Exit(1)
+	}
+	db.SetMaxOpenConns(*dbMaxConns)
+	db.SetMaxIdleConns(*dbMaxIdle)
+	if err := db.PingContext(ctx); err != nil {
+		slog.ErrorContext(ctx, "Failed to connect to MySQL database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	fetchedRootsBackupStorage, err := mysql.NewRootsStorage(ctx, db)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to initialize MySQL backup storage for remotely fetched roots", slog.Any("error", err))
+		os.Exit(1)
+	}
+	chainValidationConfig.RootsRemoteFetchBackup = fetchedRootsBackupStorage
+	if *acceptSHA1 {
+		slog.InfoContext(ctx, `**** WARNING **** This server will accept chains signed
+using SHA-1 based algorithms. This feature is available to allow chains
+submitted by Chrome's Merge Delay Monitor Root for the time being, but will
+eventually go away. See /internal/lax509/README.md for more information.`)
+	}
+
+	metadataStorage, err := mysql.NewMetadataStorage(ctx, db)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to initialize MySQL metadata storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	issuerStorage, err := mysql.NewIssuerStorage(ctx, db)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to initialize MySQL issuer storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	var checkpointArchive *mysql.CheckpointArchiveStorage
+	if *enableCheckpointArchive {
+		checkpointArchive, err = mysql.NewCheckpointArchiveStorage(ctx, db)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to initialize MySQL checkpoint archive", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
+	// The log reader is only available once the storage is created, which
+	// NewLogHandler does.
+	var reader tessera.LogReader
+	hOpts := tesseract.LogHandlerOpts{
+		NotBeforeRL:       notBeforeRL.RL,
+		DedupRL:           dedupRL,
+		MaxCertChainBytes: *maxCertChainBytes,
+		Metadata: &tesseract.LogMetadataOpts{
+			Description:   *metadataDescription,
+			MonitoringURL: *metadataMonitoringURL,
+			MMD:           *metadataMMD,
+			Storage:       metadataStorage,
+		},
+	}
+	logHandler, err := tesseract.NewLogHandler(ctx, *origin, signer, chainValidationConfig, newMySQLStorageFunc(db, issuerStorage, checkpointArchive, &reader), *httpDeadline, *maskInternalErrors, *pathPrefix, hOpts)
+	if err != nil {
+		slog.ErrorContext(ctx, "Can't initialize CT HTTP Server", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", logHandler)
+	rOpts := mysql.ReadHandlerOpts{
+		Reader:   reader,
+		Issuers:  issuerStorage,
+		Metadata: metadataStorage,
+	}
+	if checkpointArchive != nil {
+		rOpts.Checkpoints = checkpointArchive
+	}
+	mysql.RegisterReadHandlers(mux, *pathPrefix, rOpts)
+
+	slog.InfoContext(ctx, "**** CT HTTP Server Starting ****")
+	http.Handle("/", otelhttp.NewHandler(mux, "/"))
+
+	// Bring up the HTTP server and serve until we get a signal not to.
+	srv := http.Server{
+		Addr: *httpEndpoint,
+		// Set timeout for reading headers to avoid a slowloris attack.
+		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    8 << 10, // 8 KiB
+	}
+	shutdownWG := new(sync.WaitGroup)
+	shutdownWG.Add(1)
+	go awaitSignal(func() {
+		defer shutdownWG.Done()
+		// Allow 60s for any pending requests to finish then terminate any stragglers
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+		defer cancel()
+		slog.InfoContext(ctx, "Shutting down HTTP server...")
+		if err := srv.Shutdown(ctx); err != nil {
+			slog.ErrorContext(ctx, "srv.Shutdown()", slog.Any("error", err))
+		}
+		slog.InfoContext(ctx, "HTTP server shutdown")
+	})
+
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		slog.WarnContext(ctx, "Server exited", slog.Any("error", err))
+	}
+	// Wait will only block if the function passed to awaitSignal was called,
+	// in which case it'll block until the HTTP server has gracefully shutdown
+	shutdownWG.Wait()
+}
+
+// awaitSignal waits for standard termination signals, then runs the given
+// function; it should be run as a separate goroutine.
+func awaitSignal(doneFn func()) {
+	// Arrange notification for the standard set of signals used to terminate a server
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	// Now block main and wait for a signal
+	sig := <-sigs
+	slog.WarnContext(context.Background(), "Signal received", slog.Any("signal", sig))
+
+	doneFn()
+}
+
+// newMySQLStorageFunc returns a function creating a CTStorage on top of
+// Tessera's MySQL driver. The log reader it creates is stored in reader.
+func newMySQLStorageFunc(db *sql.DB, issuerStorage storage.IssuerStorage, checkpointArchive *mysql.CheckpointArchiveStorage, reader *tessera.LogReader) func(ctx context.Context, signer note.Signer) (*storage.CTStorage, error) {
+	return func(ctx context.Context, signer note.Signer) (*storage.CTStorage, error) {
+		var issuerQueue *storage.IssuerQueue
+		if *enableIssuerQueue {
+			qs, err := mysql.NewIssuerQueueStorage(ctx, db)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize MySQL issuer queue storage: %v", err)
+			}
+			issuerQueue = storage.NewIssuerQueue(qs, issuerStorage)
+			signer = issuerQueue.WrapSigner(signer)
+		}
+		var signedCheckpoints *storage.SignedCheckpoints
+		if *enableCheckpointArchive {
+			signedCheckpoints = &storage.SignedCheckpoints{}
+			signer = signedCheckpoints.WrapSigner(signer)
+		}
+
+		driver, err := tmysql.New(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize MySQL Tessera storage driver: %v", err)
+		}
+
+		// Tessera's AWS antispam implementation only depends on MySQL.
+		var antispam tessera.Antispam
+		if *antispamDBName != "" {
+			antispam, err = aws_as.NewAntispam(ctx, mysqlConfig(*antispamDBName).FormatDSN(), aws_as.AntispamOpts{PushbackThreshold: *pushbackMaxAntispamLag})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create new MySQL antispam storage: %v", err)
+			}
+		}
+
+		antispamCacheSize, unit, error := humanize.ParseSI(*inMemoryAntispamCacheSize)
+		if unit != "" {
+			return nil, fmt.Errorf("invalid antispam cache size, used unit %q, want none", unit)
+		}
+		if error != nil {
+			return nil, fmt.Errorf("invalid antispam cache size: %v", error)
+		}
+
+		opts := tessera.NewAppendOptions().
+			WithCheckpointSigner(signer).
+			WithCTLayout().
+			WithAntispam(uint(antispamCacheSize), antispam).
+			WithCheckpointInterval(*checkpointInterval).
+			WithCheckpointRepublishInterval(*checkpointRepublishInterval).
+			WithBatching(*batchMaxSize, *batchMaxAge).
+			WithPushback(*pushbackMaxOutstanding).
+			WithGarbageCollectionInterval(*garbageCollectionInterval)
+
+		if *witnessPolicyFile != "" {
+			f, err := os.ReadFile(*witnessPolicyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read witness policy file %q: %v", *witnessPolicyFile, err)
+			}
+			wg, err := tessera.NewWitnessGroupFromPolicy(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create witness group from policy: %v", err)
+			}
+
+			// Don't block if witnesses are unavailable.
+			wOpts := &tessera.WitnessOptions{
+				FailOpen: true,
+				Timeout:  *witnessTimeout,
+			}
+			opts.WithWitnesses(wg, wOpts)
+		}
+
+		appender, _, r, err := tessera.NewAppender(ctx, driver, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize MySQL Tessera storage: %v", err)
+		}
+		*reader = r
+
+		sopts := storage.CTStorageOptions{
+			Appender:               appender,
+			Reader:                 r,
+			IssuerStorage:          issuerStorage,
+			AwaiterPollInterval:    *awaiterPollInterval,
+			EnablePubAwaiter:       *enablePublicationAwaiter,
+			AuditInterval:          *auditInterval,
+			AuditBundleSampleRate:  *auditBundleSampleRate,
+			MergeDelayPollInterval: *mergeDelayPollInterval,
+			MMD:                    *metadataMMD,
+			MergeDelayAlertRatio:   *mergeDelayAlertRatio,
+			DedupSCTCacheSize:      *dedupSCTCacheSize,
+			DedupBundleCacheSize:   *dedupBundleCacheSize,
+			IssuerQueue:            issuerQueue,
+		}
+		if checkpointArchive != nil {
+			sopts.CheckpointArchive = checkpointArchive
+			sopts.CheckpointArchiveInterval = *checkpointArchiveInterval
+			sopts.CheckpointInterval = *checkpointInterval
+			sopts.SignedCheckpoints = signedCheckpoints
+			sopts.CheckpointArchiveMaxAge = *checkpointArchiveMaxAge
+		}
+		return storage.NewCTStorage(ctx, &sopts)
+	}
+}
+
+// mysqlConfig returns a MySQL configuration for database name, populated
+// with values provided via flags.
+func mysqlConfig(name string) *gomysql.Config {
+	if name == "" {
+		slog.ErrorContext(context.Background(), "--db_name must be set")
+		os.Exit(1)
+	}
+	if *dbHost == "" {
+		slog.ErrorContext(context.Background(), "--db_host must be set")
+		os.Exit(1)
+	}
+	if *dbPort == 0 {
+		slog.ErrorContext(context.Background(), "--db_port must be set")
+		os.Exit(1)
+	}
+	if *dbUser == "" {
+		slog.ErrorContext(context.Background(), "--db_user must be set")
+		os.Exit(1)
+	}
+
+	c := gomysql.NewConfig()
+	c.User = *dbUser
+	c.Passwd = *dbPassword
+	c.Net = "tcp"
+	c.Addr = fmt.Sprintf("%s:%d", *dbHost, *dbPort)
+	c.DBName = name
+	c.AllowNativePasswords = true
+	return c
+}
+
+// chainValidationConfigFromFlags returns the chain validation configuration
+// set by flags. RootsRemoteFetchBackup is left for the caller to set.
+func chainValidationConfigFromFlags() tesseract.ChainValidationConfig {
+	if len(rootsRemoteFetchURLs) == 0 {
+		rootsRemoteFetchURLs = []string{"https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV"}
+	}
+	return tesseract.ChainValidationConfig{
+		RootsPEMFile:             *rootsPemFile,
+		RootsRemoteFetchURLs:     rootsRemoteFetchURLs,
+		RootsRemoteFetchInterval: *rootsRemoteFetchInterval,
+		RejectExpired:            *rejectExpired,
+		RejectUnexpired:          *rejectUnexpired,
+		ExtKeyUsages:             *extKeyUsages,
+		RejectExtensions:         *rejectExtensions,
+		NotAfterStart:            notAfterStart.T,
+		NotAfterLimit:            notAfterLimit.T,
+		AcceptSHA1:               *acceptSHA1,
+		RejectRoots:              rootsRejectFingerprints,
+	}
+}
+
+func signerFromFlags() crypto.Signer {
+	if *remoteSignerURL != "" {
+		s, err := remote.NewMTLSSigner(*remoteSignerURL, *remoteSignerPublicKey, *remoteSignerTLSCert, *remoteSignerTLSKey, *remoteSignerCA)
+		if err != nil {
+			slog.ErrorContext(context.Background(), "Failed to create remote signer", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return s
+	}
+	kf := *privKeyFile
+	if kf == "" {
+		kf = os.Getenv("LOG_PRIVATE_KEY")
+	}
+	if kf == "" {
+		slog.ErrorContext(context.Background(), "Must specify --private_key or LOG_PRIVATE_KEY environment variable.")
+		os.Exit(1)
+	}
+	r, err := os.ReadFile(kf)
+	if err != nil {
+		slog.ErrorContext(context.Background(), "Failed to read private key", slog.String("path", kf), slog.Any("error", err))
+		os.Exit(1)
+	}
+	block, _ := pem.Decode(r)
+	if block == nil {
+		slog.ErrorContext(context.Background(), "Failed to parse PEM private key", slog.String("path", kf))
+		os.Exit(1)
+	}
+	k, err := signer.ParsePrivateKeyPEM(block)
+	if err != nil {
+		slog.ErrorContext(context.Background(), "Failed to parse private key", slog.Any("error", err))
+		os.Exit(1)
+	}
+	return k
+}
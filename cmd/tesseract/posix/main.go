@@ -18,7 +18,6 @@ package main
 import (
 	"context"
 	"crypto"
-	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"flag"
@@ -29,8 +28,6 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -42,6 +39,9 @@ import (
 	tposix "github.com/transparency-dev/tessera/storage/posix"
 	tposix_as "github.com/transparency-dev/tessera/storage/posix/antispam"
 	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+	"github.com/transparency-dev/tesseract/internal/signer"
+	"github.com/transparency-dev/tesseract/internal/signer/remote"
 	"github.com/transparency-dev/tesseract/storage"
 	"github.com/transparency-dev/tesseract/storage/posix"
 	"golang.org/x/mod/sumdb/note"
@@ -52,6 +52,7 @@ import (
 func init() {
 	flag.Var(&notAfterStart, "not_after_start", "Start of the range of acceptable NotAfter values, inclusive. Leaving this unset implies no lower bound to the range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
 	flag.Var(&notAfterLimit, "not_after_limit", "Cut off point of notAfter dates - only notAfter dates strictly *before* notAfterLimit will be accepted. Leaving this unset means no upper bound on the accepted range. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
+	flag.Var(&notBeforeRL, "rate_limit_old_not_before", "Optionally rate limits submissions with old notBefore dates. Expects a value of with the format: \"<go duration>:<rate limit>\", e.g. \"30d:50\" would impose a limit of 50 certs/s on submissions whose notBefore date is >= 30days old.")
 	flag.Var(&additionalSigners, "additional_signer", "Path to a file containing an additional note Signer formatted keys for checkpoints. May be specified multiple times.")
 	flag.Var(&rootsRejectFingerprints, "roots_reject_fingerprints", "Hex-encoded SHA-256 fingerprint of a root certificate to reject. May be specified multiple times.")
 	flag.Float64Var(&dedupRL, "rate_limit_dedup", 100, "Rate limit for resolving duplicate submissions, in requests per second - i.e. duplicate requests for already integrated entries, which need to be fetched from the log storage by TesseraCT to extract their timestamp. When 0, all duplicate submissions are rejected. When negative, no rate limit is applied.")
@@ -60,13 +61,18 @@ func init() {
 
 // Global flags that affect all log instances.
 var (
-	notAfterStart           timestampFlag
-	notAfterLimit           timestampFlag
-	additionalSigners       multiStringFlag
-	rootsRejectFingerprints multiStringFlag
-	rootsRemoteFetchURLs    multiStringFlag
+	notAfterStart           flagutil.Timestamp
+	notAfterLimit           flagutil.Timestamp
+	notBeforeRL             = tesseract.NotBeforeRLFlag{RL: &tesseract.NotBeforeRL{AgeThreshold: 28 * time.Hour, RateLimit: 500}}
+	additionalSigners       flagutil.MultiString
+	rootsRejectFingerprints flagutil.MultiString
+	rootsRemoteFetchURLs    flagutil.MultiString
 	dedupRL                 float64
 
+	// Configuration file flags
+	configFile     = flag.String("config", "", "(Optional) Path to a JSON configuration file. Other formats, such as YAML, aren't supported. Flags set on the command line override values from the file. See cmd/tesseract/README.md#Configuration-file.")
+	validateConfig = flag.Bool("validate_config", false, "If true, checks the configuration and exits without starting the log.")
+
 	// Functionality flags
 	httpEndpoint             = flag.String("http_endpoint", "localhost:6962", "Endpoint for HTTP (host:port).")
 	maxCertChainBytes        = flag.Int64("max_cert_chain_bytes", 512<<10, "Maximum size of certificate chain in bytes for add-chain and add-pre-chain endpoints (default: 512 KiB)")
@@ -83,7 +89,9 @@ var (
 	enablePublicationAwaiter = flag.Bool("enable_publication_awaiter", true, "If true, waits for the submitted certificate to be covered by a published checkpoint before responding to an add-* request.")
 	witnessPolicyFile        = flag.String("witness_policy_file", "", "(Optional) Path to the file containing the witness policy in the format described at https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md")
 	witnessTimeout           = flag.Duration("witness_timeout", tessera.DefaultWitnessTimeout, "Maximum time to wait for witness responses.")
-	notBeforeRL              = flag.String("rate_limit_old_not_before", "28h:500", "Optionally rate limits submissions with old notBefore dates. Expects a value of with the format: \"<go duration>:<rate limit>\", e.g. \"30d:50\" would impose a limit of 50 certs/s on submissions whose notBefore date is >= 30days old.")
+	metadataDescription      = flag.String("metadata_description", "", "Description of the log, published in its metadata document.")
+	metadataMonitoringURL    = flag.String("metadata_monitoring_url", "", "URL prefix of the log's monitoring APIs, published in its metadata document.")
+	metadataMMD              = flag.Duration("metadata_mmd", time.Minute, "Maximum merge delay of the log, published in its metadata document.")
 
 	// Performance flags
 	httpDeadline                = flag.Duration("http_deadline", time.Second*10, "Deadline for HTTP requests.")
@@ -106,19 +114,55 @@ var (
 	antispamMemTableSize        = flag.Int64("antispam_mem_table_size", 256<<20, "Size of BadgerDB memtables.")
 	antispamBaseTableSize       = flag.Int64("antispam_base_table_size", 16<<20, "Size of BadgerDB base tables.")
 	awaiterPollInterval         = flag.Duration("awaiter_poll_interval", storage.DefaultAwaiterPollInterval, "Interval between two checkpoint polls by the awaiter. Used for antispam, and if enable_publication_awaiter is set, to block add-* requests responses. Must be strictly positive or defaults to DefaultAwaiterPollInterval.")
+	auditInterval               = flag.Duration("audit_interval", time.Minute, "Interval at which the self-auditor checks that newly published checkpoints are consistent with the previous ones. Set to zero to disable.")
+	auditBundleSampleRate       = flag.Float64("audit_bundle_sample_rate", 0.01, "Fraction of new entry bundles the self-auditor re-hashes against tiles, between 0 and 1.")
+	enableCheckpointArchive     = flag.Bool("enable_checkpoint_archive", false, "If true, newly published checkpoints, and their witness cosignatures, are archived under checkpoints/<size>.")
+	checkpointArchiveInterval   = flag.Duration("checkpoint_archive_interval", 0, "Interval at which the latest checkpoint is read to be archived. Defaults to --checkpoint_interval, and must not be longer, so that no checkpoint is replaced before being archived.")
+	checkpointArchiveMaxAge     = flag.Duration("checkpoint_archive_max_age", 0, "How long archived checkpoints are kept for. Set to zero to keep them forever.")
+	mergeDelayPollInterval      = flag.Duration("merge_delay_poll_interval", time.Second, "Interval at which checkpoints are read to measure the merge delay of issued SCTs. Set to zero to disable merge delay tracking.")
+	mergeDelayAlertRatio        = flag.Float64("merge_delay_alert_ratio", 0.8, "Fraction of --metadata_mmd after which SCTs whose entry isn't covered by a checkpoint yet raise an alert.")
+	dedupSCTCacheSize           = flag.Int("dedup_sct_cache_size", storage.DefaultDedupSCTCacheSize, "Number of SCT inputs cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	dedupBundleCacheSize        = flag.Int("dedup_bundle_cache_size", storage.DefaultDedupBundleCacheSize, "Number of full entry bundles cached to resolve duplicate submissions. Set to zero to disable the cache.")
+	issuerQueueDir              = flag.String("issuer_queue_dir", "", "Directory where issuer writes are durably queued, to be written to the issuer storage asynchronously. Checkpoints are only signed once the issuers of the entries they cover are written. If empty, issuers are written synchronously.")
 
 	// Infrastructure setup flags
-	storageDir    = flag.String("storage_dir", "", "Path to root of log storage.")
-	privKeyFile   = flag.String("private_key", "", "Location of private key file. If unset, uses the contents of the LOG_PRIVATE_KEY environment variable.")
-	traceFraction = flag.Float64("trace_fraction", 0, "Fraction of open-telemetry span traces to sample")
-	slogLevel     = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
+	storageDir            = flag.String("storage_dir", "", "Path to root of log storage.")
+	privKeyFile           = flag.String("private_key", "", "Location of private key file. If unset, uses the contents of the LOG_PRIVATE_KEY environment variable.")
+	remoteSignerURL       = flag.String("remote_signer_url", "", "(Optional) URL of a remote_signer daemon holding the log private key. If set, the log key is not loaded locally and the --remote_signer_* flags must also be set.")
+	remoteSignerPublicKey = flag.String("remote_signer_public_key", "", "Path to the PEM encoded public key of the log key held by the remote signer.")
+	remoteSignerTLSCert   = flag.String("remote_signer_tls_cert", "", "Path to the PEM encoded client certificate used to authenticate to the remote signer.")
+	remoteSignerTLSKey    = flag.String("remote_signer_tls_key", "", "Path to the PEM encoded private key for --remote_signer_tls_cert.")
+	remoteSignerCA        = flag.String("remote_signer_ca", "", "Path to a PEM file containing the CA certificates which issue the remote signer's certificate.")
+	traceFraction         = flag.Float64("trace_fraction", 0, "Fraction of open-telemetry span traces to sample")
+	slogLevel             = flag.Int("slog_level", 0, "The cut-off threshold for structured logging. See cmd/tesseract/README.md#Logging.")
 )
 
 func main() {
 	flag.Parse()
 	ctx := context.Background()
+	if *configFile != "" {
+		cfg, err := tesseract.LoadConfig(*configFile)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to load config file", slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := cfg.ApplyToFlags(flag.CommandLine, tesseract.BackendPOSIX); err != nil {
+			slog.ErrorContext(ctx, "Invalid config file", slog.String("path", *configFile), slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.Level(*slogLevel)})))
 
+	chainValidationConfig := chainValidationConfigFromFlags()
+	if *validateConfig {
+		if err := tesseract.CheckConfig(*origin, chainValidationConfig, *witnessPolicyFile); err != nil {
+			slog.ErrorContext(ctx, "Invalid configuration", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Configuration is valid")
+		return
+	}
+
 	shutdownOTel := initOTel(ctx, *traceFraction, *origin)
 	defer shutdownOTel(ctx)
 	signer := signerFromFlags()
@@ -129,24 +173,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if len(rootsRemoteFetchURLs) == 0 {
-		rootsRemoteFetchURLs = []string{"https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV"}
-	}
-
-	chainValidationConfig := tesseract.ChainValidationConfig{
-		RootsPEMFile:             *rootsPemFile,
-		RootsRemoteFetchURLs:     rootsRemoteFetchURLs,
-		RootsRemoteFetchInterval: *rootsRemoteFetchInterval,
-		RootsRemoteFetchBackup:   fetchedRootsBackupStorage,
-		RejectExpired:            *rejectExpired,
-		RejectUnexpired:          *rejectUnexpired,
-		ExtKeyUsages:             *extKeyUsages,
-		RejectExtensions:         *rejectExtensions,
-		NotAfterStart:            notAfterStart.t,
-		NotAfterLimit:            notAfterLimit.t,
-		AcceptSHA1:               *acceptSHA1,
-		RejectRoots:              rootsRejectFingerprints,
-	}
+	chainValidationConfig.RootsRemoteFetchBackup = fetchedRootsBackupStorage
 	if *acceptSHA1 {
 		slog.InfoContext(ctx, `**** WARNING **** This server will accept chains signed
 using SHA-1 based algorithms. This feature is available to allow chains
@@ -154,10 +181,22 @@ submitted by Chrome's Merge Delay Monitor Root for the time being, but will
 eventually go away. See /internal/lax509/README.md for more information.`)
 	}
 
+	metadataStorage, err := posix.NewMetadataStorage(ctx, *storageDir)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to initialize POSIX metadata storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	hOpts := tesseract.LogHandlerOpts{
-		NotBeforeRL:       notBeforeRLFromFlags(),
+		NotBeforeRL:       notBeforeRL.RL,
 		DedupRL:           dedupRL,
 		MaxCertChainBytes: *maxCertChainBytes,
+		Metadata: &tesseract.LogMetadataOpts{
+			Description:   *metadataDescription,
+			MonitoringURL: *metadataMonitoringURL,
+			MMD:           *metadataMMD,
+			Storage:       metadataStorage,
+		},
 	}
 	logHandler, err := tesseract.NewLogHandler(ctx, *origin, signer, chainValidationConfig, newStorage, *httpDeadline, *maskInternalErrors, *pathPrefix, hOpts)
 	if err != nil {
@@ -217,6 +256,25 @@ func newStorage(ctx context.Context, signer note.Signer) (st *storage.CTStorage,
 		return nil, errors.New("missing storage_dir")
 	}
 
+	issuerStorage, err := posix.NewIssuerStorage(ctx, *storageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize POSIX issuer storage: %v", err)
+	}
+	var issuerQueue *storage.IssuerQueue
+	if *issuerQueueDir != "" {
+		qs, err := posix.NewIssuerQueueStorage(ctx, *issuerQueueDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize POSIX issuer queue storage: %v", err)
+		}
+		issuerQueue = storage.NewIssuerQueue(qs, issuerStorage)
+		signer = issuerQueue.WrapSigner(signer)
+	}
+	var signedCheckpoints *storage.SignedCheckpoints
+	if *enableCheckpointArchive {
+		signedCheckpoints = &storage.SignedCheckpoints{}
+		signer = signedCheckpoints.WrapSigner(signer)
+	}
+
 	cfg := tposix.Config{
 		Path: *storageDir,
 		HTTPClient: &http.Client{
@@ -312,42 +370,54 @@ func newStorage(ctx context.Context, signer note.Signer) (st *storage.CTStorage,
 		return nil, fmt.Errorf("failed to initialize POSIX Tessera appender: %v", err)
 	}
 
-	issuerStorage, err := posix.NewIssuerStorage(ctx, *storageDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize POSIX issuer storage: %v", err)
-	}
-
 	sopts := storage.CTStorageOptions{
-		Appender:            appender,
-		Reader:              reader,
-		IssuerStorage:       issuerStorage,
-		AwaiterPollInterval: *awaiterPollInterval,
-		EnablePubAwaiter:    *enablePublicationAwaiter,
+		Appender:               appender,
+		Reader:                 reader,
+		IssuerStorage:          issuerStorage,
+		AwaiterPollInterval:    *awaiterPollInterval,
+		EnablePubAwaiter:       *enablePublicationAwaiter,
+		AuditInterval:          *auditInterval,
+		AuditBundleSampleRate:  *auditBundleSampleRate,
+		MergeDelayPollInterval: *mergeDelayPollInterval,
+		MMD:                    *metadataMMD,
+		MergeDelayAlertRatio:   *mergeDelayAlertRatio,
+		DedupSCTCacheSize:      *dedupSCTCacheSize,
+		DedupBundleCacheSize:   *dedupBundleCacheSize,
+		IssuerQueue:            issuerQueue,
+	}
+	if *enableCheckpointArchive {
+		checkpointArchive, err := posix.NewCheckpointArchiveStorage(ctx, *storageDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize POSIX checkpoint archive: %v", err)
+		}
+		sopts.CheckpointArchive = checkpointArchive
+		sopts.CheckpointArchiveInterval = *checkpointArchiveInterval
+		sopts.CheckpointInterval = *checkpointInterval
+		sopts.SignedCheckpoints = signedCheckpoints
+		sopts.CheckpointArchiveMaxAge = *checkpointArchiveMaxAge
 	}
 	return storage.NewCTStorage(ctx, &sopts)
 }
 
-type timestampFlag struct {
-	t *time.Time
-}
-
-func (t *timestampFlag) String() string {
-	if t.t != nil {
-		return t.t.Format(time.RFC3339)
-	}
-	return ""
-}
-
-func (t *timestampFlag) Set(w string) error {
-	if w == "" {
-		return nil
+// chainValidationConfigFromFlags returns the chain validation configuration
+// set by flags. RootsRemoteFetchBackup is left for the caller to set.
+func chainValidationConfigFromFlags() tesseract.ChainValidationConfig {
+	if len(rootsRemoteFetchURLs) == 0 {
+		rootsRemoteFetchURLs = []string{"https://ccadb.my.salesforce-sites.com/ccadb/RootCACertificatesIncludedByRSReportCSV"}
 	}
-	tt, err := time.Parse(time.RFC3339, w)
-	if err != nil {
-		return fmt.Errorf("can't parse %q as RFC3339 timestamp: %v", w, err)
+	return tesseract.ChainValidationConfig{
+		RootsPEMFile:             *rootsPemFile,
+		RootsRemoteFetchURLs:     rootsRemoteFetchURLs,
+		RootsRemoteFetchInterval: *rootsRemoteFetchInterval,
+		RejectExpired:            *rejectExpired,
+		RejectUnexpired:          *rejectUnexpired,
+		ExtKeyUsages:             *extKeyUsages,
+		RejectExtensions:         *rejectExtensions,
+		NotAfterStart:            notAfterStart.T,
+		NotAfterLimit:            notAfterLimit.T,
+		AcceptSHA1:               *acceptSHA1,
+		RejectRoots:              rootsRejectFingerprints,
 	}
-	t.t = &tt
-	return nil
 }
 
 func noteSignerFromFile(path string) (note.Signer, error) {
@@ -363,6 +433,14 @@ func noteSignerFromFile(path string) (note.Signer, error) {
 }
 
 func signerFromFlags() crypto.Signer {
+	if *remoteSignerURL != "" {
+		s, err := remote.NewMTLSSigner(*remoteSignerURL, *remoteSignerPublicKey, *remoteSignerTLSCert, *remoteSignerTLSKey, *remoteSignerCA)
+		if err != nil {
+			slog.ErrorContext(context.Background(), "Failed to create remote signer", slog.Any("error", err))
+			os.Exit(1)
+		}
+		return s
+	}
 	kf := *privKeyFile
 	if kf == "" {
 		kf = os.Getenv("LOG_PRIVATE_KEY")
@@ -377,49 +455,14 @@ func signerFromFlags() crypto.Signer {
 		os.Exit(1)
 	}
 	block, _ := pem.Decode(r)
-	if err != nil {
-		slog.ErrorContext(context.Background(), "Failed to parse PEM private key", slog.Any("error", err))
+	if block == nil {
+		slog.ErrorContext(context.Background(), "Failed to parse PEM private key", slog.String("path", kf))
 		os.Exit(1)
 	}
-	k, err := x509.ParseECPrivateKey(block.Bytes)
+	k, err := signer.ParsePrivateKeyPEM(block)
 	if err != nil {
 		slog.ErrorContext(context.Background(), "Failed to parse private key", slog.Any("error", err))
 		os.Exit(1)
 	}
 	return k
 }
-
-// multiStringFlag allows a flag to be specified multiple times on the command
-// line, and stores all of these values.
-type multiStringFlag []string
-
-func (ms *multiStringFlag) String() string {
-	return strings.Join(*ms, ",")
-}
-
-func (ms *multiStringFlag) Set(w string) error {
-	*ms = append(*ms, w)
-	return nil
-}
-
-func notBeforeRLFromFlags() *tesseract.NotBeforeRL {
-	if *notBeforeRL == "" {
-		return nil
-	}
-	bits := strings.Split(*notBeforeRL, ":")
-	if len(bits) != 2 {
-		slog.ErrorContext(context.Background(), "Invalid format for --rate_limit_old_not_before flag")
-		os.Exit(1)
-	}
-	a, err := time.ParseDuration(bits[0])
-	if err != nil {
-		slog.ErrorContext(context.Background(), "Invalid age passed to --rate_limit_old_not_before flag", slog.String("age", bits[0]), slog.Any("error", err))
-		os.Exit(1)
-	}
-	l, err := strconv.ParseFloat(bits[1], 64)
-	if err != nil {
-		slog.ErrorContext(context.Background(), "Invalid rate limit passed to --rate_limit_old_not_before", slog.String("limit", bits[1]), slog.Any("error", err))
-		os.Exit(1)
-	}
-	return &tesseract.NotBeforeRL{AgeThreshold: a, RateLimit: l}
-}
@@ -0,0 +1,412 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tesseract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/transparency-dev/tessera"
+)
+
+// Backends which a Config can hold a section for.
+const (
+	BackendPOSIX = "posix"
+	BackendGCP   = "gcp"
+	BackendAWS   = "aws"
+	BackendMySQL = "mysql"
+)
+
+// Config is the configuration file schema shared by all TesseraCT binaries.
+//
+// Each field corresponds to the command-line flag named in its `flag` tag,
+// and unset fields leave the flag's default untouched. Backend sections are
+// only applied by the binary for that backend, so the same file can be shared
+// by several of them.
+type Config struct {
+	Origin             string    `json:"origin,omitempty" flag:"origin"`
+	PathPrefix         string    `json:"path_prefix,omitempty" flag:"path_prefix"`
+	HTTPEndpoint       string    `json:"http_endpoint,omitempty" flag:"http_endpoint"`
+	HTTPDeadline       *Duration `json:"http_deadline,omitempty" flag:"http_deadline"`
+	MaskInternalErrors *bool     `json:"mask_internal_errors,omitempty" flag:"mask_internal_errors"`
+	MaxCertChainBytes  *int64    `json:"max_cert_chain_bytes,omitempty" flag:"max_cert_chain_bytes"`
+	SlogLevel          *int64    `json:"slog_level,omitempty" flag:"slog_level"`
+
+	ChainValidation   *ChainValidationFileConfig `json:"chain_validation,omitempty"`
+	RateLimits        *RateLimitsConfig          `json:"rate_limits,omitempty"`
+	Batching          *BatchingConfig            `json:"batching,omitempty"`
+	Witnesses         *WitnessesConfig           `json:"witnesses,omitempty"`
+	Signer            *SignerConfig              `json:"signer,omitempty"`
+	Metadata          *MetadataConfig            `json:"metadata,omitempty"`
+	Audit             *AuditConfig               `json:"audit,omitempty"`
+	CheckpointArchive *CheckpointArchiveConfig   `json:"checkpoint_archive,omitempty"`
+	MergeDelay        *MergeDelayConfig          `json:"merge_delay,omitempty"`
+	DedupCache        *DedupCacheConfig          `json:"dedup_cache,omitempty"`
+
+	POSIX *POSIXConfig `json:"posix,omitempty" backend:"posix"`
+	GCP   *GCPConfig   `json:"gcp,omitempty" backend:"gcp"`
+	AWS   *AWSConfig   `json:"aws,omitempty" backend:"aws"`
+	MySQL *MySQLConfig `json:"mysql,omitempty" backend:"mysql"`
+}
+
+// ChainValidationFileConfig configures which chains are accepted by the log.
+// See ChainValidationConfig for the meaning of each field.
+type ChainValidationFileConfig struct {
+	RootsPEMFile             string    `json:"roots_pem_file,omitempty" flag:"roots_pem_file"`
+	RootsRemoteFetchURLs     []string  `json:"roots_remote_fetch_urls,omitempty" flag:"roots_remote_fetch_url"`
+	RootsRemoteFetchInterval *Duration `json:"roots_remote_fetch_interval,omitempty" flag:"roots_remote_fetch_interval"`
+	RejectRoots              []string  `json:"roots_reject_fingerprints,omitempty" flag:"roots_reject_fingerprints"`
+	RejectExpired            *bool     `json:"reject_expired,omitempty" flag:"reject_expired"`
+	RejectUnexpired          *bool     `json:"reject_unexpired,omitempty" flag:"reject_unexpired"`
+	ExtKeyUsages             string    `json:"ext_key_usages,omitempty" flag:"ext_key_usages"`
+	RejectExtensions         string    `json:"reject_extension,omitempty" flag:"reject_extension"`
+	// NotAfterStart and NotAfterLimit are RFC3339 timestamps.
+	NotAfterStart string `json:"not_after_start,omitempty" flag:"not_after_start"`
+	NotAfterLimit string `json:"not_after_limit,omitempty" flag:"not_after_limit"`
+	AcceptSHA1    *bool  `json:"accept_sha1_signing_algorithms,omitempty" flag:"accept_sha1_signing_algorithms"`
+}
+
+// RateLimitsConfig configures submission rate limits.
+type RateLimitsConfig struct {
+	// OldNotBefore has the "<go duration>:<rate limit>" format, see ParseNotBeforeRL.
+	OldNotBefore string   `json:"old_not_before,omitempty" flag:"rate_limit_old_not_before"`
+	Dedup        *float64 `json:"dedup,omitempty" flag:"rate_limit_dedup"`
+}
+
+// BatchingConfig configures how entries are sequenced, integrated and published.
+type BatchingConfig struct {
+	BatchMaxSize                *int64    `json:"batch_max_size,omitempty" flag:"batch_max_size"`
+	BatchMaxAge                 *Duration `json:"batch_max_age,omitempty" flag:"batch_max_age"`
+	CheckpointInterval          *Duration `json:"checkpoint_interval,omitempty" flag:"checkpoint_interval"`
+	CheckpointRepublishInterval *Duration `json:"checkpoint_republish_interval,omitempty" flag:"checkpoint_republish_interval"`
+	PushbackMaxOutstanding      *int64    `json:"pushback_max_outstanding,omitempty" flag:"pushback_max_outstanding"`
+	PushbackMaxAntispamLag      *int64    `json:"pushback_max_antispam_lag,omitempty" flag:"pushback_max_antispam_lag"`
+	InMemoryAntispamCacheSize   string    `json:"inmemory_antispam_cache_size,omitempty" flag:"inmemory_antispam_cache_size"`
+	EnablePublicationAwaiter    *bool     `json:"enable_publication_awaiter,omitempty" flag:"enable_publication_awaiter"`
+	AwaiterPollInterval         *Duration `json:"awaiter_poll_interval,omitempty" flag:"awaiter_poll_interval"`
+	GarbageCollectionInterval   *Duration `json:"garbage_collection_interval,omitempty" flag:"garbage_collection_interval"`
+}
+
+// WitnessesConfig configures checkpoint witnessing.
+type WitnessesConfig struct {
+	PolicyFile string    `json:"policy_file,omitempty" flag:"witness_policy_file"`
+	Timeout    *Duration `json:"timeout,omitempty" flag:"witness_timeout"`
+}
+
+// SignerConfig configures a remote log signer. Local keys are configured in
+// the backend sections.
+type SignerConfig struct {
+	RemoteURL       string `json:"remote_url,omitempty" flag:"remote_signer_url"`
+	RemotePublicKey string `json:"remote_public_key,omitempty" flag:"remote_signer_public_key"`
+	RemoteTLSCert   string `json:"remote_tls_cert,omitempty" flag:"remote_signer_tls_cert"`
+	RemoteTLSKey    string `json:"remote_tls_key,omitempty" flag:"remote_signer_tls_key"`
+	RemoteCA        string `json:"remote_ca,omitempty" flag:"remote_signer_ca"`
+}
+
+// MetadataConfig configures the log metadata document.
+type MetadataConfig struct {
+	Description   string    `json:"description,omitempty" flag:"metadata_description"`
+	MonitoringURL string    `json:"monitoring_url,omitempty" flag:"metadata_monitoring_url"`
+	MMD           *Duration `json:"mmd,omitempty" flag:"metadata_mmd"`
+}
+
+// AuditConfig configures the self-auditor of published checkpoints.
+type AuditConfig struct {
+	Interval         *Duration `json:"interval,omitempty" flag:"audit_interval"`
+	BundleSampleRate *float64  `json:"bundle_sample_rate,omitempty" flag:"audit_bundle_sample_rate"`
+}
+
+// CheckpointArchiveConfig configures the archive of published checkpoints.
+type CheckpointArchiveConfig struct {
+	Enabled  *bool     `json:"enabled,omitempty" flag:"enable_checkpoint_archive"`
+	Interval *Duration `json:"interval,omitempty" flag:"checkpoint_archive_interval"`
+	MaxAge   *Duration `json:"max_age,omitempty" flag:"checkpoint_archive_max_age"`
+}
+
+// MergeDelayConfig configures the tracking of the merge delay of issued SCTs.
+type MergeDelayConfig struct {
+	PollInterval *Duration `json:"poll_interval,omitempty" flag:"merge_delay_poll_interval"`
+	AlertRatio   *float64  `json:"alert_ratio,omitempty" flag:"merge_delay_alert_ratio"`
+}
+
+// DedupCacheConfig configures the caches used to resolve duplicate submissions.
+type DedupCacheConfig struct {
+	SCTCacheSize    *int64 `json:"sct_cache_size,omitempty" flag:"dedup_sct_cache_size"`
+	BundleCacheSize *int64 `json:"bundle_cache_size,omitempty" flag:"dedup_bundle_cache_size"`
+}
+
+// POSIXConfig holds settings specific to the POSIX binary.
+type POSIXConfig struct {
+	StorageDir                 string    `json:"storage_dir,omitempty" flag:"storage_dir"`
+	PrivateKey                 string    `json:"private_key,omitempty" flag:"private_key"`
+	AdditionalSigners          []string  `json:"additional_signers,omitempty" flag:"additional_signer"`
+	AntispamBatchSize          *int64    `json:"antispam_batch_size,omitempty" flag:"antispam_batch_size"`
+	AntispamBlockCacheSize     string    `json:"antispam_block_cache_size,omitempty" flag:"antispam_block_cache_size"`
+	AntispamIndexCacheSize     string    `json:"antispam_index_cache_size,omitempty" flag:"antispam_index_cache_size"`
+	AntispamCompactionInterval *Duration `json:"antispam_compaction_interval,omitempty" flag:"antispam_compaction_interval"`
+	AntispamNumCompactors      *int64    `json:"antispam_num_compactors,omitempty" flag:"antispam_num_compactors"`
+	AntispamMemTableSize       *int64    `json:"antispam_mem_table_size,omitempty" flag:"antispam_mem_table_size"`
+	AntispamBaseTableSize      *int64    `json:"antispam_base_table_size,omitempty" flag:"antispam_base_table_size"`
+	ClientHTTPTimeout          *Duration `json:"client_http_timeout,omitempty" flag:"client_http_timeout"`
+	ClientHTTPMaxIdle          *int64    `json:"client_http_max_idle,omitempty" flag:"client_http_max_idle"`
+	ClientHTTPMaxIdlePerHost   *int64    `json:"client_http_max_idle_per_host,omitempty" flag:"client_http_max_idle_per_host"`
+	IssuerQueueDir             string    `json:"issuer_queue_dir,omitempty" flag:"issuer_queue_dir"`
+}
+
+// GCPConfig holds settings specific to the GCP binary.
+type GCPConfig struct {
+	Bucket                     string    `json:"bucket,omitempty" flag:"bucket"`
+	GCSConnections             *int64    `json:"gcs_connections,omitempty" flag:"gcs_connections"`
+	SpannerDBPath              string    `json:"spanner_db_path,omitempty" flag:"spanner_db_path"`
+	SpannerAntispamDBPath      string    `json:"spanner_antispam_db_path,omitempty" flag:"spanner_antispam_db_path"`
+	SpannerConnections         *int64    `json:"spanner_connections,omitempty" flag:"spanner_connections"`
+	SignerPublicKeySecretName  string    `json:"signer_public_key_secret_name,omitempty" flag:"signer_public_key_secret_name"`
+	SignerPrivateKeySecretName string    `json:"signer_private_key_secret_name,omitempty" flag:"signer_private_key_secret_name"`
+	AdditionalSigners          []string  `json:"additional_signer_private_key_secret_names,omitempty" flag:"additional_signer_private_key_secret_name"`
+	ClientHTTPTimeout          *Duration `json:"client_http_timeout,omitempty" flag:"client_http_timeout"`
+	ClientHTTPMaxIdle          *int64    `json:"client_http_max_idle,omitempty" flag:"client_http_max_idle"`
+	ClientHTTPMaxIdlePerHost   *int64    `json:"client_http_max_idle_per_host,omitempty" flag:"client_http_max_idle_per_host"`
+	OTelProjectID              string    `json:"otel_project_id,omitempty" flag:"otel_project_id"`
+	EnableIssuerQueue          *bool     `json:"enable_issuer_queue,omitempty" flag:"enable_issuer_queue"`
+}
+
+// AWSConfig holds settings specific to the AWS binary.
+type AWSConfig struct {
+	Bucket                     string `json:"bucket,omitempty" flag:"bucket"`
+	S3UsePathStyle             *bool  `json:"s3_use_path_style,omitempty" flag:"s3_use_path_style"`
+	DBName                     string `json:"db_name,omitempty" flag:"db_name"`
+	AntispamDBName             string `json:"antispam_db_name,omitempty" flag:"antispam_db_name"`
+	DBHost                     string `json:"db_host,omitempty" flag:"db_host"`
+	DBPort                     *int64 `json:"db_port,omitempty" flag:"db_port"`
+	DBUser                     string `json:"db_user,omitempty" flag:"db_user"`
+	DBPassword                 string `json:"db_password,omitempty" flag:"db_password"`
+	DBMaxConns                 *int64 `json:"db_max_conns,omitempty" flag:"db_max_conns"`
+	DBMaxIdleConns             *int64 `json:"db_max_idle_conns,omitempty" flag:"db_max_idle_conns"`
+	SignerPublicKeySecretName  string `json:"signer_public_key_secret_name,omitempty" flag:"signer_public_key_secret_name"`
+	SignerPrivateKeySecretName string `json:"signer_private_key_secret_name,omitempty" flag:"signer_private_key_secret_name"`
+	SignerPublicKeyFile        string `json:"signer_public_key_file,omitempty" flag:"signer_public_key_file"`
+	SignerPrivateKeyFile       string `json:"signer_private_key_file,omitempty" flag:"signer_private_key_file"`
+	EnableIssuerQueue          *bool  `json:"enable_issuer_queue,omitempty" flag:"enable_issuer_queue"`
+}
+
+// MySQLConfig holds settings specific to the MySQL binary.
+type MySQLConfig struct {
+	DBName            string `json:"db_name,omitempty" flag:"db_name"`
+	AntispamDBName    string `json:"antispam_db_name,omitempty" flag:"antispam_db_name"`
+	DBHost            string `json:"db_host,omitempty" flag:"db_host"`
+	DBPort            *int64 `json:"db_port,omitempty" flag:"db_port"`
+	DBUser            string `json:"db_user,omitempty" flag:"db_user"`
+	DBPassword        string `json:"db_password,omitempty" flag:"db_password"`
+	DBMaxConns        *int64 `json:"db_max_conns,omitempty" flag:"db_max_conns"`
+	DBMaxIdleConns    *int64 `json:"db_max_idle_conns,omitempty" flag:"db_max_idle_conns"`
+	PrivateKey        string `json:"private_key,omitempty" flag:"private_key"`
+	EnableIssuerQueue *bool  `json:"enable_issuer_queue,omitempty" flag:"enable_issuer_queue"`
+}
+
+// Duration is a time.Duration which is encoded in JSON as a Go duration
+// string, e.g. "1h30m".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string, e.g. \"1h30m\": %v", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// LoadConfig reads a JSON Config from path. JSON is the only supported format.
+//
+// Unknown fields are rejected, to catch typos.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %q: %v", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	cfg := &Config{}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// ApplyToFlags sets the flags in fs from the shared sections of c, and from its
+// backend section.
+//
+// Flags which were explicitly set on the command line take precedence, and are
+// left untouched. fs must already have been parsed.
+func (c *Config) ApplyToFlags(fs *flag.FlagSet, backend string) error {
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	var errs []error
+	var apply func(v reflect.Value)
+	apply = func(v reflect.Value) {
+		t := v.Type()
+		for i := range t.NumField() {
+			f, fv := t.Field(i), v.Field(i)
+			if b, ok := f.Tag.Lookup("backend"); ok && b != backend {
+				continue
+			}
+			name, ok := f.Tag.Lookup("flag")
+			if !ok {
+				// A section.
+				if !fv.IsNil() {
+					apply(fv.Elem())
+				}
+				continue
+			}
+			values, err := flagValues(fv)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", name, err))
+				continue
+			}
+			if len(values) == 0 || explicit[name] {
+				continue
+			}
+			if fs.Lookup(name) == nil {
+				errs = append(errs, fmt.Errorf("%s: not supported by this binary", name))
+				continue
+			}
+			for _, s := range values {
+				if err := fs.Set(name, s); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %v", name, err))
+				}
+			}
+		}
+	}
+	apply(reflect.ValueOf(c).Elem())
+	return errors.Join(errs...)
+}
+
+// flagValues returns the flag values to set for a Config field, or nil if the
+// field is unset.
+func flagValues(v reflect.Value) ([]string, error) {
+	switch x := v.Interface().(type) {
+	case string:
+		if x == "" {
+			return nil, nil
+		}
+		return []string{x}, nil
+	case []string:
+		return x, nil
+	case *bool:
+		if x != nil {
+			return []string{strconv.FormatBool(*x)}, nil
+		}
+	case *int64:
+		if x != nil {
+			return []string{strconv.FormatInt(*x, 10)}, nil
+		}
+	case *float64:
+		if x != nil {
+			return []string{strconv.FormatFloat(*x, 'g', -1, 64)}, nil
+		}
+	case *Duration:
+		if x != nil {
+			return []string{time.Duration(*x).String()}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config field type %T", x)
+	}
+	return nil, nil
+}
+
+// ParseNotBeforeRL parses a rate limit with the "<go duration>:<rate limit>"
+// format, e.g. "30d:50".
+//
+// An empty string means no rate limit, and returns nil.
+func ParseNotBeforeRL(s string) (*NotBeforeRL, error) {
+	if s == "" {
+		return nil, nil
+	}
+	bits := strings.Split(s, ":")
+	if len(bits) != 2 {
+		return nil, fmt.Errorf("invalid format %q, want <go duration>:<rate limit>", s)
+	}
+	a, err := time.ParseDuration(bits[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid age %q: %v", bits[0], err)
+	}
+	l, err := strconv.ParseFloat(bits[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit %q: %v", bits[1], err)
+	}
+	return &NotBeforeRL{AgeThreshold: a, RateLimit: l}, nil
+}
+
+// NotBeforeRLFlag is a flag.Value holding an optional NotBeforeRL, with the
+// format of ParseNotBeforeRL.
+type NotBeforeRLFlag struct {
+	RL *NotBeforeRL
+}
+
+func (f *NotBeforeRLFlag) String() string {
+	if f.RL == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s", f.RL.AgeThreshold, strconv.FormatFloat(f.RL.RateLimit, 'g', -1, 64))
+}
+
+// Set parses s with ParseNotBeforeRL. An empty string unsets the rate limit.
+func (f *NotBeforeRLFlag) Set(s string) error {
+	rl, err := ParseNotBeforeRL(s)
+	if err != nil {
+		return err
+	}
+	f.RL = rl
+	return nil
+}
+
+// CheckConfig checks the consistency of a log configuration, and that the
+// local resources it references can be loaded, without starting the log.
+//
+// witnessPolicyFile is optional.
+func CheckConfig(origin string, cfg ChainValidationConfig, witnessPolicyFile string) error {
+	var errs []error
+	if origin == "" {
+		errs = append(errs, errors.New("origin: must be set"))
+	}
+	if _, err := cfg.parse(); err != nil {
+		errs = append(errs, fmt.Errorf("chain validation: %v", err))
+	}
+	if witnessPolicyFile != "" {
+		if p, err := os.ReadFile(witnessPolicyFile); err != nil {
+			errs = append(errs, fmt.Errorf("witness_policy_file: %v", err))
+		} else if _, err := tessera.NewWitnessGroupFromPolicy(p); err != nil {
+			errs = append(errs, fmt.Errorf("witness_policy_file: %v", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tesseract
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/transparency-dev/tesseract/internal/flagutil"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+	return p
+}
+
+func TestLoadConfig(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		content string
+		wantErr string
+	}{
+		{
+			desc: "ok",
+			content: `{
+				"origin": "example.com/log",
+				"chain_validation": {"reject_expired": true, "roots_remote_fetch_urls": ["https://a", "https://b"]},
+				"batching": {"checkpoint_interval": "2s"},
+				"posix": {"storage_dir": "/tmp/log"}
+			}`,
+		},
+		{
+			desc:    "unknown-field",
+			content: `{"orgin": "example.com/log"}`,
+			wantErr: "unknown field",
+		},
+		{
+			desc:    "invalid-duration",
+			content: `{"batching": {"checkpoint_interval": "2 seconds"}}`,
+			wantErr: "unknown unit",
+		},
+		{
+			desc:    "numeric-duration",
+			content: `{"batching": {"checkpoint_interval": 2}}`,
+			wantErr: "duration must be a string",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := LoadConfig(writeFile(t, "config.json", tc.content))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("LoadConfig(): %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("LoadConfig()=%v, want err containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyToFlags(t *testing.T) {
+	cfg, err := LoadConfig(writeFile(t, "config.json", `{
+		"origin": "example.com/log",
+		"http_endpoint": "localhost:1234",
+		"chain_validation": {
+			"reject_expired": true,
+			"roots_remote_fetch_urls": ["https://a", "https://b"],
+			"not_after_start": "2026-01-01T00:00:00Z"
+		},
+		"rate_limits": {"old_not_before": "1h:5"},
+		"batching": {"checkpoint_interval": "2s", "batch_max_size": 10},
+		"merge_delay": {"poll_interval": "5s", "alert_ratio": 0.5},
+		"dedup_cache": {"sct_cache_size": 100, "bundle_cache_size": 0},
+		"posix": {"storage_dir": "/posix"},
+		"gcp": {"bucket": "gcp-bucket"}
+	}`))
+	if err != nil {
+		t.Fatalf("LoadConfig(): %v", err)
+	}
+
+	type flags struct {
+		Origin             string
+		HTTPEndpoint       string
+		RejectExpired      bool
+		FetchURLs          flagutil.MultiString
+		NotAfterStart      flagutil.Timestamp
+		NotBeforeRL        NotBeforeRLFlag
+		CheckpointInterval time.Duration
+		BatchMaxSize       uint
+		StorageDir         string
+		MergeDelayPoll     time.Duration
+		MergeDelayAlert    float64
+		DedupSCTCache      int
+		DedupBundleCache   int
+	}
+	newFlagSet := func(f *flags) *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.StringVar(&f.Origin, "origin", "", "")
+		fs.StringVar(&f.HTTPEndpoint, "http_endpoint", "localhost:6962", "")
+		fs.BoolVar(&f.RejectExpired, "reject_expired", false, "")
+		fs.Var(&f.FetchURLs, "roots_remote_fetch_url", "")
+		fs.Var(&f.NotAfterStart, "not_after_start", "")
+		fs.Var(&f.NotBeforeRL, "rate_limit_old_not_before", "")
+		fs.DurationVar(&f.CheckpointInterval, "checkpoint_interval", time.Second, "")
+		fs.UintVar(&f.BatchMaxSize, "batch_max_size", 1, "")
+		fs.StringVar(&f.StorageDir, "storage_dir", "", "")
+		fs.DurationVar(&f.MergeDelayPoll, "merge_delay_poll_interval", time.Second, "")
+		fs.Float64Var(&f.MergeDelayAlert, "merge_delay_alert_ratio", 0.8, "")
+		fs.IntVar(&f.DedupSCTCache, "dedup_sct_cache_size", 1000, "")
+		fs.IntVar(&f.DedupBundleCache, "dedup_bundle_cache_size", 10, "")
+		return fs
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rl := &NotBeforeRL{AgeThreshold: time.Hour, RateLimit: 5}
+
+	for _, tc := range []struct {
+		desc    string
+		args    []string
+		backend string
+		want    flags
+	}{
+		{
+			desc:    "from-config",
+			backend: BackendPOSIX,
+			want: flags{
+				Origin:             "example.com/log",
+				HTTPEndpoint:       "localhost:1234",
+				RejectExpired:      true,
+				FetchURLs:          flagutil.MultiString{"https://a", "https://b"},
+				NotAfterStart:      flagutil.Timestamp{T: &start},
+				NotBeforeRL:        NotBeforeRLFlag{RL: rl},
+				CheckpointInterval: 2 * time.Second,
+				BatchMaxSize:       10,
+				StorageDir:         "/posix",
+				MergeDelayPoll:     5 * time.Second,
+				MergeDelayAlert:    0.5,
+				DedupSCTCache:      100,
+			},
+		},
+		{
+			desc:    "flags-override-config",
+			args:    []string{"--http_endpoint=localhost:80", "--reject_expired=false", "--roots_remote_fetch_url=https://c", "--merge_delay_alert_ratio=0.9"},
+			backend: BackendPOSIX,
+			want: flags{
+				Origin:             "example.com/log",
+				HTTPEndpoint:       "localhost:80",
+				FetchURLs:          flagutil.MultiString{"https://c"},
+				NotAfterStart:      flagutil.Timestamp{T: &start},
+				NotBeforeRL:        NotBeforeRLFlag{RL: rl},
+				CheckpointInterval: 2 * time.Second,
+				BatchMaxSize:       10,
+				StorageDir:         "/posix",
+				MergeDelayPoll:     5 * time.Second,
+				MergeDelayAlert:    0.9,
+				DedupSCTCache:      100,
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got flags
+			fs := newFlagSet(&got)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(): %v", err)
+			}
+			if err := cfg.ApplyToFlags(fs, tc.backend); err != nil {
+				t.Fatalf("ApplyToFlags(): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("flags diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("unsupported-flag", func(t *testing.T) {
+		var got flags
+		fs := newFlagSet(&got)
+		if err := fs.Parse(nil); err != nil {
+			t.Fatalf("Parse(): %v", err)
+		}
+		// The gcp section sets --bucket, which this flag set doesn't define.
+		err := cfg.ApplyToFlags(fs, BackendGCP)
+		if err == nil || !strings.Contains(err.Error(), "bucket: not supported") {
+			t.Errorf("ApplyToFlags()=%v, want unsupported bucket error", err)
+		}
+	})
+}
+
+// TestConfigFieldTypes checks that every flag field of Config has a type
+// which ApplyToFlags supports.
+func TestConfigFieldTypes(t *testing.T) {
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			if _, ok := f.Tag.Lookup("flag"); !ok {
+				walk(f.Type.Elem())
+				continue
+			}
+			if _, err := flagValues(reflect.Zero(f.Type)); err != nil {
+				t.Errorf("%s.%s: %v", typ.Name(), f.Name, err)
+			}
+		}
+	}
+	walk(reflect.TypeFor[Config]())
+}
+
+func TestParseNotBeforeRL(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    *NotBeforeRL
+		wantErr bool
+	}{
+		{in: ""},
+		{in: "28h:500", want: &NotBeforeRL{AgeThreshold: 28 * time.Hour, RateLimit: 500}},
+		{in: "1h:0.5", want: &NotBeforeRL{AgeThreshold: time.Hour, RateLimit: 0.5}},
+		{in: "28h", wantErr: true},
+		{in: "28h:500:1", wantErr: true},
+		{in: "28d:500", wantErr: true},
+		{in: "28h:lots", wantErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseNotBeforeRL(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseNotBeforeRL()=%v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseNotBeforeRL() diff (-want +got):\n%s", diff)
+			}
+
+			f := &NotBeforeRLFlag{}
+			if err := f.Set(tc.in); (err != nil) != tc.wantErr {
+				t.Fatalf("NotBeforeRLFlag.Set()=%v, wantErr %t", err, tc.wantErr)
+			}
+			// The flag's default value is displayed with String.
+			again := &NotBeforeRLFlag{}
+			if err := again.Set(f.String()); err != nil {
+				t.Fatalf("NotBeforeRLFlag.Set(%q): %v", f.String(), err)
+			}
+			if diff := cmp.Diff(f.RL, again.RL); diff != "" {
+				t.Errorf("NotBeforeRLFlag.String() round trip diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	t100 := time.Unix(100, 0)
+	t200 := time.Unix(200, 0)
+	badPolicy := writeFile(t, "policy", "witness w1\nquorum w1\n")
+
+	for _, tc := range []struct {
+		desc          string
+		origin        string
+		cvCfg         ChainValidationConfig
+		witnessPolicy string
+		wantErrs      []string
+	}{
+		{
+			desc:   "ok",
+			origin: "example.com/log",
+			cvCfg:  ChainValidationConfig{RootsPEMFile: "./internal/testdata/fake-ca.cert"},
+		},
+		{
+			desc:     "missing-origin",
+			cvCfg:    ChainValidationConfig{RootsPEMFile: "./internal/testdata/fake-ca.cert"},
+			wantErrs: []string{"origin: must be set"},
+		},
+		{
+			desc:   "rejecting-all",
+			origin: "example.com/log",
+			cvCfg: ChainValidationConfig{
+				RootsPEMFile:    "./internal/testdata/fake-ca.cert",
+				RejectExpired:   true,
+				RejectUnexpired: true,
+			},
+			wantErrs: []string{"configuration would reject all certificates"},
+		},
+		{
+			desc:   "not-after-ordering",
+			origin: "example.com/log",
+			cvCfg: ChainValidationConfig{
+				RootsPEMFile:  "./internal/testdata/fake-ca.cert",
+				NotAfterStart: &t200,
+				NotAfterLimit: &t100,
+			},
+			wantErrs: []string{"before start"},
+		},
+		{
+			desc:          "all-errors-reported",
+			cvCfg:         ChainValidationConfig{RootsPEMFile: "./internal/testdata/fake-ca.cert"},
+			witnessPolicy: badPolicy,
+			wantErrs:      []string{"origin", "witness_policy_file"},
+		},
+		{
+			desc:          "missing-witness-policy",
+			origin:        "example.com/log",
+			cvCfg:         ChainValidationConfig{RootsPEMFile: "./internal/testdata/fake-ca.cert"},
+			witnessPolicy: "./internal/testdata/bogus.policy",
+			wantErrs:      []string{"witness_policy_file"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := CheckConfig(tc.origin, tc.cvCfg, tc.witnessPolicy)
+			if len(tc.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("CheckConfig(): %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CheckConfig()=nil, want errors containing %q", tc.wantErrs)
+			}
+			for _, want := range tc.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("CheckConfig()=%v, want err containing %q", err, want)
+				}
+			}
+		})
+	}
+}
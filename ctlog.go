@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -83,6 +84,12 @@ type ChainValidationConfig struct {
 	AcceptSHA1 bool
 }
 
+// TimeSource provides the current time to a log.
+type TimeSource interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
 // systemTimeSource implements ct.TimeSource.
 type systemTimeSource struct{}
 
@@ -93,9 +100,16 @@ func (s systemTimeSource) Now() time.Time {
 
 var sysTimeSource = systemTimeSource{}
 
-// newChainValidator checks that a chain validation config is valid,
-// parses it, and loads resources to validate chains.
-func newChainValidator(ctx context.Context, cfg ChainValidationConfig) (ct.ChainValidator, error) {
+// parsedChainValidationConfig holds the parsed fields of a ChainValidationConfig.
+type parsedChainValidationConfig struct {
+	roots        *x509util.PEMCertPool
+	extKeyUsages []x509.ExtKeyUsage
+	rejectExtIds []asn1.ObjectIdentifier
+}
+
+// parse checks that a chain validation config is valid, parses it, and loads
+// the trusted roots from RootsPEMFile.
+func (cfg ChainValidationConfig) parse() (*parsedChainValidationConfig, error) {
 	// Load the trusted roots.
 	if cfg.RootsPEMFile == "" {
 		return nil, errors.New("empty rootsPemFile")
@@ -138,6 +152,22 @@ func newChainValidator(ctx context.Context, cfg ChainValidationConfig) (ct.Chain
 		}
 	}
 
+	return &parsedChainValidationConfig{
+		roots:        roots,
+		extKeyUsages: extKeyUsages,
+		rejectExtIds: rejectExtIds,
+	}, nil
+}
+
+// newChainValidator checks that a chain validation config is valid,
+// parses it, and loads resources to validate chains.
+func newChainValidator(ctx context.Context, cfg ChainValidationConfig) (ct.ChainValidator, error) {
+	p, err := cfg.parse()
+	if err != nil {
+		return nil, err
+	}
+	roots, extKeyUsages, rejectExtIds := p.roots, p.extKeyUsages, p.rejectExtIds
+
 	if cfg.RootsRemoteFetchBackup != nil {
 		kvs, err := cfg.RootsRemoteFetchBackup.LoadAll(ctx)
 		if err != nil {
@@ -218,6 +248,53 @@ type LogHandlerOpts struct {
 	NotBeforeRL       *NotBeforeRL
 	DedupRL           float64
 	MaxCertChainBytes int64
+	// Metadata configures the log metadata document. If nil, no document is
+	// published.
+	Metadata *LogMetadataOpts
+	// TimeSource, if set, replaces the system clock to timestamp SCTs and
+	// checkpoints. It is meant for tests.
+	TimeSource TimeSource
+}
+
+// LogMetadataOpts configures the log metadata document, a v3 log list style
+// description of the log.
+//
+// The log ID, key, submission URL and temporal interval are derived from the
+// rest of the log configuration.
+type LogMetadataOpts struct {
+	Description   string
+	MonitoringURL string
+	MMD           time.Duration
+	// Storage, if set, is where the document is written to on startup, next
+	// to the checkpoint.
+	Storage storage.MetadataStorage
+}
+
+// publishMetadata builds the log metadata document, and writes it to storage
+// if configured to.
+func publishMetadata(ctx context.Context, origin string, pubKey crypto.PublicKey, cfg ChainValidationConfig, pathPrefix string, opts *LogMetadataOpts) ([]byte, error) {
+	m, err := ct.NewLogMetadata(origin, pubKey, ct.MetadataOptions{
+		Description:   opts.Description,
+		PathPrefix:    pathPrefix,
+		MonitoringURL: opts.MonitoringURL,
+		MMD:           opts.MMD,
+		NotAfterStart: cfg.NotAfterStart,
+		NotAfterLimit: cfg.NotAfterLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %v", err)
+	}
+	if opts.Storage != nil {
+		if err := opts.Storage.SetMetadata(ctx, data); err != nil {
+			return nil, fmt.Errorf("failed to store metadata: %v", err)
+		}
+		slog.InfoContext(ctx, "Published log metadata", slog.String("log_id", m.LogID))
+	}
+	return data, nil
 }
 
 // NewLogHandler creates a Tessera based CT log plugged into HTTP handlers.
@@ -234,7 +311,11 @@ func NewLogHandler(ctx context.Context, origin string, signer crypto.Signer, cfg
 	if err != nil {
 		return nil, fmt.Errorf("newCertValidationOpts(): %v", err)
 	}
-	log, err := ct.NewLog(ctx, origin, signer, cv, cs, sysTimeSource)
+	var ts ct.TimeSource = sysTimeSource
+	if opts.TimeSource != nil {
+		ts = opts.TimeSource
+	}
+	log, err := ct.NewLog(ctx, origin, signer, cv, cs, ts)
 	if err != nil {
 		return nil, fmt.Errorf("newLog(): %v", err)
 	}
@@ -243,7 +324,7 @@ func NewLogHandler(ctx context.Context, origin string, signer crypto.Signer, cfg
 		Deadline:           httpDeadline,
 		RequestLog:         &ct.DefaultRequestLog{},
 		MaskInternalErrors: maskInternalErrors,
-		TimeSource:         sysTimeSource,
+		TimeSource:         ts,
 		PathPrefix:         pathPrefix,
 	}
 	if opts.NotBeforeRL != nil {
@@ -252,6 +333,12 @@ func NewLogHandler(ctx context.Context, origin string, signer crypto.Signer, cfg
 	if opts.DedupRL >= 0 {
 		ctOpts.RateLimits.Dedup(opts.DedupRL)
 	}
+	if opts.Metadata != nil {
+		ctOpts.Metadata, err = publishMetadata(ctx, origin, signer.Public(), cfg, pathPrefix, opts.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("publishMetadata(): %v", err)
+		}
+	}
 
 	handlers := ct.NewPathHandlers(ctx, ctOpts, log)
 	mux := http.NewServeMux()
@@ -0,0 +1,365 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package devlog runs a self-contained, ephemeral TesseraCT log for
+// development and tests.
+//
+// The log runs in-process, on top of POSIX storage in a temporary directory.
+// Its key and the test CA it trusts are generated on startup, and it serves
+// both the submission and the monitoring APIs over HTTP.
+//
+// DO NOT use this package to run a production log.
+package devlog
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/tessera"
+	tposix "github.com/transparency-dev/tessera/storage/posix"
+	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/storage"
+	"github.com/transparency-dev/tesseract/storage/posix"
+	"github.com/transparency-dev/tesseract/tesseracttest/chains"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// DefaultOrigin is the default origin of development logs. Origins can't
+	// contain a port, so it doesn't match the address the log is served on.
+	DefaultOrigin = "dev.tesseract.localhost"
+	// DefaultCheckpointInterval is the default interval between two
+	// checkpoints. It is kept short so that submissions return quickly.
+	DefaultCheckpointInterval = 100 * time.Millisecond
+
+	awaiterPollInterval  = 50 * time.Millisecond
+	httpDeadline         = 10 * time.Second
+	maxCertChainBytes    = 512 << 10
+	inMemoryAntispamSize = 64 << 10
+)
+
+// Options configures a development log.
+type Options struct {
+	// Dir is the directory to store the log in. If empty, a temporary
+	// directory is created, and removed when the log is closed.
+	Dir string
+	// Addr is the address to serve the log on. Defaults to "localhost:0",
+	// i.e. a random port.
+	Addr string
+	// Origin is the origin of the log. Defaults to DefaultOrigin.
+	Origin string
+	// CheckpointInterval is the interval between two checkpoints. Defaults to
+	// DefaultCheckpointInterval.
+	CheckpointInterval time.Duration
+	// CheckpointRepublishInterval, if set, is the interval after which a
+	// checkpoint is published again when the log hasn't grown.
+	CheckpointRepublishInterval time.Duration
+	// Signer is the log key. Defaults to a generated ECDSA P-256 key.
+	Signer crypto.Signer
+	// TimeSource, if set, replaces the system clock to timestamp SCTs and
+	// checkpoints, and to issue test certificates.
+	TimeSource tesseract.TimeSource
+	// Roots are trusted by the log, in addition to the root of its test CA.
+	Roots []*x509.Certificate
+	// ChainValidation, if set, is used to validate chains. Its RootsPEMFile is
+	// overridden with a file containing Roots and the root of the test CA.
+	ChainValidation *tesseract.ChainValidationConfig
+	// WrapAdd, if set, wraps the function adding entries to the log, e.g. to
+	// inject pushback.
+	WrapAdd func(tessera.AddFn) tessera.AddFn
+}
+
+// Log is a running development log.
+type Log struct {
+	// Origin is the origin of the log, used in its checkpoints.
+	Origin string
+	// URL is the log's submission and monitoring prefix, with a trailing
+	// slash, e.g. "http://localhost:12345/".
+	URL string
+	// PublicKey is the log's public key, to verify SCTs and checkpoints.
+	PublicKey crypto.PublicKey
+	// Verifier verifies the log's checkpoints.
+	Verifier note.Verifier
+	// Root is the test root CA trusted by the log.
+	Root *x509.Certificate
+	// Intermediate is a test intermediate CA issued by Root, and issuing
+	// the chains returned by IssueChain and IssuePrecertChain.
+	Intermediate *x509.Certificate
+	// Dir is the directory the log is stored in.
+	Dir string
+	// Factory issues certificates valid relative to the log's time source.
+	Factory *chains.Factory
+	// Issuer is Intermediate along with its key, to issue custom chains from
+	// with Factory.
+	Issuer *chains.Issuer
+	// Reader reads the log's state from its storage.
+	Reader tessera.LogReader
+
+	srv          *http.Server
+	serveErr     chan error
+	cancel       context.CancelFunc
+	removeDir    bool
+	shutdownMu   sync.Mutex
+	shutdownFunc func(context.Context) error
+	closeOnce    sync.Once
+	closeErr     error
+}
+
+// Start starts a development log, serving until Close is called.
+func Start(ctx context.Context, opts Options) (_ *Log, rErr error) {
+	l := &Log{
+		Dir:      opts.Dir,
+		serveErr: make(chan error, 1),
+	}
+	if l.Dir == "" {
+		d, err := os.MkdirTemp("", "tesseract-dev-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %v", err)
+		}
+		l.Dir, l.removeDir = d, true
+	}
+	defer func() {
+		if rErr != nil && l.removeDir {
+			_ = os.RemoveAll(l.Dir)
+		}
+	}()
+	logDir := filepath.Join(l.Dir, "log")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %v", err)
+	}
+
+	signer := opts.Signer
+	if signer == nil {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate log key: %v", err)
+		}
+		signer = k
+	}
+	l.PublicKey = signer.Public()
+	var now func() time.Time
+	if opts.TimeSource != nil {
+		now = opts.TimeSource.Now
+	}
+	rootsPEMFile := filepath.Join(l.Dir, "roots.pem")
+	if err := l.generateCA(now, opts.Roots, rootsPEMFile); err != nil {
+		return nil, err
+	}
+
+	addr := opts.Addr
+	if addr == "" {
+		addr = "localhost:0"
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %v", addr, err)
+	}
+	defer func() {
+		if rErr != nil {
+			_ = lis.Close()
+		}
+	}()
+	l.URL = fmt.Sprintf("http://%s/", lis.Addr())
+	l.Origin = opts.Origin
+	if l.Origin == "" {
+		l.Origin = DefaultOrigin
+	}
+	l.Verifier, err = staticct.NewCheckpointVerifier(l.Origin, l.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint verifier: %v", err)
+	}
+
+	// The log runs background tasks until it's closed, independently of ctx.
+	logCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	l.cancel = cancel
+	defer func() {
+		if rErr != nil {
+			cancel()
+		}
+	}()
+
+	metadataStorage, err := posix.NewMetadataStorage(logCtx, logDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize POSIX metadata storage: %v", err)
+	}
+	checkpointInterval := opts.CheckpointInterval
+	if checkpointInterval <= 0 {
+		checkpointInterval = DefaultCheckpointInterval
+	}
+	cvCfg := tesseract.ChainValidationConfig{}
+	if opts.ChainValidation != nil {
+		cvCfg = *opts.ChainValidation
+	}
+	cvCfg.RootsPEMFile = rootsPEMFile
+	hOpts := tesseract.LogHandlerOpts{
+		DedupRL:           -1,
+		MaxCertChainBytes: maxCertChainBytes,
+		TimeSource:        opts.TimeSource,
+		Metadata: &tesseract.LogMetadataOpts{
+			Description:   "TesseraCT development log",
+			MonitoringURL: l.URL,
+			Storage:       metadataStorage,
+		},
+	}
+	logHandler, err := tesseract.NewLogHandler(logCtx, l.Origin, signer, cvCfg, l.newStorage(logDir, checkpointInterval, opts), httpDeadline, false, "", hOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize log handler: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", logHandler)
+	fs := http.FileServer(http.Dir(logDir))
+	for _, p := range []string{"/checkpoint", "/tile/", "/" + staticct.IssuersPrefix, "/" + staticct.MetadataPath} {
+		mux.Handle("GET "+p, fs)
+	}
+	l.srv = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := l.srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+			l.serveErr <- err
+		}
+		close(l.serveErr)
+	}()
+	slog.InfoContext(ctx, "Started development log", slog.String("origin", l.Origin), slog.String("url", l.URL), slog.String("dir", l.Dir))
+	return l, nil
+}
+
+// generateCA generates the test CA, issuing certificates valid relative to
+// now, and writes its root and roots to rootsPEMFile.
+func (l *Log) generateCA(now func() time.Time, roots []*x509.Certificate, rootsPEMFile string) error {
+	var err error
+	if l.Factory, err = chains.NewFactory(now); err != nil {
+		return fmt.Errorf("failed to create chain factory: %v", err)
+	}
+	root, err := l.Factory.Root()
+	if err != nil {
+		return fmt.Errorf("failed to generate test root: %v", err)
+	}
+	if l.Issuer, err = l.Factory.Intermediate(root); err != nil {
+		return fmt.Errorf("failed to generate test intermediate: %v", err)
+	}
+	l.Root, l.Intermediate = root.Cert, l.Issuer.Cert
+	rootsPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: l.Root.Raw})
+	for _, r := range roots {
+		rootsPEM = append(rootsPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: r.Raw})...)
+	}
+	if err := os.WriteFile(rootsPEMFile, rootsPEM, 0o644); err != nil {
+		return fmt.Errorf("failed to write roots to %q: %v", rootsPEMFile, err)
+	}
+	return nil
+}
+
+// newStorage returns a function creating the log's POSIX storage in dir.
+//
+// Antispam only uses an in-memory cache: the log is not meant to outlive the
+// process.
+func (l *Log) newStorage(dir string, checkpointInterval time.Duration, logOpts Options) storage.CreateStorage {
+	return func(ctx context.Context, signer note.Signer) (*storage.CTStorage, error) {
+		driver, err := tposix.New(ctx, tposix.Config{Path: dir})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize POSIX Tessera storage driver: %v", err)
+		}
+		opts := tessera.NewAppendOptions().
+			WithCheckpointSigner(signer).
+			WithCTLayout().
+			WithAntispam(inMemoryAntispamSize, nil).
+			WithCheckpointInterval(checkpointInterval)
+		if logOpts.CheckpointRepublishInterval > 0 {
+			opts = opts.WithCheckpointRepublishInterval(logOpts.CheckpointRepublishInterval)
+		}
+		appender, shutdown, reader, err := tessera.NewAppender(ctx, driver, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize POSIX Tessera appender: %v", err)
+		}
+		l.shutdownMu.Lock()
+		l.shutdownFunc = shutdown
+		l.shutdownMu.Unlock()
+		l.Reader = reader
+		if logOpts.WrapAdd != nil {
+			appender.Add = logOpts.WrapAdd(appender.Add)
+		}
+
+		issuerStorage, err := posix.NewIssuerStorage(ctx, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize POSIX issuer storage: %v", err)
+		}
+		return storage.NewCTStorage(ctx, &storage.CTStorageOptions{
+			Appender:            appender,
+			Reader:              reader,
+			IssuerStorage:       issuerStorage,
+			AwaiterPollInterval: awaiterPollInterval,
+			EnablePubAwaiter:    true,
+		})
+	}
+}
+
+// IssueChain returns a new certificate chain accepted by the log, from the
+// leaf to the root, to submit to add-chain.
+func (l *Log) IssueChain() ([]*x509.Certificate, error) {
+	return l.Factory.Chain(l.Issuer)
+}
+
+// IssuePrecertChain returns a new precertificate chain accepted by the log,
+// from the precertificate to the root, to submit to add-pre-chain.
+func (l *Log) IssuePrecertChain() ([]*x509.Certificate, error) {
+	return l.Factory.PrecertChain(l.Issuer)
+}
+
+// Close stops serving the log, shuts it down, and removes its directory if it
+// was created by Start.
+func (l *Log) Close() error {
+	l.closeOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		var errs []error
+		if err := l.srv.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down HTTP server: %v", err))
+		}
+		if err := <-l.serveErr; err != nil {
+			errs = append(errs, fmt.Errorf("HTTP server failed: %v", err))
+		}
+		l.shutdownMu.Lock()
+		if l.shutdownFunc != nil {
+			if err := l.shutdownFunc(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("failed to shut down appender: %v", err))
+			}
+		}
+		l.shutdownMu.Unlock()
+		l.cancel()
+		if l.removeDir {
+			if err := os.RemoveAll(l.Dir); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove %q: %v", l.Dir, err))
+			}
+		}
+		l.closeErr = errors.Join(errs...)
+	})
+	return l.closeErr
+}
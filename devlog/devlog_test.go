@@ -0,0 +1,135 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devlog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+func TestDevLog(t *testing.T) {
+	l, err := Start(t.Context(), Options{})
+	if err != nil {
+		t.Fatalf("Start(): %v", err)
+	}
+	dir := l.Dir
+
+	for _, tc := range []struct {
+		name  string
+		path  string
+		issue func() ([]*x509.Certificate, error)
+	}{
+		{name: "chain", path: "ct/v1/add-chain", issue: l.IssueChain},
+		{name: "precert", path: "ct/v1/add-pre-chain", issue: l.IssuePrecertChain},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			chain, err := tc.issue()
+			if err != nil {
+				t.Fatalf("issue(): %v", err)
+			}
+			req := rfc6962.AddChainRequest{}
+			for _, c := range chain {
+				req.Chain = append(req.Chain, c.Raw)
+			}
+			body, err := json.Marshal(req)
+			if err != nil {
+				t.Fatalf("json.Marshal(): %v", err)
+			}
+			resp, err := http.Post(l.URL+tc.path, "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("http.Post(): %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != http.StatusOK {
+				b, _ := io.ReadAll(resp.Body)
+				t.Fatalf("http.Post(%s): got status %d: %s", tc.path, resp.StatusCode, b)
+			}
+			var sct rfc6962.AddChainResponse
+			if err := json.NewDecoder(resp.Body).Decode(&sct); err != nil {
+				t.Fatalf("failed to decode SCT: %v", err)
+			}
+		})
+	}
+
+	cpRaw := mustGet(t, l.URL+"checkpoint")
+	cp, _, _, err := log.ParseCheckpoint(cpRaw, l.Origin, l.Verifier)
+	if err != nil {
+		t.Fatalf("ParseCheckpoint(): %v", err)
+	}
+	if cp.Size != 2 {
+		t.Errorf("checkpoint size = %d, want 2", cp.Size)
+	}
+	mustGet(t, l.URL+"tile/data/000.p/2")
+	issuerKey := sha256.Sum256(l.Intermediate.Raw)
+	mustGet(t, l.URL+staticct.IssuersPrefix+hex.EncodeToString(issuerKey[:]))
+	if m := mustGet(t, l.URL+staticct.MetadataPath); !bytes.Contains(m, []byte(l.URL)) {
+		t.Errorf("metadata %s doesn't contain URL %q", m, l.URL)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(%q) after Close(): %v, want not exist", dir, err)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("second Close(): %v", err)
+	}
+}
+
+func TestDevLogDir(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Start(t.Context(), Options{Dir: dir, Origin: "dev.example.com/log"})
+	if err != nil {
+		t.Fatalf("Start(): %v", err)
+	}
+	if l.Origin != "dev.example.com/log" {
+		t.Errorf("Origin = %q, want %q", l.Origin, "dev.example.com/log")
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("os.Stat(%q) after Close(): %v, want directory to be kept", dir, err)
+	}
+}
+
+func mustGet(t *testing.T, url string) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("http.Get(%q): %v", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("http.Get(%q): got status %d: %s", url, resp.StatusCode, b)
+	}
+	return b
+}
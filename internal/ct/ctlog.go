@@ -3,7 +3,6 @@ package ct
 import (
 	"context"
 	"crypto"
-	"crypto/ecdsa"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/transparency-dev/tessera"
 	"github.com/transparency-dev/tessera/ctonly"
+	tsigner "github.com/transparency-dev/tesseract/internal/signer"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 	"github.com/transparency-dev/tesseract/storage"
 )
@@ -84,14 +84,12 @@ func NewLog(ctx context.Context, origin string, signer crypto.Signer, cv ChainVa
 	}
 	log.origin = origin
 
-	// Validate signer that only ECDSA is supported.
+	// Validate signer that only ECDSA and RSA are supported.
 	if signer == nil {
 		return nil, errors.New("empty signer")
 	}
-	switch keyType := signer.Public().(type) {
-	case *ecdsa.PublicKey:
-	default:
-		return nil, fmt.Errorf("unsupported key type: %v", keyType)
+	if err := tsigner.CheckLogKey(signer.Public()); err != nil {
+		return nil, err
 	}
 
 	sctSigner := &sctSigner{signer: signer}
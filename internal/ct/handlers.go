@@ -69,6 +69,7 @@ const (
 	addChainName    = entrypointName("AddChain")
 	addPreChainName = entrypointName("AddPreChain")
 	getRootsName    = entrypointName("GetRoots")
+	getMetadataName = entrypointName("GetMetadata")
 )
 
 var (
@@ -340,6 +341,8 @@ type HandlerOptions struct {
 	PathPrefix string
 	// RateLimits describes optional rate limits to enforce.
 	RateLimits RateLimits
+	// Metadata is the JSON log metadata document to serve, if any.
+	Metadata []byte
 }
 
 func NewPathHandlers(ctx context.Context, opts *HandlerOptions, log *log) pathHandlers {
@@ -358,6 +361,9 @@ func NewPathHandlers(ctx context.Context, opts *HandlerOptions, log *log) pathHa
 		prefix + rfc6962.AddPreChainPath: appHandler{opts: opts, log: log, handler: addPreChain, name: addPreChainName, method: http.MethodPost},
 		prefix + rfc6962.GetRootsPath:    appHandler{opts: opts, log: log, handler: getRoots, name: getRootsName, method: http.MethodGet},
 	}
+	if len(opts.Metadata) > 0 {
+		ph[prefix+"/"+staticct.MetadataPath] = appHandler{opts: opts, log: log, handler: getMetadata, name: getMetadataName, method: http.MethodGet}
+	}
 
 	return ph
 }
@@ -490,6 +496,12 @@ func addChainInternal(ctx context.Context, opts *HandlerOptions, log *log, w htt
 
 	logger.DebugExtraContext(ctx, "storage.Add", slog.String("origin", log.origin), slog.String("method", method))
 	future, err := log.storage.Add(ctx, entry)
+	var index tessera.Index
+	if err == nil {
+		// Without the publication awaiter, pushback is only reported when
+		// resolving the future.
+		index, err = future()
+	}
 	// helper function to return a 429
 	tooManyRequests := func(reason string) (int, []attribute.KeyValue, error) {
 		w.Header().Add("Retry-After", strconv.Itoa(rand.IntN(5)+1)) // random retry within [1,6) seconds
@@ -509,11 +521,6 @@ func addChainInternal(ctx context.Context, opts *HandlerOptions, log *log, w htt
 		return http.StatusInternalServerError, nil, fmt.Errorf("couldn't store the leaf: %v", err)
 	}
 
-	index, err := future()
-	if err != nil {
-		return http.StatusInternalServerError, nil, fmt.Errorf("couldn't resolve tessera future: %v", err)
-	}
-
 	var sctInput rfc6962.CertificateTimestamp
 	if index.IsDup {
 		if ok := opts.RateLimits.AcceptDedup(ctx); !ok {
@@ -634,6 +641,15 @@ func getRoots(ctx context.Context, opts *HandlerOptions, log *log, w http.Respon
 	return http.StatusOK, nil, nil
 }
 
+// getMetadata serves the log metadata document.
+func getMetadata(ctx context.Context, opts *HandlerOptions, log *log, w http.ResponseWriter, _ *http.Request) (int, []attribute.KeyValue, error) {
+	w.Header().Set(contentTypeHeader, staticct.MetadataContentType)
+	if _, err := w.Write(opts.Metadata); err != nil {
+		return http.StatusInternalServerError, nil, fmt.Errorf("failed to write metadata: %s", err)
+	}
+	return http.StatusOK, nil, nil
+}
+
 // marshalAndWriteAddChainResponse is used by add-chain and add-pre-chain to create and write
 // the JSON response to the client
 func marshalAndWriteAddChainResponse(sct *rfc6962.SignedCertificateTimestamp, w http.ResponseWriter) error {
@@ -402,6 +402,35 @@ func TestGetRoots(t *testing.T) {
 	}
 }
 
+func TestGetMetadata(t *testing.T) {
+	log, _ := setupTestLog(t)
+	opts := hOpts()
+	opts.Metadata = []byte(`{"log_id":"test"}`)
+	metadataPath := path.Join(prefix, staticct.MetadataPath)
+	server := setupTestServer(t, log, metadataPath, opts)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + metadataPath)
+	if err != nil {
+		t.Fatalf("Failed to get metadata: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected status code: %v", resp.StatusCode)
+	}
+	if got, want := resp.Header.Get("Content-Type"), staticct.MetadataContentType; got != want {
+		t.Errorf("Unexpected Content-Type: got %q, want %q", got, want)
+	}
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	if !bytes.Equal(got, opts.Metadata) {
+		t.Errorf("Unexpected metadata: got %s, want %s", got, opts.Metadata)
+	}
+}
+
 // TODO(phboneff): this could just be a parseBodyJSONChain test
 func TestAddChainWhitespace(t *testing.T) {
 	// Throughout we use variants of a hard-coded POST body derived from a chain of:
@@ -0,0 +1,100 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ct
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LogMetadata describes a static-ct-api log, in the format of a tiled_logs
+// entry of a v3 log list: https://www.gstatic.com/ct/log_list/v3/log_list_schema.json.
+type LogMetadata struct {
+	Description      string            `json:"description,omitempty"`
+	LogID            string            `json:"log_id"`
+	Key              string            `json:"key"`
+	SubmissionURL    string            `json:"submission_url"`
+	MonitoringURL    string            `json:"monitoring_url,omitempty"`
+	MMD              int64             `json:"mmd"`
+	TemporalInterval *TemporalInterval `json:"temporal_interval,omitempty"`
+}
+
+// TemporalInterval is the range of certificate NotAfter dates accepted by a log.
+type TemporalInterval struct {
+	StartInclusive string `json:"start_inclusive"`
+	EndExclusive   string `json:"end_exclusive"`
+}
+
+// MetadataOptions holds the parts of LogMetadata which can't be derived from
+// the log's key and origin.
+type MetadataOptions struct {
+	// Description is a human readable description of the log.
+	Description string
+	// PathPrefix is the path prefix of the submission endpoints, if any.
+	PathPrefix string
+	// MonitoringURL is the URL prefix of the log's monitoring APIs.
+	MonitoringURL string
+	// MMD is the maximum merge delay of the log.
+	MMD time.Duration
+	// NotAfterStart and NotAfterLimit bound the NotAfter dates accepted by
+	// the log. Both must be set to publish a temporal interval.
+	NotAfterStart *time.Time
+	NotAfterLimit *time.Time
+}
+
+// NewLogMetadata builds the metadata document of the log with the given
+// origin and public key.
+func NewLogMetadata(origin string, pubKey crypto.PublicKey, opts MetadataOptions) (*LogMetadata, error) {
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	logID, err := getCTLogID(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log ID: %v", err)
+	}
+	m := &LogMetadata{
+		Description:   opts.Description,
+		LogID:         base64.StdEncoding.EncodeToString(logID[:]),
+		Key:           base64.StdEncoding.EncodeToString(der),
+		SubmissionURL: submissionURL(origin, opts.PathPrefix),
+		MonitoringURL: opts.MonitoringURL,
+		MMD:           int64(opts.MMD.Seconds()),
+	}
+	if opts.NotAfterStart != nil && opts.NotAfterLimit != nil {
+		m.TemporalInterval = &TemporalInterval{
+			StartInclusive: opts.NotAfterStart.UTC().Format(time.RFC3339),
+			EndExclusive:   opts.NotAfterLimit.UTC().Format(time.RFC3339),
+		}
+	}
+	return m, nil
+}
+
+// submissionURL returns the submission prefix of a log as an HTTPS URL with a
+// trailing slash.
+//
+// The origin should already include the path prefix, as required by
+// https://c2sp.org/static-ct-api. If it doesn't, pathPrefix is appended.
+func submissionURL(origin, pathPrefix string) string {
+	u := strings.TrimRight(origin, "/")
+	if p := strings.Trim(pathPrefix, "/"); p != "" && !strings.HasSuffix(u, "/"+p) {
+		u += "/" + p
+	}
+	return "https://" + u + "/"
+}
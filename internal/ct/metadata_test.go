@@ -0,0 +1,100 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ct
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/transparency-dev/tesseract/internal/testdata"
+)
+
+func TestNewLogMetadata(t *testing.T) {
+	block, _ := pem.Decode([]byte(testdata.DemoPublicKey))
+	pk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("unexpected error loading public key: %v", err)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		desc   string
+		origin string
+		opts   MetadataOptions
+		want   LogMetadata
+	}{
+		{
+			desc:   "minimal",
+			origin: "example.com/log",
+			want: LogMetadata{
+				LogID:         base64.StdEncoding.EncodeToString(demoLogID[:]),
+				Key:           base64.StdEncoding.EncodeToString(block.Bytes),
+				SubmissionURL: "https://example.com/log/",
+			},
+		},
+		{
+			desc:   "full",
+			origin: "example.com/log",
+			opts: MetadataOptions{
+				Description:   "Example log",
+				PathPrefix:    "/log",
+				MonitoringURL: "https://static.example.com/log/",
+				MMD:           time.Minute,
+				NotAfterStart: &start,
+				NotAfterLimit: &limit,
+			},
+			want: LogMetadata{
+				Description:   "Example log",
+				LogID:         base64.StdEncoding.EncodeToString(demoLogID[:]),
+				Key:           base64.StdEncoding.EncodeToString(block.Bytes),
+				SubmissionURL: "https://example.com/log/",
+				MonitoringURL: "https://static.example.com/log/",
+				MMD:           60,
+				TemporalInterval: &TemporalInterval{
+					StartInclusive: "2026-01-01T00:00:00Z",
+					EndExclusive:   "2026-07-01T00:00:00Z",
+				},
+			},
+		},
+		{
+			desc:   "origin-without-path-prefix",
+			origin: "example.com",
+			opts: MetadataOptions{
+				PathPrefix:    "/log/",
+				NotAfterStart: &start,
+			},
+			want: LogMetadata{
+				LogID:         base64.StdEncoding.EncodeToString(demoLogID[:]),
+				Key:           base64.StdEncoding.EncodeToString(block.Bytes),
+				SubmissionURL: "https://example.com/log/",
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewLogMetadata(tc.origin, pk, tc.opts)
+			if err != nil {
+				t.Fatalf("NewLogMetadata(): %v", err)
+			}
+			if diff := cmp.Diff(&tc.want, got); diff != "" {
+				t.Errorf("NewLogMetadata() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -41,8 +41,9 @@ func (sctSigner *sctSigner) Sign(sctInput rfc6962.CertificateTimestamp) (*rfc696
 		return nil, fmt.Errorf("failed to serialize SCT data: %v", err)
 	}
 
-	h := sha256.Sum256(data)
-	signature, err := sctSigner.signer.Sign(rand.Reader, h[:], crypto.SHA256)
+	// Use SignMessage so that signers which need to inspect the structure
+	// being signed, such as remote signers, receive the full message.
+	signature, err := crypto.SignMessage(sctSigner.signer, rand.Reader, data, crypto.SHA256)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign SCT data: %v", err)
 	}
@@ -110,8 +111,7 @@ func buildCp(signer crypto.Signer, size uint64, timeMilli uint64, hash []byte) (
 		return nil, fmt.Errorf("ct.SerializeSTHSignatureInput(): %v", err)
 	}
 
-	h := sha256.Sum256(sthBytes)
-	signature, err := signer.Sign(rand.Reader, h[:], crypto.SHA256)
+	signature, err := crypto.SignMessage(signer, rand.Reader, sthBytes, crypto.SHA256)
 	if err != nil {
 		return nil, err
 	}
@@ -16,7 +16,6 @@ package ct
 
 import (
 	"bytes"
-	"crypto/ecdsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
@@ -25,11 +24,12 @@ import (
 	"time"
 
 	"github.com/kylelemons/godebug/pretty"
+	tsigner "github.com/transparency-dev/tesseract/internal/signer"
 	"github.com/transparency-dev/tesseract/internal/testdata"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
 	"github.com/transparency-dev/tesseract/internal/types/tls"
 	"github.com/transparency-dev/tesseract/internal/x509util"
-	"github.com/transparency-dev/tesseract/internal/types/staticct"
 )
 
 var (
@@ -99,7 +99,6 @@ const (
 		"696d757374626565786163746c7974686972747974776f62797465736c6f6e67"
 )
 
-
 func defaultCertificate() []byte {
 	return []byte(defaultCertifictateString)
 }
@@ -367,54 +366,75 @@ func setupSCTSigner(fakeSig []byte) (*sctSigner, error) {
 }
 
 func TestBuildCp(t *testing.T) {
-	// Create a test signer.
-	ecdsaSigner, err := loadPEMPrivateKey("../testdata/test_ct_server_ecdsa_private_key.pem")
-	if err != nil {
-		t.Fatalf("Can't open key: %v", err)
-	}
-
-	// Define test data.
-	size := uint64(12345)
-	hash := []byte("test_hash_value_12345678901234567890")
-
-	// Build the checkpoint which is in the RFC6962NoteSignature format.
-	checkpoint, err := buildCp(ecdsaSigner, size, fixedTimeMillis, hash)
-	if err != nil {
-		t.Errorf("buildCp failed: %v", err)
-	}
-
-	// Verify whether the checkpoint is empty.
-	if len(checkpoint) == 0 {
-		t.Errorf("buildCp returned an empty checkpoint")
-	}
-
-	// Verify that the checkpoint can be parsed.
-	var sig rfc6962NoteSignature
-	_, err = tls.Unmarshal(checkpoint, &sig)
-	if err != nil {
-		t.Errorf("failed to unmarshal checkpoint: %v", err)
-	}
-	// Verify the timestamp in the note signature.
-	if sig.Timestamp != fixedTimeMillis {
-		t.Errorf("buildCp returned wrong timestamp, got %d, want %d", sig.Timestamp, fixedTimeMillis)
-	}
-
-	// Verify the signature using the public key.
-	sth := rfc6962.SignedTreeHead{
-		Version:   rfc6962.V1,
-		TreeSize:  size,
-		Timestamp: fixedTimeMillis,
-	}
-	copy(sth.SHA256RootHash[:], hash)
-
-	sthBytes, err := serializeSTHSignatureInput(sth)
-	if err != nil {
-		t.Fatalf("serializeSTHSignatureInput(): %v", err)
-	}
-
-	h := sha256.Sum256(sthBytes)
-	valid := ecdsa.VerifyASN1(ecdsaSigner.Public().(*ecdsa.PublicKey), h[:], sig.Signature.Signature)
-	if !valid {
-		t.Errorf("buildCp returned an invalid signature")
+	for _, tc := range []struct {
+		desc    string
+		keyFile string
+		wantAlg tls.SignatureAlgorithm
+	}{
+		{
+			desc:    "ecdsa",
+			keyFile: "../testdata/test_ct_server_ecdsa_private_key.pem",
+			wantAlg: tls.ECDSA,
+		},
+		{
+			desc:    "rsa",
+			keyFile: "../testdata/test_ct_server_rsa_private_key.pem",
+			wantAlg: tls.RSA,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Create a test signer.
+			signer, err := loadPEMPrivateKey(tc.keyFile)
+			if err != nil {
+				t.Fatalf("Can't open key: %v", err)
+			}
+
+			// Define test data.
+			size := uint64(12345)
+			hash := []byte("test_hash_value_12345678901234567890")
+
+			// Build the checkpoint which is in the RFC6962NoteSignature format.
+			checkpoint, err := buildCp(signer, size, fixedTimeMillis, hash)
+			if err != nil {
+				t.Errorf("buildCp failed: %v", err)
+			}
+
+			// Verify whether the checkpoint is empty.
+			if len(checkpoint) == 0 {
+				t.Errorf("buildCp returned an empty checkpoint")
+			}
+
+			// Verify that the checkpoint can be parsed.
+			var sig rfc6962NoteSignature
+			_, err = tls.Unmarshal(checkpoint, &sig)
+			if err != nil {
+				t.Errorf("failed to unmarshal checkpoint: %v", err)
+			}
+			// Verify the timestamp in the note signature.
+			if sig.Timestamp != fixedTimeMillis {
+				t.Errorf("buildCp returned wrong timestamp, got %d, want %d", sig.Timestamp, fixedTimeMillis)
+			}
+			if got := sig.Signature.Algorithm.Signature; got != tc.wantAlg {
+				t.Errorf("buildCp returned signature algorithm %v, want %v", got, tc.wantAlg)
+			}
+
+			// Verify the signature using the public key.
+			sth := rfc6962.SignedTreeHead{
+				Version:   rfc6962.V1,
+				TreeSize:  size,
+				Timestamp: fixedTimeMillis,
+			}
+			copy(sth.SHA256RootHash[:], hash)
+
+			sthBytes, err := serializeSTHSignatureInput(sth)
+			if err != nil {
+				t.Fatalf("serializeSTHSignatureInput(): %v", err)
+			}
+
+			h := sha256.Sum256(sthBytes)
+			if !tsigner.VerifySHA256(signer.Public(), h[:], sig.Signature.Signature) {
+				t.Errorf("buildCp returned an invalid signature")
+			}
+		})
 	}
 }
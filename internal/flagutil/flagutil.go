@@ -0,0 +1,61 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flagutil contains flag.Value implementations shared by TesseraCT
+// binaries.
+package flagutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timestamp is a flag.Value holding an optional RFC3339 timestamp.
+type Timestamp struct {
+	T *time.Time
+}
+
+func (t *Timestamp) String() string {
+	if t.T != nil {
+		return t.T.Format(time.RFC3339)
+	}
+	return ""
+}
+
+// Set parses w as an RFC3339 timestamp. An empty string leaves t unset.
+func (t *Timestamp) Set(w string) error {
+	if w == "" {
+		return nil
+	}
+	tt, err := time.Parse(time.RFC3339, w)
+	if err != nil {
+		return fmt.Errorf("can't parse %q as RFC3339 timestamp: %v", w, err)
+	}
+	t.T = &tt
+	return nil
+}
+
+// MultiString allows a flag to be specified multiple times on the command
+// line, and stores all of these values.
+type MultiString []string
+
+func (ms *MultiString) String() string {
+	return strings.Join(*ms, ",")
+}
+
+func (ms *MultiString) Set(w string) error {
+	*ms = append(*ms, w)
+	return nil
+}
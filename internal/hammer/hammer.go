@@ -23,7 +23,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
@@ -45,27 +44,28 @@ import (
 	"sync"
 	"time"
 
-	tdnote "github.com/transparency-dev/formats/note"
-	"github.com/transparency-dev/tesseract/internal/client"
-	"github.com/transparency-dev/tesseract/internal/client/gcp"
+	"github.com/transparency-dev/tesseract/client"
 	"github.com/transparency-dev/tesseract/internal/hammer/loadtest"
+	"github.com/transparency-dev/tesseract/internal/source"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 	"github.com/transparency-dev/tesseract/internal/types/staticct"
-	tlstypes "github.com/transparency-dev/tesseract/internal/types/tls"
-	"github.com/transparency-dev/tesseract/internal/x509util"
 	"golang.org/x/mod/sumdb/note"
 	"golang.org/x/net/http2"
 )
 
 func init() {
-	flag.Var(&logURL, "log_url", "Log storage root URL (can be specified multiple times), e.g. https://log.server/and/path/")
+	flag.Var(&logURL, "log_url", "Log storage root URL (can be specified multiple times), e.g. https://log.server/and/path/, or gs://bucket, s3://bucket or file:///path/to/log/ to read the log directly from its storage")
 	flag.Var(&writeLogURL, "write_log_url", "Root URL for writing to a log (can be specified multiple times), e.g. https://log.server/and/path/ (optional, defaults to log_url)")
+	flag.Var(&checkpointSourceURL, "checkpoint_source_url", "Root URL of a distributor of cosigned checkpoints for the log (can be specified multiple times), e.g. https://distributor.example.com/log/ (optional, requires --witness_policy_file)")
 }
 
 var (
 	logURL      multiStringFlag
 	writeLogURL multiStringFlag
 
+	checkpointSourceURL multiStringFlag
+	witnessPolicyFile   = flag.String("witness_policy_file", "", "Path to a witness policy file. If set, only checkpoints whose cosignatures satisfy the policy are trusted. See cmd/tesseract/README.md#policy-file.")
+
 	origin                    = flag.String("origin", os.Getenv("CT_LOG_ORIGIN"), "Origin of the log, for checkpoints and the monitoring prefix. This is defaulted to the environment variable CT_LOG_ORIGIN")
 	logPubKey                 = flag.String("log_public_key", os.Getenv("CT_LOG_PUBLIC_KEY"), "Public key for the log. This is defaulted to the environment variable CT_LOG_PUBLIC_KEY")
 	intermediateCACertPath    = flag.String("intermediate_ca_cert_path", "./internal/hammer/testdata/test_intermediate_ca_cert.pem", "Intermediate CA certificate path for certificate generator")
@@ -154,7 +154,11 @@ func main() {
 	w := mustCreateWriters(writeLogURL, verifier)
 
 	var cpRaw []byte
-	cons := client.UnilateralConsensus(r.ReadCheckpoint)
+	cons, err := consensusFromFlags(r.ReadCheckpoint)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to set up checkpoint consensus", slog.Any("error", err))
+		os.Exit(1)
+	}
 	tracker, err := client.NewLogStateTracker(ctx, r.ReadCheckpoint, r.ReadTile, cpRaw, logSigV, logSigV.Name(), cons)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create LogStateTracker", slog.Any("error", err))
@@ -283,6 +287,42 @@ func newLeafGenerator(startSize uint64, dupChance float64, intermediateCACert *x
 	}
 }
 
+// consensusFromFlags returns the function used to agree on the log's latest
+// checkpoint.
+//
+// Without --witness_policy_file, the checkpoint served by the log is trusted.
+// Otherwise, checkpoints are fetched from the log and from each
+// --checkpoint_source_url, and must satisfy the witness policy.
+func consensusFromFlags(readCheckpoint client.CheckpointFetcherFunc) (client.ConsensusCheckpointFunc, error) {
+	if *witnessPolicyFile == "" {
+		if len(checkpointSourceURL) > 0 {
+			return nil, errors.New("--checkpoint_source_url requires --witness_policy_file")
+		}
+		return client.UnilateralConsensus(readCheckpoint), nil
+	}
+	p, err := os.ReadFile(*witnessPolicyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read witness policy file %q: %v", *witnessPolicyFile, err)
+	}
+	wp, err := client.ParseWitnessPolicy(p)
+	if err != nil {
+		return nil, err
+	}
+	fs := []client.CheckpointFetcherFunc{readCheckpoint}
+	for _, s := range checkpointSourceURL {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint source URL %q: %v", s, err)
+		}
+		f, err := client.NewHTTPFetcher(u, hc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP fetcher for %q: %v", s, err)
+		}
+		fs = append(fs, f.ReadCheckpoint)
+	}
+	return client.WitnessedConsensus(wp, fs...), nil
+}
+
 // multiStringFlag allows a flag to be specified multiple times on the command
 // line, and stores all of these values.
 type multiStringFlag []string
@@ -429,11 +469,7 @@ func logSigVerifier(origin, b64PubKey string) (note.Verifier, error) {
 		return nil, err
 	}
 
-	verifierKey, err := tdnote.RFC6962VerifierString(origin, pub)
-	if err != nil {
-		return nil, fmt.Errorf("error creating RFC6962 verifier string: %v", err)
-	}
-	logSigV, err := tdnote.NewVerifier(verifierKey)
+	logSigV, err := staticct.NewCheckpointVerifier(origin, pub)
 	if err != nil {
 		return nil, fmt.Errorf("error creating verifier: %v", err)
 	}
@@ -453,31 +489,18 @@ func mustCreateReaders(ctx context.Context, us []string) loadtest.LogReader {
 			os.Exit(1)
 		}
 
-		switch rURL.Scheme {
-		case "http", "https":
-			c, err := client.NewHTTPFetcher(rURL, hc)
-			if err != nil {
-				slog.ErrorContext(ctx, "Failed to create HTTP fetcher", slog.String("url", u), slog.Any("error", err))
-				os.Exit(1)
-			}
+		f, err := source.New(ctx, rURL, hc)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create log reader", slog.String("url", u), slog.Any("error", err))
+			os.Exit(1)
+		}
+		if c, ok := f.(*client.HTTPFetcher); ok {
 			c.EnableRetries(5)
 			if *bearerToken != "" {
 				c.SetAuthorizationHeader(fmt.Sprintf("Bearer %s", *bearerToken))
 			}
-			r = append(r, c)
-		case "file":
-			r = append(r, client.FileFetcher{Root: rURL.Path})
-		case "gs":
-			c, err := gcp.NewGSFetcher(ctx, rURL.Host, nil)
-			if err != nil {
-				slog.ErrorContext(ctx, "NewGSFetcher", slog.Any("error", err))
-				os.Exit(1)
-			}
-			r = append(r, c)
-		default:
-			slog.ErrorContext(ctx, "Unsupported scheme on log URL", slog.String("scheme", rURL.Scheme))
-			os.Exit(1)
 		}
+		r = append(r, f)
 	}
 	return loadtest.NewRoundRobinReader(r)
 }
@@ -578,34 +601,22 @@ func newAddChainResponseVerifier(pubKey crypto.PublicKey) (addChainResponseVerif
 			return 0, 0, errors.New("cannot verify SCT: log public key is nil")
 		}, errors.New("log public key is nil")
 	}
-	pubBytes, err := x509.MarshalPKIXPublicKey(pubKey)
-	if err != nil {
+	if _, err := x509.MarshalPKIXPublicKey(pubKey); err != nil {
 		return func(reqBytes []byte, respBytes []byte) (uint64, uint64, error) {
 			return 0, 0, fmt.Errorf("failed to marshal public key: %w", err)
 		}, fmt.Errorf("can't parse public key: %v", err)
 	}
-	expectedID := sha256.Sum256(pubBytes)
 
 	return func(reqBytes []byte, respBytes []byte) (uint64, uint64, error) {
 		if len(reqBytes) == 0 {
 			return 0, 0, errors.New("cannot verify SCT: submitted request payload is empty")
 		}
 
-		var resp rfc6962.AddChainResponse
-		if err := json.Unmarshal(respBytes, &resp); err != nil {
+		var sct client.SCT
+		if err := json.Unmarshal(respBytes, &sct); err != nil {
 			return 0, 0, fmt.Errorf("can't parse add-chain response: %v", err)
 		}
 
-		extBytes, err := base64.StdEncoding.DecodeString(resp.Extensions)
-		if err != nil {
-			return 0, 0, fmt.Errorf("can't decode base64 extensions in response: %w", err)
-		}
-
-		leafIdx, err := staticct.ParseCTExtensionsBytes(extBytes)
-		if err != nil {
-			return 0, 0, fmt.Errorf("can't parse extensions: %v", err)
-		}
-
 		var req rfc6962.AddChainRequest
 		if err := json.Unmarshal(reqBytes, &req); err != nil {
 			return 0, 0, fmt.Errorf("can't unmarshal submitted request: %w", err)
@@ -623,49 +634,10 @@ func newAddChainResponseVerifier(pubKey crypto.PublicKey) (addChainResponseVerif
 			chainCerts = append(chainCerts, cert)
 		}
 
-		isPrecert, err := x509util.IsPrecertificate(chainCerts[0])
+		leafIdx, err := client.VerifySCT(pubKey, chainCerts, &sct)
 		if err != nil {
-			return 0, 0, fmt.Errorf("failed to check if leaf is precertificate: %w", err)
+			return 0, 0, fmt.Errorf("failed to verify SCT: %v", err)
 		}
-
-		entry, err := x509util.EntryFromChain(chainCerts, isPrecert, resp.Timestamp)
-		if err != nil {
-			return 0, 0, fmt.Errorf("failed to build entry from submitted chain: %w", err)
-		}
-		defer x509util.ReturnEntry(entry)
-
-		var ikh [32]byte
-		if isPrecert {
-			copy(ikh[:], entry.IssuerKeyHash)
-		}
-		sctInput := *staticct.NewCertificateTimestamp(extBytes, resp.Timestamp, isPrecert, entry.Certificate, ikh)
-
-		if !bytes.Equal(resp.ID, expectedID[:]) {
-			return 0, 0, fmt.Errorf("SCT LogID mismatch: got %x, want %x", resp.ID, expectedID[:])
-		}
-
-		var ds rfc6962.DigitallySigned
-		if rest, err := tlstypes.Unmarshal(resp.Signature, &ds); err != nil {
-			return 0, 0, fmt.Errorf("failed to unmarshal DigitallySigned from AddChainResponse.Signature: %w", err)
-		} else if len(rest) > 0 {
-			return 0, 0, fmt.Errorf("extra data after DigitallySigned: %d bytes", len(rest))
-		}
-
-		data, err := tlstypes.Marshal(sctInput)
-		if err != nil {
-			return 0, 0, fmt.Errorf("failed to tlstypes.Marshal sctInput: %w", err)
-		}
-		digest := sha256.Sum256(data)
-
-		switch pk := pubKey.(type) {
-		case *ecdsa.PublicKey:
-			if !ecdsa.VerifyASN1(pk, digest[:], ds.Signature) {
-				return 0, 0, errors.New("SCT ECDSA signature verification failed")
-			}
-		default:
-			return 0, 0, fmt.Errorf("unsupported public key type: %T", pubKey)
-		}
-
-		return uint64(leafIdx), resp.Timestamp, nil
+		return leafIdx, sct.Timestamp, nil
 	}, nil
 }
@@ -21,7 +21,7 @@ import (
 	"os"
 	"time"
 
-	"github.com/transparency-dev/tesseract/internal/client"
+	"github.com/transparency-dev/tesseract/client"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 )
 
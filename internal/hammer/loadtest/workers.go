@@ -29,7 +29,7 @@ import (
 	"github.com/transparency-dev/merkle/proof"
 	hasher "github.com/transparency-dev/merkle/rfc6962"
 	"github.com/transparency-dev/tessera/api/layout"
-	"github.com/transparency-dev/tesseract/internal/client"
+	"github.com/transparency-dev/tesseract/client"
 	"github.com/transparency-dev/tesseract/internal/logger"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 
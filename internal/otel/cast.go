@@ -33,6 +33,12 @@ var (
 		[]float64{0, 0.1, 0.25, 0.5, 0.75, 1, 2, 5, 7.5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000, 25000, 50000, 75000, 100000},
 		time.Minute,
 		time.Second)
+
+	// MergeDelayHistogramBuckets is a range of second scale bucket boundaries intended to cover merge delays, from sub-second up to a day.
+	MergeDelayHistogramBuckets = createBuckets(
+		[]float64{0, 0.25, 0.5, 1, 2, 3, 4, 5, 7.5, 10, 15, 20, 30, 45, 60, 90, 120, 300, 600, 1800, 3600, 7200, 14400, 43200, 86400},
+		time.Second,
+		time.Second)
 )
 
 // createBuckets is a helper for creating histogram buckets in a readable fashion by taking a list of values and the duration unit they represent, and returns
@@ -0,0 +1,149 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/signer"
+)
+
+// Signer implements crypto.Signer and crypto.MessageSigner by delegating
+// signing operations to a remote signing daemon.
+//
+// Only crypto.MessageSigner is usable: the daemon refuses to sign opaque
+// digests, so Sign always returns an error. Callers should use
+// crypto.SignMessage, which prefers SignMessage when it is available.
+type Signer struct {
+	pubKey  crypto.PublicKey
+	logID   []byte
+	signURL string
+	client  *http.Client
+}
+
+// NewSigner returns a Signer which requests signatures from the daemon at
+// serverURL, using the given HTTP client.
+//
+// pubKey is the public key of the log. It is used to select the key held by
+// the daemon, and to verify that the daemon signs with the expected key.
+// The HTTP client should be configured for mTLS, see ClientTLSConfig.
+func NewSigner(serverURL string, pubKey crypto.PublicKey, client *http.Client) (*Signer, error) {
+	logID, err := LogID(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	signURL, err := url.JoinPath(serverURL, SignPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer URL %q: %v", serverURL, err)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Signer{
+		pubKey:  pubKey,
+		logID:   logID[:],
+		signURL: signURL,
+		client:  client,
+	}, nil
+}
+
+// Public returns the public key of the log.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.pubKey
+}
+
+// Sign always returns an error, since the remote daemon only signs messages
+// it can inspect. Use SignMessage instead.
+func (s *Signer) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("remote signer does not sign digests, use crypto.SignMessage")
+}
+
+// SignMessage asks the remote daemon to sign msg, which must be a TLS-encoded
+// CertificateTimestamp or TreeHeadSignature.
+func (s *Signer) SignMessage(_ io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts == nil || opts.HashFunc() != crypto.SHA256 {
+		return nil, errors.New("remote signer only supports crypto.SHA256")
+	}
+	body, err := json.Marshal(SignRequest{LogID: s.logID, Message: msg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sign request: %v", err)
+	}
+	// crypto.Signer doesn't take a context, the HTTP client timeout bounds the request.
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.signURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sign request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sign request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	rb, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sign response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer returned %d: %s", resp.StatusCode, bytes.TrimSpace(rb))
+	}
+	var sr SignResponse
+	if err := json.Unmarshal(rb, &sr); err != nil {
+		return nil, fmt.Errorf("failed to parse sign response: %v", err)
+	}
+	if !verify(s.pubKey, msg, sr.Signature) {
+		return nil, errors.New("remote signer returned a signature which does not verify with the log public key")
+	}
+	return sr.Signature, nil
+}
+
+// NewMTLSSigner returns a Signer which talks to the daemon at serverURL over
+// mTLS, for the log whose PEM encoded public key is in pubKeyFile.
+func NewMTLSSigner(serverURL, pubKeyFile, certFile, keyFile, caFile string) (*Signer, error) {
+	p, err := os.ReadFile(pubKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key file %q: %v", pubKeyFile, err)
+	}
+	block, _ := pem.Decode(p)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM public key from %q", pubKeyFile)
+	}
+	pubKey, err := signer.ParsePublicKeyPEM(block)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := ClientTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	hc := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig:     tlsConfig,
+			MaxIdleConnsPerHost: 64,
+		},
+	}
+	return NewSigner(serverURL, pubKey, hc)
+}
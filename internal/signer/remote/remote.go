@@ -0,0 +1,112 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remote implements a small protocol which allows TesseraCT to use a
+// log signing key held by a separate signing daemon.
+//
+// Rather than sending a digest to be signed, the client sends the full
+// TLS-encoded structure which is being signed. This allows the daemon to
+// check that it is only ever asked to sign a well-formed RFC 6962
+// CertificateTimestamp or TreeHeadSignature.
+//
+// Clients and servers are expected to authenticate each other with mTLS.
+package remote
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+const (
+	// SignPath is the path of the signing endpoint served by the daemon.
+	SignPath = "/v1/sign"
+)
+
+// SignRequest is the body of a request sent to SignPath.
+type SignRequest struct {
+	// LogID is the RFC 6962 log ID of the key to sign with.
+	LogID []byte `json:"log_id"`
+	// Message is the TLS-encoded CertificateTimestamp or TreeHeadSignature to sign.
+	Message []byte `json:"message"`
+}
+
+// SignResponse is the body of a successful response from SignPath.
+type SignResponse struct {
+	// Signature is the signature over the SHA-256 digest of the request's Message.
+	Signature []byte `json:"signature"`
+}
+
+// LogID returns the RFC 6962 log ID for the given public key, i.e. the
+// SHA-256 hash of its DER-encoded SubjectPublicKeyInfo.
+func LogID(pubKey crypto.PublicKey) ([sha256.Size]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	return sha256.Sum256(der), nil
+}
+
+// ClientTLSConfig returns a TLS configuration which presents the certificate
+// in certFile and trusts the server certificates issued by the CAs in caFile.
+func ClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %v", err)
+	}
+	pool, err := certPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// ServerTLSConfig returns a TLS configuration which presents the certificate
+// in certFile and requires clients to present a certificate issued by one of
+// the CAs in caFile.
+func ServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %v", err)
+	}
+	pool, err := certPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+func certPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file %q: %v", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file %q", caFile)
+	}
+	return pool, nil
+}
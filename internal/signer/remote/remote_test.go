@@ -0,0 +1,202 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+)
+
+var now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// leafIndexExt is a CTExtensions containing a leaf_index extension for index 42.
+var leafIndexExt = []byte{0, 0, 5, 0, 0, 0, 0, 42}
+
+func mustGenKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return k
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := tls.Marshal(v)
+	if err != nil {
+		t.Fatalf("tls.Marshal: %v", err)
+	}
+	return b
+}
+
+func newTestServer(t *testing.T, k crypto.Signer, opts ServerOpts) *httptest.Server {
+	t.Helper()
+	opts.Now = func() time.Time { return now }
+	opts.AuditLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+	s, err := NewServer([]crypto.Signer{k}, opts)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestSignMessage(t *testing.T) {
+	k := mustGenKey(t)
+	ts := newTestServer(t, k, ServerOpts{MaxClockSkew: time.Minute})
+	s, err := NewSigner(ts.URL, k.Public(), ts.Client())
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+
+	ms := uint64(now.UnixMilli())
+	for _, test := range []struct {
+		desc    string
+		msg     []byte
+		wantErr string
+	}{
+		{
+			desc: "cert",
+			msg:  mustMarshal(t, *staticct.NewCertificateTimestamp(leafIndexExt, ms, false, []byte("cert"), [32]byte{})),
+		},
+		{
+			desc: "precert",
+			msg:  mustMarshal(t, *staticct.NewCertificateTimestamp(leafIndexExt, ms, true, []byte("tbs"), [32]byte{1})),
+		},
+		{
+			desc: "tree head",
+			msg: mustMarshal(t, rfc6962.TreeHeadSignature{
+				Version:       rfc6962.V1,
+				SignatureType: rfc6962.TreeHashSignatureType,
+				Timestamp:     ms,
+				TreeSize:      12,
+			}),
+		},
+		{
+			desc:    "missing leaf index",
+			msg:     mustMarshal(t, *staticct.NewCertificateTimestamp(nil, ms, false, []byte("cert"), [32]byte{})),
+			wantErr: "invalid extensions",
+		},
+		{
+			desc:    "timestamp too old",
+			msg:     mustMarshal(t, *staticct.NewCertificateTimestamp(leafIndexExt, ms-2*60*1000, false, []byte("cert"), [32]byte{})),
+			wantErr: "away from now",
+		},
+		{
+			desc: "timestamp in the future",
+			msg: mustMarshal(t, rfc6962.TreeHeadSignature{
+				Version:       rfc6962.V1,
+				SignatureType: rfc6962.TreeHashSignatureType,
+				Timestamp:     ms + 2*60*1000,
+			}),
+			wantErr: "away from now",
+		},
+		{
+			desc:    "trailing data",
+			msg:     append(mustMarshal(t, rfc6962.TreeHeadSignature{SignatureType: rfc6962.TreeHashSignatureType, Timestamp: ms}), 0),
+			wantErr: "trailing data",
+		},
+		{
+			desc:    "unknown signature type",
+			msg:     []byte{0, 7, 1, 2, 3},
+			wantErr: "unsupported signature type",
+		},
+		{
+			desc:    "unknown version",
+			msg:     []byte{1, 0, 1, 2, 3},
+			wantErr: "unsupported version",
+		},
+		{
+			desc:    "arbitrary digest",
+			msg:     make([]byte, sha256.Size),
+			wantErr: "failed to parse",
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			sig, err := crypto.SignMessage(s, rand.Reader, test.msg, crypto.SHA256)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("SignMessage()=%v, want error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignMessage(): %v", err)
+			}
+			h := sha256.Sum256(test.msg)
+			if !ecdsa.VerifyASN1(&k.PublicKey, h[:], sig) {
+				t.Error("signature does not verify")
+			}
+		})
+	}
+}
+
+func TestSignDigestFails(t *testing.T) {
+	k := mustGenKey(t)
+	s, err := NewSigner("https://example.com", k.Public(), nil)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	h := sha256.Sum256([]byte("hello"))
+	if _, err := s.Sign(rand.Reader, h[:], crypto.SHA256); err == nil {
+		t.Error("Sign() succeeded, want error")
+	}
+}
+
+func TestUnknownLogID(t *testing.T) {
+	k := mustGenKey(t)
+	ts := newTestServer(t, k, ServerOpts{})
+	s, err := NewSigner(ts.URL, mustGenKey(t).Public(), ts.Client())
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	msg := mustMarshal(t, *staticct.NewCertificateTimestamp(leafIndexExt, uint64(now.UnixMilli()), false, []byte("cert"), [32]byte{}))
+	if _, err := crypto.SignMessage(s, rand.Reader, msg, crypto.SHA256); err == nil || !strings.Contains(err.Error(), "unknown log ID") {
+		t.Errorf("SignMessage()=%v, want unknown log ID error", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	k := mustGenKey(t)
+	ts := newTestServer(t, k, ServerOpts{RateLimit: 0.001, RateBurst: 2})
+	s, err := NewSigner(ts.URL, k.Public(), ts.Client())
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	msg := mustMarshal(t, *staticct.NewCertificateTimestamp(leafIndexExt, uint64(now.UnixMilli()), false, []byte("cert"), [32]byte{}))
+	for i := range 2 {
+		if _, err := crypto.SignMessage(s, rand.Reader, msg, crypto.SHA256); err != nil {
+			t.Fatalf("SignMessage() #%d: %v", i, err)
+		}
+	}
+	if _, err := crypto.SignMessage(s, rand.Reader, msg, crypto.SHA256); err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("SignMessage()=%v, want rate limited error", err)
+	}
+}
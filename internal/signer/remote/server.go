@@ -0,0 +1,252 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/signer"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// maxRequestBytes bounds the size of sign requests. CertificateTimestamps
+	// contain a whole certificate, so this needs to be fairly generous.
+	maxRequestBytes = 1 << 20
+)
+
+// ServerOpts configures a Server.
+type ServerOpts struct {
+	// RateLimit is the maximum sustained number of signatures per second, across all keys.
+	// Zero means no limit.
+	RateLimit float64
+	// RateBurst is the maximum number of signatures which can be issued in a burst.
+	RateBurst int
+	// MaxClockSkew is how far from the daemon's clock the timestamp in a
+	// signed structure may be. Zero disables the check.
+	MaxClockSkew time.Duration
+	// AuditLog receives one record for each signature issued or refused.
+	// Defaults to slog.Default().
+	AuditLog *slog.Logger
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server is an http.Handler which serves signing requests for a set of log keys.
+type Server struct {
+	signers  map[[sha256.Size]byte]crypto.Signer
+	limiter  *rate.Limiter
+	maxSkew  time.Duration
+	auditLog *slog.Logger
+	now      func() time.Time
+}
+
+// NewServer returns a Server which signs with the given keys.
+//
+// Requests are routed to a key using the log ID they carry.
+func NewServer(signers []crypto.Signer, opts ServerOpts) (*Server, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("at least one signer is required")
+	}
+	s := &Server{
+		signers:  make(map[[sha256.Size]byte]crypto.Signer, len(signers)),
+		limiter:  rate.NewLimiter(rate.Inf, 0),
+		maxSkew:  opts.MaxClockSkew,
+		auditLog: opts.AuditLog,
+		now:      opts.Now,
+	}
+	for _, sig := range signers {
+		if err := signer.CheckLogKey(sig.Public()); err != nil {
+			return nil, err
+		}
+		id, err := LogID(sig.Public())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.signers[id]; ok {
+			return nil, fmt.Errorf("duplicate key for log ID %x", id)
+		}
+		s.signers[id] = sig
+	}
+	if opts.RateLimit > 0 {
+		s.limiter = rate.NewLimiter(rate.Limit(opts.RateLimit), max(opts.RateBurst, 1))
+	}
+	if s.auditLog == nil {
+		s.auditLog = slog.Default()
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != SignPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	audit := s.auditLog.With(slog.String("client", clientName(r)))
+
+	var req SignRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		audit.WarnContext(ctx, "Refused to sign: malformed request", slog.Any("error", err))
+		http.Error(w, "malformed request", http.StatusBadRequest)
+		return
+	}
+	var id [sha256.Size]byte
+	if len(req.LogID) != len(id) {
+		audit.WarnContext(ctx, "Refused to sign: invalid log ID", slog.String("log_id", hex.EncodeToString(req.LogID)))
+		http.Error(w, "invalid log ID", http.StatusBadRequest)
+		return
+	}
+	copy(id[:], req.LogID)
+	audit = audit.With(slog.String("log_id", hex.EncodeToString(id[:])))
+	k, ok := s.signers[id]
+	if !ok {
+		audit.WarnContext(ctx, "Refused to sign: unknown log ID")
+		http.Error(w, "unknown log ID", http.StatusNotFound)
+		return
+	}
+
+	attrs, err := s.validate(req.Message)
+	digest := sha256.Sum256(req.Message)
+	audit = audit.With(slog.String("message_sha256", hex.EncodeToString(digest[:])))
+	if err != nil {
+		audit.WarnContext(ctx, "Refused to sign: invalid message", slog.Any("error", err))
+		http.Error(w, fmt.Sprintf("invalid message: %v", err), http.StatusBadRequest)
+		return
+	}
+	audit = audit.With(attrs...)
+
+	if !s.limiter.Allow() {
+		audit.WarnContext(ctx, "Refused to sign: rate limited")
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+		return
+	}
+
+	sig, err := k.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		audit.ErrorContext(ctx, "Failed to sign", slog.Any("error", err))
+		http.Error(w, "failed to sign", http.StatusInternalServerError)
+		return
+	}
+	audit.InfoContext(ctx, "Signed")
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(SignResponse{Signature: sig}); err != nil {
+		slog.WarnContext(ctx, "Failed to write sign response", slog.Any("error", err))
+	}
+}
+
+// validate checks that msg is a well-formed CertificateTimestamp or
+// TreeHeadSignature, and returns attributes describing it for the audit log.
+func (s *Server) validate(msg []byte) ([]any, error) {
+	if len(msg) < 2 {
+		return nil, errors.New("message too short")
+	}
+	if v := rfc6962.Version(msg[0]); v != rfc6962.V1 {
+		return nil, fmt.Errorf("unsupported version %v", v)
+	}
+	switch st := rfc6962.SignatureType(msg[1]); st {
+	case rfc6962.CertificateTimestampSignatureType:
+		var ct rfc6962.CertificateTimestamp
+		if rest, err := tls.Unmarshal(msg, &ct); err != nil {
+			return nil, fmt.Errorf("failed to parse CertificateTimestamp: %v", err)
+		} else if len(rest) > 0 {
+			return nil, errors.New("trailing data after CertificateTimestamp")
+		}
+		switch ct.EntryType {
+		case rfc6962.X509LogEntryType, rfc6962.PrecertLogEntryType:
+		default:
+			return nil, fmt.Errorf("unsupported entry type %v", ct.EntryType)
+		}
+		idx, err := staticct.ParseCTExtensionsBytes(ct.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extensions: %v", err)
+		}
+		if err := s.checkTimestamp(ct.Timestamp); err != nil {
+			return nil, err
+		}
+		return []any{
+			slog.String("type", st.String()),
+			slog.String("entry_type", ct.EntryType.String()),
+			slog.Uint64("timestamp", ct.Timestamp),
+			slog.Uint64("leaf_index", idx),
+		}, nil
+	case rfc6962.TreeHashSignatureType:
+		var th rfc6962.TreeHeadSignature
+		if rest, err := tls.Unmarshal(msg, &th); err != nil {
+			return nil, fmt.Errorf("failed to parse TreeHeadSignature: %v", err)
+		} else if len(rest) > 0 {
+			return nil, errors.New("trailing data after TreeHeadSignature")
+		}
+		if err := s.checkTimestamp(th.Timestamp); err != nil {
+			return nil, err
+		}
+		return []any{
+			slog.String("type", st.String()),
+			slog.Uint64("timestamp", th.Timestamp),
+			slog.Uint64("tree_size", th.TreeSize),
+			slog.String("root_hash", hex.EncodeToString(th.SHA256RootHash[:])),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature type %v", st)
+	}
+}
+
+func (s *Server) checkTimestamp(ms uint64) error {
+	if s.maxSkew == 0 {
+		return nil
+	}
+	t := time.UnixMilli(int64(ms))
+	now := s.now()
+	if t.Before(now.Add(-s.maxSkew)) || t.After(now.Add(s.maxSkew)) {
+		return fmt.Errorf("timestamp %s is more than %s away from now (%s)", t.UTC(), s.maxSkew, now.UTC())
+	}
+	return nil
+}
+
+// clientName returns the subject of the client's TLS certificate, if any.
+func clientName(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return r.RemoteAddr
+	}
+	return r.TLS.PeerCertificates[0].Subject.String()
+}
+
+// verify checks that sig is a valid signature over the SHA-256 digest of msg.
+func verify(pubKey crypto.PublicKey, msg, sig []byte) bool {
+	digest := sha256.Sum256(msg)
+	return signer.VerifySHA256(pubKey, digest[:], sig)
+}
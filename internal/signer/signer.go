@@ -0,0 +1,164 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signer contains helpers to load and use TesseraCT log signing keys.
+//
+// RFC 6962 logs sign with SHA-256, and either ECDSA or RSA keys.
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MinRSAKeyBits is the smallest RSA modulus accepted for log keys.
+const MinRSAKeyBits = 2048
+
+// CheckLogKey returns an error if pub can't be used as a log key.
+func CheckLogKey(pub crypto.PublicKey) error {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		return nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < MinRSAKeyBits {
+			return fmt.Errorf("RSA key too small: %d bits, want at least %d", k.N.BitLen(), MinRSAKeyBits)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type: %T", pub)
+	}
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded ECDSA or RSA private key.
+//
+// SEC 1 ("EC PRIVATE KEY"), PKCS #1 ("RSA PRIVATE KEY") and PKCS #8
+// ("PRIVATE KEY") encodings are supported.
+func ParsePrivateKeyPEM(block *pem.Block) (crypto.Signer, error) {
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	var k any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		k, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		k, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		k, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	s, ok := k.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", k)
+	}
+	if err := CheckLogKey(s.Public()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParsePublicKeyPEM parses a PEM encoded PKIX ECDSA or RSA public key.
+func ParsePublicKeyPEM(block *pem.Block) (crypto.PublicKey, error) {
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM type: %s", block.Type)
+	}
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+	if err := CheckLogKey(k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// SHA256Signer implements crypto.Signer for ECDSA and RSA log keys.
+// Only crypto.SHA256 is supported.
+type SHA256Signer struct {
+	publicKey  crypto.PublicKey
+	privateKey crypto.Signer
+}
+
+// NewSHA256Signer returns a SHA256Signer, after checking that the two keys
+// form a pair.
+func NewSHA256Signer(publicKey crypto.PublicKey, privateKey crypto.Signer) (*SHA256Signer, error) {
+	if err := CheckLogKey(publicKey); err != nil {
+		return nil, err
+	}
+	pk, ok := privateKey.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pk.Equal(publicKey) {
+		return nil, errors.New("signer key pair doesn't match")
+	}
+	return &SHA256Signer{
+		publicKey:  publicKey,
+		privateKey: privateKey,
+	}, nil
+}
+
+// Public returns the public key stored in the Signer object.
+func (s *SHA256Signer) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs digest with the private key.
+// ECDSA signatures are ASN.1 encoded, RSA signatures use PKCS #1 v1.5.
+func (s *SHA256Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	// Verify hash function and digest bytes length.
+	if opts == nil {
+		return nil, errors.New("opts cannot be nil")
+	}
+	if opts.HashFunc() != crypto.SHA256 {
+		return nil, fmt.Errorf("unsupported hash func: %v", opts.HashFunc())
+	}
+	if len(digest) != opts.HashFunc().Size() {
+		return nil, fmt.Errorf("digest bytes length %d does not match hash function bytes length %d", len(digest), opts.HashFunc().Size())
+	}
+
+	switch k := s.privateKey.(type) {
+	case *ecdsa.PrivateKey:
+		return ecdsa.SignASN1(rand, k, digest)
+	case *rsa.PrivateKey:
+		return rsa.SignPKCS1v15(rand, k, crypto.SHA256, digest)
+	default:
+		// E.g. hardware backed keys, which sign with the right scheme for their type.
+		return s.privateKey.Sign(rand, digest, crypto.SHA256)
+	}
+}
+
+// VerifySHA256 checks that sig is a valid signature over digest, which must
+// be a SHA-256 digest, for the given ECDSA or RSA public key.
+func VerifySHA256(pub crypto.PublicKey, digest, sig []byte) bool {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest, sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest, sig) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, MinRSAKeyBits)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+
+	for _, test := range []struct {
+		desc string
+		key  crypto.Signer
+	}{
+		{desc: "ECDSA", key: ecdsaKey},
+		{desc: "RSA", key: rsaKey},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			s, err := NewSHA256Signer(test.key.Public(), test.key)
+			if err != nil {
+				t.Fatalf("NewSHA256Signer(): %v", err)
+			}
+			digest := sha256.Sum256([]byte("tree head"))
+			sig, err := s.Sign(rand.Reader, digest[:], crypto.SHA256)
+			if err != nil {
+				t.Fatalf("Sign(): %v", err)
+			}
+			if !VerifySHA256(s.Public(), digest[:], sig) {
+				t.Error("VerifySHA256() = false, want true")
+			}
+			other := sha256.Sum256([]byte("other tree head"))
+			if VerifySHA256(s.Public(), other[:], sig) {
+				t.Error("VerifySHA256() with another digest = true, want false")
+			}
+			if VerifySHA256(otherKey.Public(), digest[:], sig) {
+				t.Error("VerifySHA256() with another key = true, want false")
+			}
+			if _, err := s.Sign(rand.Reader, digest[:], crypto.SHA512); err == nil {
+				t.Error("Sign() with SHA-512 succeeded, want error")
+			}
+			if _, err := s.Sign(rand.Reader, digest[:16], crypto.SHA256); err == nil {
+				t.Error("Sign() with a short digest succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestNewSHA256Signer(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+
+	for _, test := range []struct {
+		desc    string
+		pub     crypto.PublicKey
+		priv    crypto.Signer
+		wantErr bool
+	}{
+		{
+			desc: "ok",
+			pub:  ecdsaKey.Public(),
+			priv: ecdsaKey,
+		},
+		{
+			desc:    "mismatched keys",
+			pub:     otherKey.Public(),
+			priv:    ecdsaKey,
+			wantErr: true,
+		},
+		{
+			desc:    "small RSA key",
+			pub:     smallRSAKey.Public(),
+			priv:    smallRSAKey,
+			wantErr: true,
+		},
+		{
+			desc:    "unsupported key type",
+			pub:     ed25519Key.Public(),
+			priv:    ed25519Key,
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewSHA256Signer(test.pub, test.priv)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("NewSHA256Signer() = %v, want error: %t", err, test.wantErr)
+			}
+		})
+	}
+
+	digest := sha256.Sum256([]byte("tree head"))
+	if VerifySHA256(ed25519Key.Public(), digest[:], []byte("sig")) {
+		t.Error("VerifySHA256() with an Ed25519 key = true, want false")
+	}
+}
+
+func TestParsePEM(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, MinRSAKeyBits)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey(): %v", err)
+	}
+	pkcs8 := func(k any) []byte {
+		t.Helper()
+		der, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			t.Fatalf("MarshalPKCS8PrivateKey(): %v", err)
+		}
+		return der
+	}
+	pkix := func(k any) []byte {
+		t.Helper()
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			t.Fatalf("MarshalPKIXPublicKey(): %v", err)
+		}
+		return der
+	}
+
+	for _, test := range []struct {
+		desc    string
+		priv    *pem.Block
+		pub     *pem.Block
+		wantErr bool
+	}{
+		{
+			desc: "ECDSA SEC 1",
+			priv: &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER},
+			pub:  &pem.Block{Type: "PUBLIC KEY", Bytes: pkix(ecdsaKey.Public())},
+		},
+		{
+			desc: "ECDSA PKCS #8",
+			priv: &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8(ecdsaKey)},
+			pub:  &pem.Block{Type: "PUBLIC KEY", Bytes: pkix(ecdsaKey.Public())},
+		},
+		{
+			desc: "RSA PKCS #1",
+			priv: &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+			pub:  &pem.Block{Type: "PUBLIC KEY", Bytes: pkix(rsaKey.Public())},
+		},
+		{
+			desc: "RSA PKCS #8",
+			priv: &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8(rsaKey)},
+			pub:  &pem.Block{Type: "PUBLIC KEY", Bytes: pkix(rsaKey.Public())},
+		},
+		{
+			desc:    "Ed25519",
+			priv:    &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8(ed25519Key)},
+			pub:     &pem.Block{Type: "PUBLIC KEY", Bytes: pkix(ed25519Key.Public())},
+			wantErr: true,
+		},
+		{
+			desc:    "wrong PEM type",
+			priv:    &pem.Block{Type: "CERTIFICATE", Bytes: ecDER},
+			pub:     &pem.Block{Type: "CERTIFICATE", Bytes: pkix(ecdsaKey.Public())},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			priv, err := ParsePrivateKeyPEM(test.priv)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("ParsePrivateKeyPEM() = %v, want error: %t", err, test.wantErr)
+			}
+			pub, err := ParsePublicKeyPEM(test.pub)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("ParsePublicKeyPEM() = %v, want error: %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if _, err := NewSHA256Signer(pub, priv); err != nil {
+				t.Errorf("NewSHA256Signer(): %v", err)
+			}
+		})
+	}
+}
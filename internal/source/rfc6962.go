@@ -0,0 +1,252 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+)
+
+// RFC6962Fetcher reads the entries of an RFC 6962 log through its get-sth and
+// get-entries endpoints, and serves them as static-ct entry bundles.
+//
+// Entries are converted from their MerkleTreeLeaf and extra_data, and the
+// issuers in their extra_data are served by ReadIssuer once their entry has
+// been read. Entries keep the extensions of their RFC 6962 leaf, usually
+// none, rather than get the leaf_index extension which ctonly.Entry would
+// add: their leaf hashes, and so the tree, are then the same as the log's.
+//
+// RFC 6962 logs have neither checkpoints nor tiles, use STH to get the tree
+// to read instead.
+type RFC6962Fetcher struct {
+	c         *http.Client
+	rootURL   *url.URL
+	backOff   []backoff.RetryOption
+	userAgent string
+
+	// issuers maps the fingerprints of the issuers of the entries read so
+	// far to their DER encoding.
+	issuers sync.Map
+}
+
+// NewRFC6962Fetcher creates a new RFC6962Fetcher for the log at rootURL,
+// e.g. https://ct.example.com/logs/2019/, using the provided HTTP client.
+//
+// c may be nil, in which case http.DefaultClient will be used.
+func NewRFC6962Fetcher(rootURL *url.URL, c *http.Client) (*RFC6962Fetcher, error) {
+	if rootURL.Scheme != "http" && rootURL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q in RFC 6962 log URL %q", rootURL.Scheme, rootURL.String())
+	}
+	if !strings.HasSuffix(rootURL.Path, "/") {
+		rootURL.Path += "/"
+	}
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &RFC6962Fetcher{
+		c:         c,
+		rootURL:   rootURL,
+		backOff:   []backoff.RetryOption{backoff.WithBackOff(backoff.NewExponentialBackOff()), backoff.WithMaxTries(10)},
+		userAgent: "TesseraCT client",
+	}, nil
+}
+
+// SetUserAgent sets the user agent to use when sending requests.
+func (f *RFC6962Fetcher) SetUserAgent(ua string) {
+	f.userAgent = ua
+}
+
+// STH returns the log's latest signed tree head.
+//
+// Its signature isn't verified, see staticct.VerifySTH.
+func (f *RFC6962Fetcher) STH(ctx context.Context) (*rfc6962.GetSTHResponse, error) {
+	sth := &rfc6962.GetSTHResponse{}
+	if err := f.get(ctx, rfc6962.GetSTHStr, nil, sth); err != nil {
+		return nil, err
+	}
+	if len(sth.SHA256RootHash) != sha256.Size {
+		return nil, fmt.Errorf("invalid STH root hash size %d", len(sth.SHA256RootHash))
+	}
+	return sth, nil
+}
+
+func (f *RFC6962Fetcher) ReadCheckpoint(_ context.Context) ([]byte, error) {
+	return nil, errors.New("RFC 6962 logs don't have checkpoints, use STH instead")
+}
+
+func (f *RFC6962Fetcher) ReadTile(_ context.Context, _, _ uint64, _ uint8) ([]byte, error) {
+	return nil, errors.New("RFC 6962 logs don't have tiles")
+}
+
+// ReadEntryBundle returns the entry bundle at index i, with partial size p,
+// built from the entries returned by get-entries.
+func (f *RFC6962Fetcher) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	n := uint64(p)
+	if n == 0 {
+		n = layout.EntryBundleWidth
+	}
+	start := i * layout.EntryBundleWidth
+	var bundle []byte
+	// Logs may return fewer entries than requested.
+	for got := uint64(0); got < n; {
+		resp := &rfc6962.GetEntriesResponse{}
+		params := url.Values{
+			"start": {strconv.FormatUint(start+got, 10)},
+			"end":   {strconv.FormatUint(start+n-1, 10)},
+		}
+		if err := f.get(ctx, rfc6962.GetEntriesStr, params, resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Entries) == 0 {
+			return nil, fmt.Errorf("get-entries returned no entries from index %d", start+got)
+		}
+		for _, le := range resp.Entries[:min(uint64(len(resp.Entries)), n-got)] {
+			e, err := f.entry(le)
+			if err != nil {
+				return nil, fmt.Errorf("invalid entry %d: %v", start+got, err)
+			}
+			bundle = append(bundle, e...)
+			got++
+		}
+	}
+	return bundle, nil
+}
+
+// ReadIssuer returns the issuer with the given fingerprint, if it's in the
+// extra_data of an entry read so far.
+func (f *RFC6962Fetcher) ReadIssuer(_ context.Context, hash []byte) ([]byte, error) {
+	if len(hash) != sha256.Size {
+		return nil, fmt.Errorf("invalid issuer fingerprint size %d", len(hash))
+	}
+	der, ok := f.issuers.Load([sha256.Size]byte(hash))
+	if !ok {
+		return nil, fmt.Errorf("issuer %x: %w", hash, os.ErrNotExist)
+	}
+	return der.([]byte), nil
+}
+
+// entry returns the static-ct encoding of le.
+func (f *RFC6962Fetcher) entry(le rfc6962.LeafEntry) ([]byte, error) {
+	var leaf rfc6962.MerkleTreeLeaf
+	if rest, err := tls.Unmarshal(le.LeafInput, &leaf); err != nil {
+		return nil, fmt.Errorf("can't parse leaf: %v", err)
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after leaf: %d bytes", len(rest))
+	}
+	if leaf.Version != rfc6962.V1 || leaf.LeafType != rfc6962.TimestampedEntryLeafType {
+		return nil, fmt.Errorf("unsupported leaf version %v or type %v", leaf.Version, leaf.LeafType)
+	}
+	te := leaf.TimestampedEntry
+	e := staticct.Entry{
+		Timestamp:     te.Timestamp,
+		RawExtensions: string(te.Extensions),
+	}
+	var chain []rfc6962.ASN1Cert
+	switch te.EntryType {
+	case rfc6962.X509LogEntryType:
+		var cc rfc6962.CertificateChain
+		if rest, err := tls.Unmarshal(le.ExtraData, &cc); err != nil {
+			return nil, fmt.Errorf("can't parse certificate chain: %v", err)
+		} else if len(rest) > 0 {
+			return nil, fmt.Errorf("trailing data after certificate chain: %d bytes", len(rest))
+		}
+		e.Certificate = te.X509Entry.Data
+		chain = cc.Entries
+	case rfc6962.PrecertLogEntryType:
+		var pc rfc6962.PrecertChainEntry
+		if rest, err := tls.Unmarshal(le.ExtraData, &pc); err != nil {
+			return nil, fmt.Errorf("can't parse precertificate chain: %v", err)
+		} else if len(rest) > 0 {
+			return nil, fmt.Errorf("trailing data after precertificate chain: %d bytes", len(rest))
+		}
+		e.IsPrecert = true
+		e.Certificate = te.PrecertEntry.TBSCertificate
+		e.IssuerKeyHash = te.PrecertEntry.IssuerKeyHash[:]
+		e.Precertificate = pc.PreCertificate.Data
+		chain = pc.CertificateChain
+	default:
+		return nil, fmt.Errorf("unsupported entry type %v", te.EntryType)
+	}
+	for _, c := range chain {
+		fp := sha256.Sum256(c.Data)
+		f.issuers.LoadOrStore(fp, c.Data)
+		e.FingerprintsChain = append(e.FingerprintsChain, fp)
+	}
+	return e.MarshalText()
+}
+
+// get calls the endpoint ep of the log with params, and decodes its JSON
+// response into out.
+func (f *RFC6962Fetcher) get(ctx context.Context, ep rfc6962.APIEndpoint, params url.Values, out any) error {
+	u := f.rootURL.JoinPath("ct/v1", string(ep))
+	u.RawQuery = params.Encode()
+	body, err := backoff.Retry(ctx, func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, backoff.Permanent(fmt.Errorf("NewRequestWithContext(%q): %v", u.String(), err))
+		}
+		req.Header.Add("User-Agent", f.userAgent)
+		r, err := f.c.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("get(%q): %v", u.String(), err)
+		}
+		defer func() {
+			// Drain all bytes left in the body and close it to allow socket reuse
+			_, _ = io.Copy(io.Discard, r.Body)
+			if err := r.Body.Close(); err != nil {
+				slog.ErrorContext(ctx, "resp.Body.Close()", slog.Any("error", err))
+			}
+		}()
+		switch {
+		case r.StatusCode == http.StatusOK:
+			return io.ReadAll(r.Body)
+		case r.StatusCode == http.StatusTooManyRequests:
+			seconds, err := strconv.ParseInt(r.Header.Get("Retry-After"), 10, 32)
+			if err != nil {
+				// The server didn't say how long to wait, so we'll wait an arbitrary amount of time.
+				seconds = 10
+			}
+			return nil, backoff.RetryAfter(int(seconds))
+		case r.StatusCode >= 400 && r.StatusCode < 500:
+			return nil, backoff.Permanent(fmt.Errorf("get(%q): %v", u.String(), r.StatusCode))
+		default:
+			return nil, fmt.Errorf("get(%q): %v", u.String(), r.StatusCode)
+		}
+	}, f.backOff...)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %v", ep, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+)
+
+func TestRFC6962Fetcher(t *testing.T) {
+	issuer, root := []byte("issuer"), []byte("root")
+	ikh := sha256.Sum256([]byte("issuer key"))
+	leaves := []rfc6962.TimestampedEntry{
+		{Timestamp: 1, EntryType: rfc6962.X509LogEntryType, X509Entry: &rfc6962.ASN1Cert{Data: []byte("cert0")}},
+		{Timestamp: 2, EntryType: rfc6962.PrecertLogEntryType, PrecertEntry: &rfc6962.PreCert{IssuerKeyHash: ikh, TBSCertificate: []byte("tbs1")}},
+		{Timestamp: 3, EntryType: rfc6962.X509LogEntryType, X509Entry: &rfc6962.ASN1Cert{Data: []byte("cert2")}},
+	}
+	var entries []rfc6962.LeafEntry
+	for _, te := range leaves {
+		leaf, err := tls.Marshal(rfc6962.MerkleTreeLeaf{Version: rfc6962.V1, LeafType: rfc6962.TimestampedEntryLeafType, TimestampedEntry: &te})
+		if err != nil {
+			t.Fatalf("tls.Marshal(): %v", err)
+		}
+		chain := []rfc6962.ASN1Cert{{Data: issuer}, {Data: root}}
+		var extra any = rfc6962.CertificateChain{Entries: chain}
+		if te.EntryType == rfc6962.PrecertLogEntryType {
+			extra = rfc6962.PrecertChainEntry{PreCertificate: rfc6962.ASN1Cert{Data: []byte("precert1")}, CertificateChain: chain}
+		}
+		extraData, err := tls.Marshal(extra)
+		if err != nil {
+			t.Fatalf("tls.Marshal(): %v", err)
+		}
+		entries = append(entries, rfc6962.LeafEntry{LeafInput: leaf, ExtraData: extraData})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /logs/test/ct/v1/get-sth", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(rfc6962.GetSTHResponse{TreeSize: uint64(len(entries)), SHA256RootHash: make([]byte, 32)})
+	})
+	mux.HandleFunc("GET /logs/test/ct/v1/get-entries", func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		end, _ := strconv.Atoi(r.URL.Query().Get("end"))
+		if start > end || end >= len(entries) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Return at most 2 entries, like logs capping get-entries responses.
+		_ = json.NewEncoder(w).Encode(rfc6962.GetEntriesResponse{Entries: entries[start:min(end+1, start+2)]})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/logs/test")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	f, err := NewRFC6962Fetcher(u, srv.Client())
+	if err != nil {
+		t.Fatalf("NewRFC6962Fetcher(): %v", err)
+	}
+	sth, err := f.STH(t.Context())
+	if err != nil {
+		t.Fatalf("STH(): %v", err)
+	}
+	if sth.TreeSize != 3 {
+		t.Errorf("STH() tree size = %d, want 3", sth.TreeSize)
+	}
+	if _, err := f.ReadIssuer(t.Context(), make([]byte, 32)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadIssuer() of an unknown issuer = %v, want os.ErrNotExist", err)
+	}
+
+	raw, err := f.ReadEntryBundle(t.Context(), 0, 3)
+	if err != nil {
+		t.Fatalf("ReadEntryBundle(): %v", err)
+	}
+	eb := staticct.EntryBundle{}
+	if err := eb.UnmarshalText(raw); err != nil {
+		t.Fatalf("UnmarshalText(): %v", err)
+	}
+	if len(eb.Entries) != len(entries) {
+		t.Fatalf("got %d entries, want %d", len(eb.Entries), len(entries))
+	}
+	for i, raw := range eb.Entries {
+		e := staticct.Entry{}
+		if err := e.UnmarshalText(raw); err != nil {
+			t.Fatalf("UnmarshalText(%d): %v", i, err)
+		}
+		// The leaf rebuilt from the entry must be the log's, so that the
+		// migrated tree has the same root hash.
+		te := rfc6962.TimestampedEntry{Timestamp: e.Timestamp, Extensions: rfc6962.CTExtensions(e.RawExtensions)}
+		if e.IsPrecert {
+			te.EntryType = rfc6962.PrecertLogEntryType
+			te.PrecertEntry = &rfc6962.PreCert{IssuerKeyHash: [32]byte(e.IssuerKeyHash), TBSCertificate: e.Certificate}
+			if string(e.Precertificate) != "precert1" {
+				t.Errorf("entry %d: got precertificate %q, want %q", i, e.Precertificate, "precert1")
+			}
+		} else {
+			te.X509Entry = &rfc6962.ASN1Cert{Data: e.Certificate}
+		}
+		leaf, err := tls.Marshal(rfc6962.MerkleTreeLeaf{Version: rfc6962.V1, LeafType: rfc6962.TimestampedEntryLeafType, TimestampedEntry: &te})
+		if err != nil {
+			t.Fatalf("tls.Marshal(): %v", err)
+		}
+		if !bytes.Equal(leaf, entries[i].LeafInput) {
+			t.Errorf("entry %d: rebuilt leaf %x, want %x", i, leaf, entries[i].LeafInput)
+		}
+		if len(e.FingerprintsChain) != 2 || e.FingerprintsChain[0] != sha256.Sum256(issuer) || e.FingerprintsChain[1] != sha256.Sum256(root) {
+			t.Errorf("entry %d: got fingerprints %x, want those of issuer and root", i, e.FingerprintsChain)
+		}
+	}
+	fp := sha256.Sum256(issuer)
+	if got, err := f.ReadIssuer(t.Context(), fp[:]); err != nil || !bytes.Equal(got, issuer) {
+		t.Errorf("ReadIssuer() = %q, %v, want %q", got, err, issuer)
+	}
+
+	if _, err := f.ReadEntryBundle(t.Context(), 0, 4); err == nil {
+		t.Error("ReadEntryBundle() beyond the tree size succeeded")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package source creates fetchers reading static-ct logs either through
+// their monitoring URL, or directly from their storage, as well as fetchers
+// reading RFC 6962 logs as if they were static-ct logs.
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/client/aws"
+	"github.com/transparency-dev/tesseract/client/gcp"
+)
+
+// Fetcher reads the resources of a static-ct log.
+type Fetcher interface {
+	ReadCheckpoint(ctx context.Context) ([]byte, error)
+	ReadTile(ctx context.Context, l, i uint64, p uint8) ([]byte, error)
+	ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error)
+	ReadIssuer(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// New returns a Fetcher for the log at u, which can be:
+//   - an http:// or https:// monitoring URL, read with a *client.HTTPFetcher using hc,
+//   - a file:// URL of the log's root directory, read with a *client.FileFetcher,
+//   - a gs://bucket URL, read with a *gcp.GSFetcher using the default GCS client,
+//   - an s3://bucket URL, read with an *aws.S3Fetcher using the default AWS configuration.
+//
+// Reading logs directly from their storage avoids CDN egress, and surfaces
+// storage-level problems which a CDN might hide.
+//
+// Callers can type-assert the returned Fetcher to configure it further, e.g.
+// to enable retries on a *client.HTTPFetcher.
+func New(ctx context.Context, u *url.URL, hc *http.Client) (Fetcher, error) {
+	switch u.Scheme {
+	case "http", "https":
+		return client.NewHTTPFetcher(u, hc)
+	case "file":
+		return &client.FileFetcher{Root: u.Path}, nil
+	case "gs":
+		if err := checkBucketURL(u); err != nil {
+			return nil, err
+		}
+		return gcp.NewGSFetcher(ctx, u.Host, nil)
+	case "s3":
+		if err := checkBucketURL(u); err != nil {
+			return nil, err
+		}
+		return aws.NewS3Fetcher(ctx, u.Host, nil)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q in log URL %q", u.Scheme, u.String())
+	}
+}
+
+// checkBucketURL checks that u points at the root of a bucket, since logs
+// can't be read from a prefix of a bucket.
+func checkBucketURL(u *url.URL) error {
+	if u.Host == "" {
+		return fmt.Errorf("missing bucket name in log URL %q", u.String())
+	}
+	if u.Path != "" && u.Path != "/" {
+		return fmt.Errorf("log URL %q must point at the root of a bucket", u.String())
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+func TestNew(t *testing.T) {
+	cp := []byte("example.com/log\n1\nAAAA\n")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, layout.CheckpointPath), cp, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+
+	for _, test := range []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "http",
+			url:  srv.URL,
+		},
+		{
+			name: "file",
+			url:  "file://" + dir,
+		},
+		{
+			name:    "gs with prefix",
+			url:     "gs://bucket/prefix",
+			wantErr: true,
+		},
+		{
+			name:    "s3 without bucket",
+			url:     "s3:///",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			url:     "ftp://example.com/log/",
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			u, err := url.Parse(test.url)
+			if err != nil {
+				t.Fatalf("url.Parse: %v", err)
+			}
+			f, err := New(t.Context(), u, srv.Client())
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("New() = %v, want err: %t", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := f.ReadCheckpoint(t.Context())
+			if err != nil {
+				t.Fatalf("ReadCheckpoint: %v", err)
+			}
+			if string(got) != string(cp) {
+				t.Errorf("ReadCheckpoint() = %q, want %q", got, cp)
+			}
+		})
+	}
+}
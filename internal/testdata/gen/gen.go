@@ -21,23 +21,16 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/asn1"
 	"encoding/pem"
 	"flag"
 	"fmt"
 	"log/slog"
-	"math/big"
 	"os"
 	"path"
 	"strings"
 	"time"
-)
 
-const (
-	commonName   = "test.transparency.dev"
-	organization = "TrustFabric Transparency.dev Test"
-	country      = "GB"
+	"github.com/transparency-dev/tesseract/tesseracttest/chains"
 )
 
 var (
@@ -45,18 +38,6 @@ var (
 	notBeforeString = flag.String("not_before", "2024-12-05T18:05:50.000Z", "Start of the range of certs to be generated. RFC3339 format, e.g: 2024-01-02T15:04:05Z.")
 )
 
-var (
-	// From RFC6962 Section 3.1. To identify pre-certs.
-	ctPrecertPoisonOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
-	ctPoison           = pkix.Extension{
-		Id:       ctPrecertPoisonOID,
-		Critical: true,
-		Value:    []byte{0x05, 0x00}, // ASN.1 NULL
-	}
-	// From RFC6962 Section 3.1. For intermediates to issue pre-certs.
-	preIssuerEKUOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 4}
-)
-
 func main() {
 	flag.Parse()
 	notBefore, err := parseTime(*notBeforeString)
@@ -64,26 +45,16 @@ func main() {
 		slog.Error("Failed to parse start time", slog.Any("error", err))
 		os.Exit(1)
 	}
-	// Generate root.
-	// Generate a new EC root CA private key.
-	rootPrivKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	f, err := chains.NewFactory(func() time.Time { return *notBefore })
 	if err != nil {
-		slog.Error("Failed to generate root CA private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveECDSAPrivateKeyPEM(rootPrivKey, path.Join(*outputPath, "test_root_ca_private_key.pem")); err != nil {
-		slog.Error("Failed to save root CA private key", slog.Any("error", err))
+		slog.Error("Failed to create certificate factory", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	// Generate a new root CA certificate.
-	rootCert, err := rootCACert(rootPrivKey, *notBefore)
+	// Generate root.
+	root, err := genIssuer(f, nil, "root", *notBefore)
 	if err != nil {
-		slog.Error("Failed to generate root CA certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(rootCert, path.Join(*outputPath, "test_root_ca_cert.pem")); err != nil {
-		slog.Error("Failed to save root CA certificate", slog.Any("error", err))
+		slog.Error("Failed to generate root CA", slog.Any("error", err))
 		os.Exit(1)
 	}
 
@@ -108,289 +79,87 @@ func main() {
 		os.Exit(1)
 	}
 
-	genLeaves(rootCert, rootPrivKey, *notBefore)
-	genPreIssuerAndLeaves(rootCert, rootPrivKey, *notBefore)
-	genIntermediateAndLeaves(rootCert, rootPrivKey, *notBefore)
-}
-
-// genLeaves generates a cert and a pre-cert.
-func genLeaves(rootCert *x509.Certificate, rootPrivKey *ecdsa.PrivateKey, notBefore time.Time) {
-	// Generate leaf certs chaining to root.
-	// Generate a new ECDSA leaf certificate signing private key.
-	leafCertPrivateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveECDSAPrivateKeyPEM(leafCertPrivateKey, path.Join(*outputPath, "test_leaf_signed_by_root_signing_private_key.pem")); err != nil {
-		slog.Error("Failed to save leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	chainGenerator := newChainGenerator(rootCert, rootPrivKey, leafCertPrivateKey.Public())
-	leafCert, err := chainGenerator.certificate(100, false, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate", slog.Any("error", err))
+	// Generate a cert and a pre-cert from the root, and from a pre-issuer
+	// and an intermediate CA.
+	if err := genLeaves(f, root, "root", *notBefore); err != nil {
+		slog.Error("Failed to generate leaves signed by the root CA", slog.Any("error", err))
 		os.Exit(1)
 	}
-	if err := saveCertificatePEM(leafCert, path.Join(*outputPath, "test_leaf_cert_signed_by_root.pem")); err != nil {
-		slog.Error("Failed to save leaf cert", slog.Any("error", err))
-		os.Exit(1)
-	}
-	leafPreCert, err := chainGenerator.certificate(200, true, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(leafPreCert, path.Join(*outputPath, "test_leaf_pre_cert_signed_by_root.pem")); err != nil {
-		slog.Error("Failed to save leaf cert", slog.Any("error", err))
-		os.Exit(1)
+	for _, name := range []string{"pre_intermediate", "intermediate"} {
+		issuer, err := genIssuer(f, root, name, *notBefore)
+		if err != nil {
+			slog.Error("Failed to generate intermediate CA", slog.String("name", name), slog.Any("error", err))
+			os.Exit(1)
+		}
+		if err := genLeaves(f, issuer, name, *notBefore); err != nil {
+			slog.Error("Failed to generate leaves signed by intermediate CA", slog.String("name", name), slog.Any("error", err))
+			os.Exit(1)
+		}
 	}
-
 }
 
-// genIntermediateAndLeaves generates an intermediate cert, a cert, a pre-cert.
-func genIntermediateAndLeaves(rootCert *x509.Certificate, rootPrivKey *ecdsa.PrivateKey, notBefore time.Time) {
-	// Generate a new ECDSA intermediate CA private key.
-	intermediatePrivKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
-	if err != nil {
-		slog.Error("Failed to generate intermediate CA private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveECDSAPrivateKeyPEM(intermediatePrivKey, path.Join(*outputPath, "test_intermediate_ca_private_key.pem")); err != nil {
-		slog.Error("Failed to save intermediate CA private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	// Generate a new intermediate CA certificate with CT extension.
-	intermediateCert, err := intermediateCACert(rootCert, rootPrivKey, intermediatePrivKey, false, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate intermediate CA certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(intermediateCert, path.Join(*outputPath, "test_intermediate_ca_cert.pem")); err != nil {
-		slog.Error("Failed to save intermediate CA certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	// Generate a new ECDSA leaf certificate signing private key.
-	leafCertPrivateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+// genIssuer generates a CA certificate and its key, and saves them under
+// test_<name>_ca_cert.pem and test_<name>_ca_private_key.pem.
+//
+// The CA is a root valid for 10 years from notBefore if parent is nil, and
+// an intermediate valid for 5 years issued by parent otherwise. The
+// "pre_intermediate" CA is a Precertificate Signing Certificate.
+func genIssuer(f *chains.Factory, parent *chains.Issuer, name string, notBefore time.Time) (*chains.Issuer, error) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
 	if err != nil {
-		slog.Error("Failed to generate leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to generate private key: %v", err)
 	}
-	if err := saveECDSAPrivateKeyPEM(leafCertPrivateKey, path.Join(*outputPath, "test_leaf_signed_by_intermediate_signing_private_key.pem")); err != nil {
-		slog.Error("Failed to save leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
+	if err := saveECDSAPrivateKeyPEM(privKey, path.Join(*outputPath, fmt.Sprintf("test_%s_ca_private_key.pem", name))); err != nil {
+		return nil, fmt.Errorf("failed to save private key: %v", err)
 	}
 
-	chainGenerator := newChainGenerator(intermediateCert, intermediatePrivKey, leafCertPrivateKey.Public())
-	leafCert, err := chainGenerator.certificate(100, false, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(leafCert, path.Join(*outputPath, "test_leaf_cert_signed_by_intermediate.pem")); err != nil {
-		slog.Error("Failed to save leaf cert", slog.Any("error", err))
-		os.Exit(1)
+	var issuer *chains.Issuer
+	switch {
+	case parent == nil:
+		issuer, err = f.Root(chains.WithKey(privKey), chains.WithValidity(notBefore, notBefore.AddDate(10, 0, 0)))
+	case name == "pre_intermediate":
+		issuer, err = f.PreIssuer(parent, chains.WithKey(privKey), chains.WithValidity(notBefore, notBefore.AddDate(5, 0, 0)))
+	default:
+		issuer, err = f.Intermediate(parent, chains.WithKey(privKey), chains.WithValidity(notBefore, notBefore.AddDate(5, 0, 0)))
 	}
-	leafPreCert, err := chainGenerator.certificate(200, true, notBefore)
 	if err != nil {
-		slog.Error("Failed to generate leaf pre-certificate", slog.Any("error", err))
-		os.Exit(1)
+		return nil, err
 	}
-	if err := saveCertificatePEM(leafPreCert, path.Join(*outputPath, "test_leaf_pre_cert_signed_by_intermediate.pem")); err != nil {
-		slog.Error("Failed to save leaf pre-cert", slog.Any("error", err))
-		os.Exit(1)
+	if err := saveCertificatePEM(issuer.Cert, path.Join(*outputPath, fmt.Sprintf("test_%s_ca_cert.pem", name))); err != nil {
+		return nil, fmt.Errorf("failed to save certificate: %v", err)
 	}
+	return issuer, nil
 }
 
-// genPreIssuerAndLeaves generates a pre-issuer intermediate cert, a cert,
-// a pre-cert.
-func genPreIssuerAndLeaves(rootCert *x509.Certificate, rootPrivKey *ecdsa.PrivateKey, notBefore time.Time) {
-	// Generate a new ECDSA intermediate CA private key.
-	preIntermediatePrivKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
-	if err != nil {
-		slog.Error("Failed to generate intermediate CA private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveECDSAPrivateKeyPEM(preIntermediatePrivKey, path.Join(*outputPath, "test_pre_intermediate_ca_private_key.pem")); err != nil {
-		slog.Error("Failed to save intermediate CA private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	// Generate a new intermediate CA certificate with CT extension.
-	preIntermediateCert, err := intermediateCACert(rootCert, rootPrivKey, preIntermediatePrivKey, true, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate intermediate CA certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(preIntermediateCert, path.Join(*outputPath, "test_pre_intermediate_ca_cert.pem")); err != nil {
-		slog.Error("Failed to save intermediate CA certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-
+// genLeaves generates a cert and a pre-cert issued by issuer, valid for a year
+// from notBefore, and saves them under test_leaf_cert_signed_by_<name>.pem and
+// test_leaf_pre_cert_signed_by_<name>.pem.
+func genLeaves(f *chains.Factory, issuer *chains.Issuer, name string, notBefore time.Time) error {
 	// Generate a new ECDSA leaf certificate signing private key.
 	leafCertPrivateKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
 	if err != nil {
-		slog.Error("Failed to generate leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveECDSAPrivateKeyPEM(leafCertPrivateKey, path.Join(*outputPath, "test_leaf_signed_by_pre_intermediate_signing_private_key.pem")); err != nil {
-		slog.Error("Failed to save leaf certificate signing private key", slog.Any("error", err))
-		os.Exit(1)
-	}
-
-	chainGenerator := newChainGenerator(preIntermediateCert, preIntermediatePrivKey, leafCertPrivateKey.Public())
-	leafCert, err := chainGenerator.certificate(100, false, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(leafCert, path.Join(*outputPath, "test_leaf_cert_signed_by_pre_intermediate.pem")); err != nil {
-		slog.Error("Failed to save leaf cert", slog.Any("error", err))
-		os.Exit(1)
-	}
-	leafPreCert, err := chainGenerator.certificate(200, true, notBefore)
-	if err != nil {
-		slog.Error("Failed to generate leaf certificate", slog.Any("error", err))
-		os.Exit(1)
-	}
-	if err := saveCertificatePEM(leafPreCert, path.Join(*outputPath, "test_leaf_pre_cert_signed_by_pre_intermediate.pem")); err != nil {
-		slog.Error("Failed to save leaf cert", slog.Any("error", err))
-		os.Exit(1)
-	}
-}
-
-func rootCACert(privKey *ecdsa.PrivateKey, notBefore time.Time) (*x509.Certificate, error) {
-	template := x509.Certificate{
-		SerialNumber: big.NewInt(1),
-		Subject: pkix.Name{
-			Organization: []string{fmt.Sprintf("%s Root Test CA", organization)},
-			Country:      []string{country},
-			CommonName:   fmt.Sprintf("%s Root Test CA", organization),
-		},
-		NotBefore:             notBefore,
-		NotAfter:              notBefore.AddDate(10, 0, 0),
-		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
-		BasicConstraintsValid: true,
-		IsCA:                  true,
-	}
-
-	// Create the self-signed certificate.
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, privKey.Public(), privKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create root certificate: %v", err)
-	}
-
-	// Parse the DER-encoded certificate.
-	cert, err := x509.ParseCertificate(derBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse root certificate: %v", err)
-	}
-
-	return cert, nil
-}
-
-func intermediateCACert(rootCACert *x509.Certificate, rootPrivKey, privKey *ecdsa.PrivateKey, preIntermediate bool, notBefore time.Time) (*x509.Certificate, error) {
-	template := x509.Certificate{
-		SerialNumber: big.NewInt(2),
-		Subject: pkix.Name{
-			Organization: []string{fmt.Sprintf("%s Intermediate Test CA", organization)},
-			Country:      []string{country},
-			CommonName:   fmt.Sprintf("%s Intermediate Test CA", organization),
-		},
-		NotBefore:             notBefore,
-		NotAfter:              notBefore.AddDate(5, 0, 0),
-		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
-		BasicConstraintsValid: true,
-		IsCA:                  true,
-		MaxPathLen:            0,
+		return fmt.Errorf("failed to generate leaf certificate signing private key: %v", err)
 	}
-
-	if preIntermediate {
-		preIssuerExtension := pkix.Extension{
-			Id: preIssuerEKUOID,
-		}
-		template.ExtraExtensions = append(template.ExtraExtensions, preIssuerExtension)
+	if err := saveECDSAPrivateKeyPEM(leafCertPrivateKey, path.Join(*outputPath, fmt.Sprintf("test_leaf_signed_by_%s_signing_private_key.pem", name))); err != nil {
+		return fmt.Errorf("failed to save leaf certificate signing private key: %v", err)
 	}
 
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, rootCACert, privKey.Public(), rootPrivKey)
+	opts := []chains.Option{chains.WithKey(leafCertPrivateKey), chains.WithValidity(notBefore, notBefore.AddDate(1, 0, 0))}
+	leafChain, err := f.Chain(issuer, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create intermediate certificate: %v", err)
+		return fmt.Errorf("failed to generate leaf certificate: %v", err)
 	}
-
-	cert, err := x509.ParseCertificate(derBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse intermediate certificate: %v", err)
+	if err := saveCertificatePEM(leafChain[0], path.Join(*outputPath, fmt.Sprintf("test_leaf_cert_signed_by_%s.pem", name))); err != nil {
+		return fmt.Errorf("failed to save leaf cert: %v", err)
 	}
-
-	return cert, nil
-}
-
-const (
-	organizationalUnit = "TrustFabric"
-	locality           = "London"
-	state              = "London"
-)
-
-type chainGenerator struct {
-	intermediateCert  *x509.Certificate
-	intermediateKey   any
-	leafCertPublicKey any
-}
-
-// newChainGenerator creates the chainGenerator.
-func newChainGenerator(intermediateCert *x509.Certificate, intermediateKey, leafCertPublicKey any) *chainGenerator {
-	return &chainGenerator{
-		intermediateCert:  intermediateCert,
-		intermediateKey:   intermediateKey,
-		leafCertPublicKey: leafCertPublicKey,
-	}
-}
-
-// certificate generates a deterministic TLS certificate by using integer as the serial number.
-func (g *chainGenerator) certificate(serialNumber int64, preCert bool, notBefore time.Time) (*x509.Certificate, error) {
-	template := x509.Certificate{
-		SerialNumber: big.NewInt(serialNumber),
-		Subject: pkix.Name{
-			CommonName:         commonName,
-			Organization:       []string{organization},
-			OrganizationalUnit: []string{organizationalUnit},
-			Locality:           []string{locality},
-			Province:           []string{state},
-			Country:            []string{country},
-		},
-		NotBefore:             notBefore,
-		NotAfter:              notBefore.AddDate(1, 0, 0),
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-		BasicConstraintsValid: true,
-		DNSNames:              []string{commonName},
-	}
-
-	ctPoison := pkix.Extension{
-		Id:       ctPrecertPoisonOID,
-		Critical: true,
-		Value:    []byte{0x05, 0x00}, // ASN.1 NULL
-	}
-
-	if preCert {
-		template.ExtraExtensions = append(template.ExtraExtensions, ctPoison)
-	}
-
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, g.intermediateCert, g.leafCertPublicKey, g.intermediateKey)
+	preChain, err := f.PrecertChain(issuer, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create leaf certificate: %v", err)
+		return fmt.Errorf("failed to generate leaf pre-certificate: %v", err)
 	}
-
-	// Parse the DER-encoded certificate.
-	cert, err := x509.ParseCertificate(derBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse root certificate: %v", err)
+	if err := saveCertificatePEM(preChain[0], path.Join(*outputPath, fmt.Sprintf("test_leaf_pre_cert_signed_by_%s.pem", name))); err != nil {
+		return fmt.Errorf("failed to save leaf pre-cert: %v", err)
 	}
-
-	return cert, nil
+	return nil
 }
 
 func saveECDSAPrivateKeyPEM(key *ecdsa.PrivateKey, filename string) error {
@@ -131,6 +131,19 @@ type PreCert struct {
 // nolint: revive
 type CTExtensions []byte // tls:"minlen:0,maxlen:65535"`
 
+// CertificateChain is the extra_data of an X.509 log entry: the chain
+// certifying the logged certificate (section 4.6).
+type CertificateChain struct {
+	Entries []ASN1Cert `tls:"minlen:0,maxlen:16777215"`
+}
+
+// PrecertChainEntry is the extra_data of a precertificate log entry: the
+// submitted precertificate, and the chain certifying it (section 4.6).
+type PrecertChainEntry struct {
+	PreCertificate   ASN1Cert   `tls:"minlen:1,maxlen:16777215"`
+	CertificateChain []ASN1Cert `tls:"minlen:0,maxlen:16777215"`
+}
+
 // MerkleTreeNode represents an internal node in the CT tree.
 type MerkleTreeNode []byte
 
@@ -307,6 +320,8 @@ const (
 	AddChainStr    APIEndpoint = "add-chain"
 	AddPreChainStr APIEndpoint = "add-pre-chain"
 	GetRootsStr    APIEndpoint = "get-roots"
+	GetSTHStr      APIEndpoint = "get-sth"
+	GetEntriesStr  APIEndpoint = "get-entries"
 )
 
 // URI paths for Log requests; see section 4.
@@ -316,6 +331,8 @@ const (
 	AddChainPath    = "/ct/v1/add-chain"
 	AddPreChainPath = "/ct/v1/add-pre-chain"
 	GetRootsPath    = "/ct/v1/get-roots"
+	GetSTHPath      = "/ct/v1/get-sth"
+	GetEntriesPath  = "/ct/v1/get-entries"
 )
 
 // AddChainRequest represents the JSON request body sent to the add-chain and
@@ -340,3 +357,26 @@ type AddChainResponse struct {
 type GetRootsResponse struct {
 	Certificates []string `json:"certificates"`
 }
+
+// GetSTHResponse represents the JSON response to the get-sth GET method from section 4.3.
+type GetSTHResponse struct {
+	TreeSize          uint64 `json:"tree_size"`           // Number of certs in the current tree
+	Timestamp         uint64 `json:"timestamp"`           // Time that the tree was created
+	SHA256RootHash    []byte `json:"sha256_root_hash"`    // Root hash of the tree
+	TreeHeadSignature []byte `json:"tree_head_signature"` // Log signature for this STH
+}
+
+// LeafEntry represents a leaf in the Log's Merkle tree, as returned by the
+// get-entries GET method from section 4.6.
+type LeafEntry struct {
+	// LeafInput is a TLS-encoded MerkleTreeLeaf
+	LeafInput []byte `json:"leaf_input"`
+	// ExtraData holds a CertificateChain for X.509 entries, or a
+	// PrecertChainEntry for precertificate entries.
+	ExtraData []byte `json:"extra_data"`
+}
+
+// GetEntriesResponse represents the JSON response to the get-entries GET method from section 4.6.
+type GetEntriesResponse struct {
+	Entries []LeafEntry `json:"entries"` // the list of returned entries
+}
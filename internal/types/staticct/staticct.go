@@ -16,103 +16,46 @@ package staticct
 
 import (
 	"bytes"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
 
-	"github.com/transparency-dev/tessera/api/layout"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 	"github.com/transparency-dev/tesseract/internal/types/tls"
+	tstaticct "github.com/transparency-dev/tesseract/staticct"
 	"golang.org/x/crypto/cryptobyte"
 )
 
 const (
 	IssuersPrefix      = "issuer/"
 	IssuersContentType = "application/pkix-cert"
-)
-
-///////////////////////////////////////////////////////////////////////////////
-// The following structures represent those outlined in Static CT API.
-///////////////////////////////////////////////////////////////////////////////
-
-// EntryBundle represents a sequence of entries in the log.
-// These entries correspond to a leaf tile in the hash tree.
-type EntryBundle struct {
-	// Entries stores the leaf entries of the log, in order.
-	Entries [][]byte
-}
 
-// UnmarshalText implements encoding/TextUnmarshaler and reads EntryBundles
-// which are encoded using the Static CT API spec.
-// TODO(phbnf): we can probably parse every individual leaf directly, since most callers
-// of this method tend to do so.
-func (t *EntryBundle) UnmarshalText(raw []byte) error {
-	entries := make([][]byte, 0, layout.EntryBundleWidth)
-	s := cryptobyte.String(raw)
-
-	for len(s) > 0 {
-		entry := []byte{}
-		var timestamp uint64
-		var entryType uint16
-		var extensions, fingerprints cryptobyte.String
-		if !s.ReadUint64(&timestamp) || !s.ReadUint16(&entryType) || timestamp > math.MaxInt64 {
-			return fmt.Errorf("invalid data tile")
-		}
+	// MetadataPath is the path of the log metadata document, relative to
+	// the log's monitoring prefix and submission prefix.
+	MetadataPath        = "log.v3.json"
+	MetadataContentType = "application/json"
 
-		bb := []byte{}
-		b := cryptobyte.NewBuilder(bb)
-		b.AddUint64(timestamp)
-		b.AddUint16(entryType)
+	// CheckpointsPrefix is the path under which published checkpoints are
+	// archived by size, relative to the log's monitoring prefix.
+	CheckpointsPrefix      = "checkpoints/"
+	CheckpointsContentType = "text/plain; charset=utf-8"
+)
 
-		switch entryType {
-		case 0: // x509_entry
-			if !s.ReadUint24LengthPrefixed((*cryptobyte.String)(&entry)) ||
-				!s.ReadUint16LengthPrefixed(&extensions) ||
-				!s.ReadUint16LengthPrefixed(&fingerprints) {
-				return fmt.Errorf("invalid data tile x509_entry")
-			}
-			b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(entry)
-			})
-			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(extensions)
-			})
-			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(fingerprints)
-			})
+// EntryBundle and Entry are defined in the public staticct package, so that
+// clients of the log can use them.
+type (
+	EntryBundle = tstaticct.EntryBundle
+	Entry       = tstaticct.Entry
+)
 
-		case 1: // precert_entry
-			IssuerKeyHash := [32]byte{}
-			var defangedCrt, extensions cryptobyte.String
-			if !s.CopyBytes(IssuerKeyHash[:]) ||
-				!s.ReadUint24LengthPrefixed(&defangedCrt) ||
-				!s.ReadUint16LengthPrefixed(&extensions) ||
-				!s.ReadUint24LengthPrefixed((*cryptobyte.String)(&entry)) ||
-				!s.ReadUint16LengthPrefixed(&fingerprints) {
-				return fmt.Errorf("invalid data tile precert_entry")
-			}
-			b.AddBytes(IssuerKeyHash[:])
-			b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(defangedCrt)
-			})
-			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(extensions)
-			})
-			b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(entry)
-			})
-			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-				b.AddBytes(fingerprints)
-			})
-		default:
-			return fmt.Errorf("invalid data tile: unknown type %d", entryType)
-		}
-		entries = append(entries, b.BytesOrPanic())
-	}
+// ParseCTExtensionsBytes parses binary CTExtensions into an index.
+func ParseCTExtensionsBytes(ext []byte) (uint64, error) {
+	return tstaticct.ParseCTExtensionsBytes(ext)
+}
 
-	t.Entries = entries
-	return nil
+// ParseCTExtensionsB64 parses base64-encoded CTExtensions into an index.
+func ParseCTExtensionsB64(ext string) (uint64, error) {
+	return tstaticct.ParseCTExtensionsB64(ext)
 }
 
 // NewCertificateTimestamp creates an rfc6962.CertificateTimestamp with the provided RFC6962 CTExtensions byte slice.
@@ -243,160 +186,3 @@ func ExtractSCTInputFromBundle(ebRaw []byte, N uint64) (rfc6962.CertificateTimes
 
 	return rfc6962.CertificateTimestamp{}, fmt.Errorf("requested entry index %d, but found only %d entries", N, i)
 }
-
-// ParseCTExtensionsBytes parses binary CTExtensions into an index.
-func ParseCTExtensionsBytes(ext []byte) (uint64, error) {
-	extensions := cryptobyte.String(ext)
-	var extensionType uint8
-	var extensionData cryptobyte.String
-	var leafIdx uint64
-	if !extensions.ReadUint8(&extensionType) {
-		return 0, fmt.Errorf("can't read extension type")
-	}
-	if extensionType != 0 {
-		return 0, fmt.Errorf("wrong extension type %d, want 0", extensionType)
-	}
-	if !extensions.ReadUint16LengthPrefixed(&extensionData) {
-		return 0, fmt.Errorf("can't read extension data")
-	}
-	if !readUint40(&extensionData, &leafIdx) {
-		return 0, fmt.Errorf("can't read leaf index from extension")
-	}
-	if !extensionData.Empty() ||
-		!extensions.Empty() {
-		return 0, fmt.Errorf("invalid SCT extension data: %x", ext)
-	}
-	return leafIdx, nil
-}
-
-// ParseCTExtensionsB64 parses base64-encoded CTExtensions into an index.
-// Code is inspired by https://github.com/FiloSottile/sunlight/blob/main/tile.go.
-func ParseCTExtensionsB64(ext string) (uint64, error) {
-	extensionBytes, err := base64.StdEncoding.DecodeString(ext)
-	if err != nil {
-		return 0, fmt.Errorf("can't decode extensions: %v", err)
-	}
-	return ParseCTExtensionsBytes(extensionBytes)
-}
-
-// readUint40 decodes a big-endian, 40-bit value into out and advances over it.
-// It reports whether the read was successful.
-// Code is copied from https://github.com/FiloSottile/sunlight/blob/main/extensions.go.
-func readUint40(s *cryptobyte.String, out *uint64) bool {
-	var v []byte
-	if !s.ReadBytes(&v, 5) {
-		return false
-	}
-	*out = uint64(v[0])<<32 | uint64(v[1])<<24 | uint64(v[2])<<16 | uint64(v[3])<<8 | uint64(v[4])
-	return true
-}
-
-// Entry represents a CT log entry.
-type Entry struct {
-	Timestamp uint64
-	IsPrecert bool
-	// Certificate holds different things depending on whether the entry represents a Certificate or a Precertificate submission:
-	//   - IsPrecert == false: the bytes here are the x509 certificate submitted for logging.
-	//   - IsPrecert == true: the bytes here are the TBS certificate extracted from the submitted precert.
-	Certificate []byte
-	// Precertificate holds the precertificate to be logged, only used when IsPrecert is true.
-	Precertificate    []byte
-	IssuerKeyHash     []byte
-	RawFingerprints   string
-	FingerprintsChain [][32]byte
-	RawExtensions     string
-	LeafIndex         uint64
-}
-
-// UnmarshalText implements encoding/TextUnmarshaler and reads EntryBundles
-// which are encoded using the Static CT API spec.
-func (t *Entry) UnmarshalText(raw []byte) error {
-	s := cryptobyte.String(raw)
-
-	entry := []byte{}
-	var entryType uint16
-	var extensions, fingerprints cryptobyte.String
-	if !s.ReadUint64(&t.Timestamp) || !s.ReadUint16(&entryType) || t.Timestamp > math.MaxInt64 {
-		return fmt.Errorf("invalid data tile")
-	}
-
-	bb := []byte{}
-	b := cryptobyte.NewBuilder(bb)
-	b.AddUint64(t.Timestamp)
-	b.AddUint16(entryType)
-
-	switch entryType {
-	case 0: // x509_entry
-		t.IsPrecert = false
-		if !s.ReadUint24LengthPrefixed((*cryptobyte.String)(&entry)) ||
-			!s.ReadUint16LengthPrefixed(&extensions) ||
-			!s.ReadUint16LengthPrefixed(&fingerprints) {
-			return fmt.Errorf("invalid data tile x509_entry")
-		}
-		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(entry)
-			t.Certificate = bytes.Clone(entry)
-		})
-		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(extensions)
-			t.RawExtensions = string(extensions)
-		})
-		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(fingerprints)
-			t.RawFingerprints = string(fingerprints)
-		})
-
-	case 1: // precert_entry
-		t.IsPrecert = true
-		IssuerKeyHash := [32]byte{}
-		var defangedCrt, extensions cryptobyte.String
-		if !s.CopyBytes(IssuerKeyHash[:]) ||
-			!s.ReadUint24LengthPrefixed(&defangedCrt) ||
-			!s.ReadUint16LengthPrefixed(&extensions) ||
-			!s.ReadUint24LengthPrefixed((*cryptobyte.String)(&entry)) ||
-			!s.ReadUint16LengthPrefixed(&fingerprints) {
-			return fmt.Errorf("invalid data tile precert_entry")
-		}
-		b.AddBytes(IssuerKeyHash[:])
-		t.IssuerKeyHash = bytes.Clone(IssuerKeyHash[:])
-		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(defangedCrt)
-			t.Certificate = bytes.Clone(defangedCrt)
-		})
-		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(extensions)
-			t.RawExtensions = string(extensions)
-		})
-		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(entry)
-			t.Precertificate = bytes.Clone(entry)
-		})
-		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
-			b.AddBytes(fingerprints)
-			t.RawFingerprints = string(fingerprints)
-		})
-	default:
-		return fmt.Errorf("invalid data tile: unknown type %d", entryType)
-	}
-
-	var err error
-	t.LeafIndex, err = ParseCTExtensionsBytes([]byte(t.RawExtensions))
-	if err != nil {
-		return fmt.Errorf("can't parse extensions: %v", err)
-	}
-
-	rfp := cryptobyte.String(t.RawFingerprints)
-	for i := 0; len(rfp) > 0; i++ {
-		fp := [32]byte{}
-		if !rfp.CopyBytes(fp[:]) {
-			return fmt.Errorf("can't extract fingerprint number %d", i)
-		}
-		t.FingerprintsChain = append(t.FingerprintsChain, fp)
-	}
-
-	if len(s) > 0 {
-		return fmt.Errorf("trailing %d bytes after entry", len(s))
-	}
-
-	return nil
-}
@@ -0,0 +1,210 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticct
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/transparency-dev/tesseract/internal/signer"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// rfc6962NoteSignatureType is the note signature type for RFC 6962 tree head
+// signatures, see https://c2sp.org/static-ct-api.
+const rfc6962NoteSignatureType = 0x05
+
+// checkpointVerifier is a note.Verifier for https://c2sp.org/static-ct-api
+// checkpoint signatures made with ECDSA or RSA log keys.
+type checkpointVerifier struct {
+	origin  string
+	keyHash uint32
+	pubKey  crypto.PublicKey
+}
+
+// NewCheckpointVerifier returns a note.Verifier for checkpoints signed by the
+// static-ct-api log with the given origin and public key.
+//
+// Unlike github.com/transparency-dev/formats/note, it supports RSA as well as
+// ECDSA log keys.
+func NewCheckpointVerifier(origin string, pubKey crypto.PublicKey) (note.Verifier, error) {
+	if err := signer.CheckLogKey(pubKey); err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	logID := sha256.Sum256(der)
+	h := sha256.New()
+	h.Write([]byte(origin))
+	h.Write([]byte{0x0A}) // newline
+	h.Write([]byte{rfc6962NoteSignatureType})
+	h.Write(logID[:])
+	return &checkpointVerifier{
+		origin:  origin,
+		keyHash: binary.BigEndian.Uint32(h.Sum(nil)),
+		pubKey:  pubKey,
+	}, nil
+}
+
+// Name returns the origin of the log.
+func (v *checkpointVerifier) Name() string {
+	return v.origin
+}
+
+// KeyHash returns the static-ct-api key hash of the log key.
+func (v *checkpointVerifier) KeyHash() uint32 {
+	return v.keyHash
+}
+
+// Verify checks that sig is a valid RFC6962NoteSignature over the checkpoint msg.
+func (v *checkpointVerifier) Verify(msg, sig []byte) bool {
+	var ns struct {
+		Timestamp uint64
+		Signature rfc6962.DigitallySigned
+	}
+	if rest, err := tls.Unmarshal(sig, &ns); err != nil || len(rest) > 0 {
+		return false
+	}
+	if ns.Signature.Algorithm.Hash != tls.SHA256 ||
+		ns.Signature.Algorithm.Signature != tls.SignatureAlgorithmFromPubKey(v.pubKey) {
+		return false
+	}
+	input, err := treeHeadSignatureInput(v.origin, ns.Timestamp, msg)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256(input)
+	return signer.VerifySHA256(v.pubKey, digest[:], ns.Signature.Signature)
+}
+
+// treeHeadSignatureInput rebuilds the TLS-encoded TreeHeadSignature which was
+// signed for the checkpoint body msg.
+func treeHeadSignatureInput(origin string, timestamp uint64, msg []byte) ([]byte, error) {
+	// static-ct-api checkpoints have exactly three lines, and no extensions.
+	lines := strings.Split(string(msg), "\n")
+	if len(lines) != 4 || lines[3] != "" {
+		return nil, errors.New("malformed checkpoint")
+	}
+	if lines[0] != origin {
+		return nil, errors.New("wrong origin")
+	}
+	size, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	root, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return nil, err
+	}
+	th := rfc6962.TreeHeadSignature{
+		Version:       rfc6962.V1,
+		SignatureType: rfc6962.TreeHashSignatureType,
+		Timestamp:     timestamp,
+		TreeSize:      size,
+	}
+	if len(root) != len(th.SHA256RootHash) {
+		return nil, errors.New("invalid root hash size")
+	}
+	copy(th.SHA256RootHash[:], root)
+	return tls.Marshal(th)
+}
+
+// VerifySCT checks that sct is a valid SCT from the log with pubKey, over the
+// entry made of cert and ikh as passed to NewCertificateTimestamp.
+//
+// It returns the leaf index of the entry, from the SCT extensions.
+func VerifySCT(pubKey crypto.PublicKey, sct *rfc6962.AddChainResponse, isPrecert bool, cert []byte, ikh [32]byte) (uint64, error) {
+	if sct.SCTVersion != rfc6962.V1 {
+		return 0, fmt.Errorf("unsupported SCT version %d", sct.SCTVersion)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	if logID := sha256.Sum256(der); !bytes.Equal(sct.ID, logID[:]) {
+		return 0, fmt.Errorf("SCT log ID %x doesn't match log ID %x", sct.ID, logID)
+	}
+	ext, err := base64.StdEncoding.DecodeString(sct.Extensions)
+	if err != nil {
+		return 0, fmt.Errorf("can't decode extensions: %v", err)
+	}
+	idx, err := ParseCTExtensionsBytes(ext)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse extensions: %v", err)
+	}
+	var ds rfc6962.DigitallySigned
+	if rest, err := tls.Unmarshal(sct.Signature, &ds); err != nil {
+		return 0, fmt.Errorf("can't parse SCT signature: %v", err)
+	} else if len(rest) > 0 {
+		return 0, fmt.Errorf("trailing data after SCT signature: %d bytes", len(rest))
+	}
+	if ds.Algorithm.Hash != tls.SHA256 || ds.Algorithm.Signature != tls.SignatureAlgorithmFromPubKey(pubKey) {
+		return 0, fmt.Errorf("unexpected SCT signature algorithm %+v", ds.Algorithm)
+	}
+	input, err := tls.Marshal(*NewCertificateTimestamp(ext, sct.Timestamp, isPrecert, cert, ikh))
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal SCT signature input: %v", err)
+	}
+	digest := sha256.Sum256(input)
+	if !signer.VerifySHA256(pubKey, digest[:], ds.Signature) {
+		return 0, errors.New("invalid SCT signature")
+	}
+	return idx, nil
+}
+
+// VerifySTH checks that sth, as returned by the get-sth endpoint of an
+// RFC 6962 log, carries a valid tree head signature from the log with pubKey.
+func VerifySTH(pubKey crypto.PublicKey, sth *rfc6962.GetSTHResponse) error {
+	th := rfc6962.TreeHeadSignature{
+		Version:       rfc6962.V1,
+		SignatureType: rfc6962.TreeHashSignatureType,
+		Timestamp:     sth.Timestamp,
+		TreeSize:      sth.TreeSize,
+	}
+	if len(sth.SHA256RootHash) != len(th.SHA256RootHash) {
+		return fmt.Errorf("invalid root hash size %d", len(sth.SHA256RootHash))
+	}
+	copy(th.SHA256RootHash[:], sth.SHA256RootHash)
+	var ds rfc6962.DigitallySigned
+	if rest, err := tls.Unmarshal(sth.TreeHeadSignature, &ds); err != nil {
+		return fmt.Errorf("can't parse STH signature: %v", err)
+	} else if len(rest) > 0 {
+		return fmt.Errorf("trailing data after STH signature: %d bytes", len(rest))
+	}
+	if ds.Algorithm.Hash != tls.SHA256 || ds.Algorithm.Signature != tls.SignatureAlgorithmFromPubKey(pubKey) {
+		return fmt.Errorf("unexpected STH signature algorithm %+v", ds.Algorithm)
+	}
+	input, err := tls.Marshal(th)
+	if err != nil {
+		return fmt.Errorf("failed to marshal STH signature input: %v", err)
+	}
+	digest := sha256.Sum256(input)
+	if !signer.VerifySHA256(pubKey, digest[:], ds.Signature) {
+		return errors.New("invalid STH signature")
+	}
+	return nil
+}
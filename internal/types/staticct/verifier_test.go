@@ -0,0 +1,349 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticct
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+)
+
+// signCheckpoint returns a checkpoint body for the given tree and an
+// RFC6962NoteSignature over it.
+func signCheckpoint(t *testing.T, k crypto.Signer, origin string, size uint64, root [32]byte, timestamp uint64) ([]byte, []byte) {
+	t.Helper()
+	th := rfc6962.TreeHeadSignature{
+		Version:        rfc6962.V1,
+		SignatureType:  rfc6962.TreeHashSignatureType,
+		Timestamp:      timestamp,
+		TreeSize:       size,
+		SHA256RootHash: root,
+	}
+	input, err := tls.Marshal(th)
+	if err != nil {
+		t.Fatalf("tls.Marshal(): %v", err)
+	}
+	digest := sha256.Sum256(input)
+	sig, err := k.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	ns, err := tls.Marshal(struct {
+		Timestamp uint64
+		Signature rfc6962.DigitallySigned
+	}{
+		Timestamp: timestamp,
+		Signature: rfc6962.DigitallySigned{
+			Algorithm: tls.SignatureAndHashAlgorithm{
+				Hash:      tls.SHA256,
+				Signature: tls.SignatureAlgorithmFromPubKey(k.Public()),
+			},
+			Signature: sig,
+		},
+	})
+	if err != nil {
+		t.Fatalf("tls.Marshal(): %v", err)
+	}
+	return checkpointBody(origin, size, root), ns
+}
+
+// checkpointBody returns the static-ct-api checkpoint body for the given tree.
+func checkpointBody(origin string, size uint64, root [32]byte) []byte {
+	return fmt.Appendf(nil, "%s\n%d\n%s\n", origin, size, base64.StdEncoding.EncodeToString(root[:]))
+}
+
+func TestCheckpointVerifier(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	const origin = "example.com/log"
+	root := sha256.Sum256([]byte("root"))
+
+	for _, tc := range []struct {
+		desc    string
+		signKey crypto.Signer
+		verKey  crypto.PublicKey
+		origin  string
+		tamper  func(msg []byte) []byte
+		want    bool
+	}{
+		{
+			desc:    "ecdsa",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			origin:  origin,
+			want:    true,
+		},
+		{
+			desc:    "rsa",
+			signKey: rsaKey,
+			verKey:  rsaKey.Public(),
+			origin:  origin,
+			want:    true,
+		},
+		{
+			desc:    "wrong-key",
+			signKey: rsaKey,
+			verKey:  ecdsaKey.Public(),
+			origin:  origin,
+		},
+		{
+			desc:    "wrong-origin",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			origin:  "example.com/other",
+		},
+		{
+			desc:    "tampered-size",
+			signKey: rsaKey,
+			verKey:  rsaKey.Public(),
+			origin:  origin,
+			tamper: func([]byte) []byte {
+				return checkpointBody(origin, 43, root)
+			},
+		},
+		{
+			desc:    "extension-line",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			origin:  origin,
+			tamper: func(msg []byte) []byte {
+				return append(msg, []byte("extension\n")...)
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, sig := signCheckpoint(t, tc.signKey, origin, 42, root, 1234)
+			if tc.tamper != nil {
+				msg = tc.tamper(msg)
+			}
+			v, err := NewCheckpointVerifier(tc.origin, tc.verKey)
+			if err != nil {
+				t.Fatalf("NewCheckpointVerifier(): %v", err)
+			}
+			if got := v.Verify(msg, sig); got != tc.want {
+				t.Errorf("Verify()=%t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// signSCT returns an SCT over the given entry, with a leaf index extension.
+func signSCT(t *testing.T, k crypto.Signer, timestamp, idx uint64, isPrecert bool, cert []byte, ikh [32]byte) *rfc6962.AddChainResponse {
+	t.Helper()
+	ext := []byte{0, 0, 5, byte(idx >> 32), byte(idx >> 24), byte(idx >> 16), byte(idx >> 8), byte(idx)}
+	input, err := tls.Marshal(*NewCertificateTimestamp(ext, timestamp, isPrecert, cert, ikh))
+	if err != nil {
+		t.Fatalf("tls.Marshal(): %v", err)
+	}
+	digest := sha256.Sum256(input)
+	sig, err := k.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	ds, err := tls.Marshal(rfc6962.DigitallySigned{
+		Algorithm: tls.SignatureAndHashAlgorithm{
+			Hash:      tls.SHA256,
+			Signature: tls.SignatureAlgorithmFromPubKey(k.Public()),
+		},
+		Signature: sig,
+	})
+	if err != nil {
+		t.Fatalf("tls.Marshal(): %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(k.Public())
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey(): %v", err)
+	}
+	logID := sha256.Sum256(der)
+	return &rfc6962.AddChainResponse{
+		SCTVersion: rfc6962.V1,
+		ID:         logID[:],
+		Timestamp:  timestamp,
+		Extensions: base64.StdEncoding.EncodeToString(ext),
+		Signature:  ds,
+	}
+}
+
+func TestVerifySCT(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	cert := []byte("certificate")
+	ikh := sha256.Sum256([]byte("issuer"))
+
+	for _, tc := range []struct {
+		desc      string
+		signKey   crypto.Signer
+		verKey    crypto.PublicKey
+		isPrecert bool
+		tamper    func(sct *rfc6962.AddChainResponse)
+		verCert   []byte
+		wantErr   bool
+	}{
+		{
+			desc:    "ecdsa",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+		},
+		{
+			desc:      "rsa-precert",
+			signKey:   rsaKey,
+			verKey:    rsaKey.Public(),
+			isPrecert: true,
+		},
+		{
+			desc:    "wrong-key",
+			signKey: ecdsaKey,
+			verKey:  rsaKey.Public(),
+			wantErr: true,
+		},
+		{
+			desc:    "wrong-cert",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			verCert: []byte("other"),
+			wantErr: true,
+		},
+		{
+			desc:    "wrong-timestamp",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			tamper:  func(sct *rfc6962.AddChainResponse) { sct.Timestamp++ },
+			wantErr: true,
+		},
+		{
+			desc:    "bad-extensions",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			tamper:  func(sct *rfc6962.AddChainResponse) { sct.Extensions = "" },
+			wantErr: true,
+		},
+		{
+			desc:    "bad-version",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			tamper:  func(sct *rfc6962.AddChainResponse) { sct.SCTVersion = 1 },
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sct := signSCT(t, tc.signKey, 1234, 42, tc.isPrecert, cert, ikh)
+			if tc.tamper != nil {
+				tc.tamper(sct)
+			}
+			verCert := cert
+			if tc.verCert != nil {
+				verCert = tc.verCert
+			}
+			idx, err := VerifySCT(tc.verKey, sct, tc.isPrecert, verCert, ikh)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("VerifySCT()=%v, want err %t", err, tc.wantErr)
+			}
+			if err == nil && idx != 42 {
+				t.Errorf("VerifySCT()=%d, want 42", idx)
+			}
+		})
+	}
+}
+
+func TestVerifySTH(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	root := sha256.Sum256([]byte("root"))
+
+	for _, tc := range []struct {
+		desc    string
+		signKey crypto.Signer
+		verKey  crypto.PublicKey
+		tamper  func(sth *rfc6962.GetSTHResponse)
+		wantErr bool
+	}{
+		{
+			desc:    "ecdsa",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+		},
+		{
+			desc:    "rsa",
+			signKey: rsaKey,
+			verKey:  rsaKey.Public(),
+		},
+		{
+			desc:    "wrong-key",
+			signKey: ecdsaKey,
+			verKey:  rsaKey.Public(),
+			wantErr: true,
+		},
+		{
+			desc:    "wrong-size",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			tamper:  func(sth *rfc6962.GetSTHResponse) { sth.TreeSize++ },
+			wantErr: true,
+		},
+		{
+			desc:    "short-root",
+			signKey: ecdsaKey,
+			verKey:  ecdsaKey.Public(),
+			tamper:  func(sth *rfc6962.GetSTHResponse) { sth.SHA256RootHash = sth.SHA256RootHash[1:] },
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, ns := signCheckpoint(t, tc.signKey, "example.com/log", 10, root, 1234)
+			sth := &rfc6962.GetSTHResponse{
+				TreeSize:       10,
+				Timestamp:      1234,
+				SHA256RootHash: root[:],
+				// An RFC6962NoteSignature is a timestamp, followed by the
+				// tree head signature.
+				TreeHeadSignature: ns[8:],
+			}
+			if tc.tamper != nil {
+				tc.tamper(sth)
+			}
+			if err := VerifySTH(tc.verKey, sth); (err != nil) != tc.wantErr {
+				t.Fatalf("VerifySTH()=%v, want err %t", err, tc.wantErr)
+			}
+		})
+	}
+}
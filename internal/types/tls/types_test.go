@@ -17,6 +17,7 @@ package tls
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/rsa"
 	"testing"
 )
 
@@ -43,6 +44,7 @@ func TestSignatureAlgorithmString(t *testing.T) {
 		want string
 	}{
 		{Anonymous, "Anonymous"},
+		{RSA, "RSA"},
 		{ECDSA, "ECDSA"},
 		{99, "UNKNOWN(99)"},
 	}
@@ -81,6 +83,7 @@ func TestSignatureAlgorithm(t *testing.T) {
 		want SignatureAlgorithm
 	}{
 		{name: "ECDSA", key: new(ecdsa.PublicKey), want: ECDSA},
+		{name: "RSA", key: new(rsa.PublicKey), want: RSA},
 		{name: "Other", key: "foo", want: Anonymous},
 	} {
 		if got := SignatureAlgorithmFromPubKey(test.key); got != test.want {
@@ -0,0 +1,60 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staticct
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/transparency-dev/tesseract/testdata"
+)
+
+func TestEntryMarshalText(t *testing.T) {
+	eb := EntryBundle{}
+	if err := eb.UnmarshalText(testdata.ExampleFullTile); err != nil {
+		t.Fatalf("failed to unmarshal full tile: %v", err)
+	}
+	for i, raw := range eb.Entries {
+		e := Entry{}
+		if err := e.UnmarshalText(raw); err != nil {
+			t.Fatalf("UnmarshalText(%d): %v", i, err)
+		}
+		got, err := e.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d): %v", i, err)
+		}
+		if !bytes.Equal(got, raw) {
+			t.Errorf("%d: MarshalText() doesn't round trip", i)
+		}
+	}
+
+	// Entries imported from RFC 6962 logs have no extensions.
+	e := Entry{Timestamp: 1, Certificate: []byte("cert"), FingerprintsChain: [][32]byte{{1}, {2}}}
+	raw, err := e.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(): %v", err)
+	}
+	got := Entry{LeafIndex: 42}
+	if err := got.UnmarshalText(raw); err != nil {
+		t.Fatalf("UnmarshalText(): %v", err)
+	}
+	if got.LeafIndex != 0 || got.RawExtensions != "" || len(got.FingerprintsChain) != 2 || !bytes.Equal(got.Certificate, e.Certificate) {
+		t.Errorf("UnmarshalText() = %+v, want %+v", got, e)
+	}
+
+	if _, err := (&Entry{IsPrecert: true, IssuerKeyHash: []byte("short")}).MarshalText(); err == nil {
+		t.Error("MarshalText() of a precert with an invalid issuer key hash succeeded")
+	}
+}
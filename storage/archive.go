@@ -0,0 +1,195 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// DefaultCheckpointArchivePruneInterval is the interval between two
+	// prunings of the checkpoint archive.
+	DefaultCheckpointArchivePruneInterval = time.Hour
+	// maxSignedCheckpoints bounds the number of signed checkpoint sizes
+	// waiting to be compared with the archived ones.
+	maxSignedCheckpoints = 1024
+)
+
+// CheckpointArchiveStorage is an append-only archive of the checkpoints
+// published by a log, stored under their size at staticct.CheckpointsPrefix.
+type CheckpointArchiveStorage interface {
+	// AddCheckpoint stores the checkpoint cp of the given size, if there isn't
+	// one of this size in the archive already.
+	AddCheckpoint(ctx context.Context, size uint64, cp []byte) error
+	// PruneCheckpoints deletes checkpoints which were archived before t, and
+	// returns how many were deleted.
+	PruneCheckpoints(ctx context.Context, t time.Time) (int, error)
+}
+
+// SignedCheckpoints records the sizes of the checkpoints signed by a log, so
+// that a CheckpointArchiver can tell which ones it didn't archive.
+type SignedCheckpoints struct {
+	mu    sync.Mutex
+	sizes []uint64
+}
+
+// WrapSigner returns a signer which records the size of the checkpoints it
+// signs with s.
+func (sc *SignedCheckpoints) WrapSigner(s note.Signer) note.Signer {
+	return &recordingSigner{Signer: s, sc: sc}
+}
+
+// skipped forgets the checkpoints of size up to size, and returns the sizes of
+// the ones which were signed after the one of size last, if not nil.
+func (sc *SignedCheckpoints) skipped(last *uint64, size uint64) []uint64 {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	var skipped []uint64
+	i := 0
+	for ; i < len(sc.sizes) && sc.sizes[i] <= size; i++ {
+		if s := sc.sizes[i]; s < size && (last == nil || s > *last) {
+			skipped = append(skipped, s)
+		}
+	}
+	sc.sizes = sc.sizes[i:]
+	return skipped
+}
+
+type recordingSigner struct {
+	note.Signer
+	sc *SignedCheckpoints
+}
+
+func (s *recordingSigner) Sign(msg []byte) ([]byte, error) {
+	sig, err := s.Signer.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := parseCheckpoint(msg)
+	if err != nil {
+		// Not a checkpoint, there's nothing to archive.
+		return sig, nil
+	}
+	s.sc.mu.Lock()
+	defer s.sc.mu.Unlock()
+	// Checkpoints are signed with a growing size, but republished ones have
+	// the same size.
+	if n := len(s.sc.sizes); n == 0 || s.sc.sizes[n-1] < cp.Size {
+		s.sc.sizes = append(s.sc.sizes, cp.Size)
+	}
+	if len(s.sc.sizes) > maxSignedCheckpoints {
+		s.sc.sizes = slices.Delete(s.sc.sizes, 0, len(s.sc.sizes)-maxSignedCheckpoints)
+	}
+	return sig, nil
+}
+
+// CheckpointArchiver archives every new checkpoint published by a log.
+type CheckpointArchiver struct {
+	readCheckpoint func(ctx context.Context) ([]byte, error)
+	s              CheckpointArchiveStorage
+	signed         *SignedCheckpoints
+	// lastSize is the size of the latest checkpoint archived.
+	lastSize *uint64
+}
+
+// NewCheckpointArchiver returns a CheckpointArchiver reading checkpoints with
+// readCheckpoint, and archiving them to s.
+//
+// If signed isn't nil, signed checkpoints which were replaced before they could
+// be archived are logged, and counted by the
+// tesseract.checkpoint.archive.skipped.count metric.
+func NewCheckpointArchiver(readCheckpoint func(ctx context.Context) ([]byte, error), s CheckpointArchiveStorage, signed *SignedCheckpoints) *CheckpointArchiver {
+	checkpointArchiveOnce.Do(setupCheckpointArchiveMetrics)
+	return &CheckpointArchiver{
+		readCheckpoint: readCheckpoint,
+		s:              s,
+		signed:         signed,
+	}
+}
+
+// Run archives new checkpoints every interval until ctx is done.
+//
+// interval must not be longer than the interval between two checkpoints, or
+// some of them are never archived.
+//
+// If maxAge is positive, checkpoints archived more than maxAge ago are pruned
+// every DefaultCheckpointArchivePruneInterval.
+func (a *CheckpointArchiver) Run(ctx context.Context, interval, maxAge time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	var pruneC <-chan time.Time
+	if maxAge > 0 {
+		pt := time.NewTicker(DefaultCheckpointArchivePruneInterval)
+		defer pt.Stop()
+		pruneC = pt.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := a.Archive(ctx); err != nil {
+				slog.WarnContext(ctx, "Failed to archive checkpoint", slog.Any("error", err))
+			}
+		case <-pruneC:
+			n, err := a.s.PruneCheckpoints(ctx, time.Now().Add(-maxAge))
+			if err != nil {
+				slog.WarnContext(ctx, "Failed to prune checkpoint archive", slog.Int("deleted", n), slog.Any("error", err))
+				continue
+			}
+			slog.InfoContext(ctx, "Pruned checkpoint archive", slog.Int("deleted", n))
+		}
+	}
+}
+
+// Archive reads the log's latest checkpoint, and adds it to the archive if it
+// has a new size.
+func (a *CheckpointArchiver) Archive(ctx context.Context) error {
+	cpRaw, err := a.readCheckpoint(ctx)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && cpRaw == nil) {
+		// Nothing has been published yet.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	cp, err := parseCheckpoint(cpRaw)
+	if err != nil {
+		return err
+	}
+	if a.lastSize != nil && *a.lastSize == cp.Size {
+		return nil
+	}
+	if err := a.s.AddCheckpoint(ctx, cp.Size, cpRaw); err != nil {
+		return fmt.Errorf("failed to archive checkpoint of size %d: %v", cp.Size, err)
+	}
+	if a.signed != nil {
+		if skipped := a.signed.skipped(a.lastSize, cp.Size); len(skipped) > 0 {
+			checkpointArchiveSkipped.Add(ctx, int64(len(skipped)))
+			slog.WarnContext(ctx, "Checkpoints were signed, but not archived", slog.Any("sizes", skipped), slog.Uint64("archived_size", cp.Size))
+		}
+	}
+	a.lastSize = &cp.Size
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/transparency-dev/formats/log"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// memArchive is an in-memory CheckpointArchiveStorage.
+type memArchive map[uint64][]byte
+
+func (m memArchive) AddCheckpoint(_ context.Context, size uint64, cp []byte) error {
+	if _, ok := m[size]; !ok {
+		m[size] = cp
+	}
+	return nil
+}
+
+func (m memArchive) PruneCheckpoints(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestCheckpointArchiver(t *testing.T) {
+	checkpoint := func(size uint64, note string) []byte {
+		h := sha256.Sum256([]byte{byte(size)})
+		return append(log.Checkpoint{Origin: "example.com/log", Size: size, Hash: h[:]}.Marshal(), note...)
+	}
+	var cp []byte
+	var cpErr error
+	readCheckpoint := func(context.Context) ([]byte, error) { return cp, cpErr }
+	s := memArchive{}
+	a := NewCheckpointArchiver(readCheckpoint, s, nil)
+
+	for _, step := range []struct {
+		cp      []byte
+		err     error
+		wantErr bool
+	}{
+		// Nothing has been published yet.
+		{err: os.ErrNotExist},
+		{cp: checkpoint(1, "\n— sig1\n")},
+		// The same size republished with more cosignatures isn't archived again.
+		{cp: checkpoint(1, "\n— sig1\n— sig2\n")},
+		{cp: checkpoint(3, "\n— sig3\n")},
+		{cp: []byte("garbage"), wantErr: true},
+	} {
+		cp, cpErr = step.cp, step.err
+		if err := a.Archive(t.Context()); (err != nil) != step.wantErr {
+			t.Fatalf("Archive(%q)=%v, want error: %t", step.cp, err, step.wantErr)
+		}
+	}
+
+	want := map[uint64]string{
+		1: string(checkpoint(1, "\n— sig1\n")),
+		3: string(checkpoint(3, "\n— sig3\n")),
+	}
+	if len(s) != len(want) {
+		t.Errorf("archived %d checkpoints, want %d", len(s), len(want))
+	}
+	for size, w := range want {
+		if got := string(s[size]); got != w {
+			t.Errorf("archived checkpoint %d=%q, want %q", size, got, w)
+		}
+	}
+}
+
+func TestSignedCheckpoints(t *testing.T) {
+	sk, _, err := note.GenerateKey(nil, "example.com/log")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	ns, err := note.NewSigner(sk)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	signed := &SignedCheckpoints{}
+	signer := signed.WrapSigner(ns)
+
+	var last *uint64
+	for _, step := range []struct {
+		desc string
+		// signed are the sizes of the checkpoints signed before the one of
+		// size archived is.
+		signed      []uint64
+		archived    uint64
+		wantSkipped []uint64
+	}{
+		{desc: "first", signed: []uint64{1}, archived: 1},
+		{desc: "republished", signed: []uint64{1}, archived: 1},
+		{desc: "replaced", signed: []uint64{3, 5}, archived: 5, wantSkipped: []uint64{3}},
+		{desc: "not-published-yet", signed: []uint64{6, 8}, archived: 6},
+		{desc: "published-later", archived: 8},
+		{desc: "never-published", signed: []uint64{9, 10, 11}, archived: 11, wantSkipped: []uint64{9, 10}},
+	} {
+		for _, size := range step.signed {
+			h := sha256.Sum256([]byte{byte(size)})
+			body := log.Checkpoint{Origin: "example.com/log", Size: size, Hash: h[:]}.Marshal()
+			if _, err := note.Sign(&note.Note{Text: string(body)}, signer); err != nil {
+				t.Fatalf("%s: note.Sign(): %v", step.desc, err)
+			}
+		}
+		if diff := cmp.Diff(step.wantSkipped, signed.skipped(last, step.archived)); diff != "" {
+			t.Errorf("%s: skipped() diff (-want +got):\n%s", step.desc, diff)
+		}
+		last = &step.archived
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera/api"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tessera/ctonly"
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/internal/otel"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	auditCheckConsistency = "consistency"
+	auditCheckBundle      = "bundle"
+)
+
+var (
+	auditOnce        sync.Once
+	auditChecks      metric.Int64Counter // check
+	auditFailures    metric.Int64Counter // check
+	auditedSize      metric.Int64Gauge
+	auditedTimestamp metric.Int64Gauge
+)
+
+func setupAuditMetrics() {
+	auditChecks = mustCreate(meter.Int64Counter("tesseract.audit.check.count",
+		metric.WithDescription("Self-audit checks of published checkpoints and entry bundles"),
+		metric.WithUnit("{check}")))
+
+	auditFailures = mustCreate(meter.Int64Counter("tesseract.audit.failure.count",
+		metric.WithDescription("Failed self-audit checks of published checkpoints and entry bundles"),
+		metric.WithUnit("{check}")))
+
+	auditedSize = mustCreate(meter.Int64Gauge("tesseract.audit.checkpoint.size",
+		metric.WithDescription("Size of the latest checkpoint proven consistent by the self-auditor"),
+		metric.WithUnit("{entry}")))
+
+	auditedTimestamp = mustCreate(meter.Int64Gauge("tesseract.audit.checkpoint.timestamp",
+		metric.WithDescription("Time at which the latest checkpoint was proven consistent by the self-auditor"),
+		metric.WithUnit("ms")))
+}
+
+// AuditReader is the subset of tessera.LogReader used by the Auditor.
+type AuditReader interface {
+	ReadCheckpoint(ctx context.Context) ([]byte, error)
+	ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error)
+	ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error)
+}
+
+// Auditor checks that the checkpoints published by a log are consistent with
+// each other, and that the entry bundles they cover match the log's tiles.
+//
+// It catches storage or sequencing bugs which would otherwise go unnoticed
+// until an external monitor noticed them.
+type Auditor struct {
+	r AuditReader
+	// bundleSampleRate is the probability that a new entry bundle is
+	// re-hashed against tiles.
+	bundleSampleRate float64
+	// last is the latest checkpoint proven consistent.
+	last *log.Checkpoint
+}
+
+// NewAuditor returns an Auditor reading the log from r.
+//
+// bundleSampleRate, between 0 and 1, is the fraction of the entry bundles
+// added by each new checkpoint which are re-hashed against tiles.
+func NewAuditor(r AuditReader, bundleSampleRate float64) *Auditor {
+	auditOnce.Do(setupAuditMetrics)
+	return &Auditor{
+		r:                r,
+		bundleSampleRate: bundleSampleRate,
+	}
+}
+
+// Run audits the log every interval, until ctx is done.
+//
+// Failures are logged at the error level, and exported as metrics.
+func (a *Auditor) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if err := a.Audit(ctx); err != nil {
+			slog.ErrorContext(ctx, "Self-audit failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Audit reads the log's latest checkpoint, and checks that it's consistent
+// with the previous one Audit read. It then re-hashes a sample of the entry
+// bundles the new checkpoint covers, and checks them against the level 0
+// tiles.
+//
+// The first call only reads the checkpoint which later ones are checked
+// against. A checkpoint which fails the consistency check is not used for
+// later audits, so that the failure keeps being reported.
+func (a *Auditor) Audit(ctx context.Context) error {
+	cpRaw, err := a.r.ReadCheckpoint(ctx)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && cpRaw == nil) {
+		// Nothing has been published yet.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	cp, err := parseCheckpoint(cpRaw)
+	if err != nil {
+		return err
+	}
+	if a.last == nil {
+		a.setLast(ctx, cp)
+		return nil
+	}
+	if cp.Size == a.last.Size && bytes.Equal(cp.Hash, a.last.Hash) {
+		return nil
+	}
+
+	auditChecks.Add(ctx, 1, metric.WithAttributes(auditCheckKey.String(auditCheckConsistency)))
+	if cp.Size < a.last.Size {
+		auditFailures.Add(ctx, 1, metric.WithAttributes(auditCheckKey.String(auditCheckConsistency)))
+		return fmt.Errorf("checkpoint size went down from %d to %d", a.last.Size, cp.Size)
+	}
+	if err := client.CheckConsistency(ctx, a.r.ReadTile, []log.Checkpoint{*a.last, *cp}); err != nil {
+		auditFailures.Add(ctx, 1, metric.WithAttributes(auditCheckKey.String(auditCheckConsistency)))
+		return fmt.Errorf("checkpoint of size %d is not consistent with checkpoint of size %d: %v", cp.Size, a.last.Size, err)
+	}
+
+	var errs []error
+	for i := a.last.Size / layout.EntryBundleWidth; i*layout.EntryBundleWidth < cp.Size; i++ {
+		if rand.Float64() >= a.bundleSampleRate {
+			continue
+		}
+		auditChecks.Add(ctx, 1, metric.WithAttributes(auditCheckKey.String(auditCheckBundle)))
+		if err := a.checkBundle(ctx, i, cp.Size); err != nil {
+			auditFailures.Add(ctx, 1, metric.WithAttributes(auditCheckKey.String(auditCheckBundle)))
+			errs = append(errs, err)
+		}
+	}
+	a.setLast(ctx, cp)
+	return errors.Join(errs...)
+}
+
+func (a *Auditor) setLast(ctx context.Context, cp *log.Checkpoint) {
+	a.last = cp
+	auditedSize.Record(ctx, otel.Clamp64(cp.Size))
+	auditedTimestamp.Record(ctx, time.Now().UnixMilli())
+}
+
+// checkBundle checks that the leaf hashes of the entries in bundle i match the
+// level 0 tile i, for a log of size logSize.
+func (a *Auditor) checkBundle(ctx context.Context, i, logSize uint64) error {
+	bundle, err := client.GetEntryBundle(ctx, a.r.ReadEntryBundle, i, logSize)
+	if err != nil {
+		return err
+	}
+	tile, err := a.readTile(ctx, i, logSize)
+	if err != nil {
+		return err
+	}
+	// Partial resources might have been replaced by larger ones since the
+	// checkpoint was read, so only compare the entries it covers.
+	n := min(logSize-i*layout.EntryBundleWidth, layout.EntryBundleWidth)
+	if uint64(len(bundle.Entries)) < n || uint64(len(tile.Nodes)) < n {
+		return fmt.Errorf("entry bundle %d has %d entries and tile %d hashes, want %d", i, len(bundle.Entries), len(tile.Nodes), n)
+	}
+	for j := range n {
+		idx := i*layout.EntryBundleWidth + j
+		e := staticct.Entry{}
+		if err := e.UnmarshalText(bundle.Entries[j]); err != nil {
+			return fmt.Errorf("failed to parse entry %d: %v", idx, err)
+		}
+		if e.RawExtensions == "" {
+			return fmt.Errorf("entry %d has no leaf index", idx)
+		}
+		if e.LeafIndex != idx {
+			return fmt.Errorf("entry %d has leaf index %d", idx, e.LeafIndex)
+		}
+		ce := ctonly.Entry{
+			Timestamp:         e.Timestamp,
+			IsPrecert:         e.IsPrecert,
+			Certificate:       e.Certificate,
+			Precertificate:    e.Precertificate,
+			IssuerKeyHash:     e.IssuerKeyHash,
+			FingerprintsChain: e.FingerprintsChain,
+		}
+		if h := ce.MerkleLeafHash(idx); !bytes.Equal(h, tile.Nodes[j]) {
+			return fmt.Errorf("entry %d has leaf hash %x, but tile %d has %x", idx, h, i, tile.Nodes[j])
+		}
+	}
+	return nil
+}
+
+// readTile reads level 0 tile i for a log of size logSize, falling back to
+// the full tile if the partial one has been garbage collected.
+func (a *Auditor) readTile(ctx context.Context, i, logSize uint64) (api.HashTile, error) {
+	tile := api.HashTile{}
+	p := layout.PartialTileSize(0, i, logSize)
+	raw, err := a.r.ReadTile(ctx, 0, i, p)
+	if errors.Is(err, os.ErrNotExist) && p > 0 {
+		raw, err = a.r.ReadTile(ctx, 0, i, 0)
+	}
+	if err != nil {
+		return tile, fmt.Errorf("failed to read tile %d: %v", i, err)
+	}
+	if err := tile.UnmarshalText(raw); err != nil {
+		return tile, fmt.Errorf("failed to parse tile %d: %v", i, err)
+	}
+	return tile, nil
+}
+
+// parseCheckpoint parses the body of a checkpoint, without verifying its
+// signatures: the auditor reads the log's own storage.
+func parseCheckpoint(raw []byte) (*log.Checkpoint, error) {
+	cp := &log.Checkpoint{}
+	if _, err := cp.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %v", err)
+	}
+	return cp, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/storage"
+	"github.com/transparency-dev/tesseract/tesseracttest"
+)
+
+// tamperingReader serves a log, with its checkpoint and entry bundles
+// optionally replaced.
+type tamperingReader struct {
+	*client.HTTPFetcher
+	checkpoint []byte
+	bundle     func([]byte) []byte
+}
+
+func (r *tamperingReader) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	if r.checkpoint != nil {
+		return r.checkpoint, nil
+	}
+	return r.HTTPFetcher.ReadCheckpoint(ctx)
+}
+
+func (r *tamperingReader) ReadEntryBundle(ctx context.Context, i uint64, p uint8) ([]byte, error) {
+	b, err := r.HTTPFetcher.ReadEntryBundle(ctx, i, p)
+	if err != nil || r.bundle == nil {
+		return b, err
+	}
+	return r.bundle(bytes.Clone(b)), nil
+}
+
+func TestAuditor(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tamper  func(r *tamperingReader, old, cur *log.Checkpoint)
+		wantErr bool
+	}{
+		{
+			name:   "consistent",
+			tamper: func(*tamperingReader, *log.Checkpoint, *log.Checkpoint) {},
+		},
+		{
+			name: "inconsistent checkpoint",
+			tamper: func(r *tamperingReader, _, cur *log.Checkpoint) {
+				h := sha256.Sum256([]byte("fork"))
+				r.checkpoint = log.Checkpoint{Origin: cur.Origin, Size: cur.Size, Hash: h[:]}.Marshal()
+			},
+			wantErr: true,
+		},
+		{
+			name: "smaller checkpoint",
+			tamper: func(r *tamperingReader, old, _ *log.Checkpoint) {
+				r.checkpoint = log.Checkpoint{Origin: old.Origin, Size: old.Size - 1, Hash: old.Hash}.Marshal()
+			},
+			wantErr: true,
+		},
+		{
+			name: "entry bundle doesn't match tile",
+			tamper: func(r *tamperingReader, _, _ *log.Checkpoint) {
+				r.bundle = func(b []byte) []byte {
+					// Change the timestamp of the first entry.
+					b[7] ^= 1
+					return b
+				}
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+			u, err := url.Parse(l.URL())
+			if err != nil {
+				t.Fatalf("url.Parse(): %v", err)
+			}
+			f, err := client.NewHTTPFetcher(u, http.DefaultClient)
+			if err != nil {
+				t.Fatalf("NewHTTPFetcher(): %v", err)
+			}
+			r := &tamperingReader{HTTPFetcher: f}
+			a := storage.NewAuditor(r, 1)
+
+			l.MustSubmit(t, l.IssueChain(t))
+			old := l.Integrate(t)
+			if err := a.Audit(t.Context()); err != nil {
+				t.Fatalf("Audit() of the first checkpoint: %v", err)
+			}
+			l.MustSubmit(t, l.IssueChain(t))
+			l.MustSubmit(t, l.IssuePrecertChain(t))
+			cur := l.Integrate(t)
+
+			tc.tamper(r, old, cur)
+			if err := a.Audit(t.Context()); (err != nil) != tc.wantErr {
+				t.Errorf("Audit(): got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
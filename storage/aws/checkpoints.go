@@ -0,0 +1,111 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+// CheckpointArchiveStorage archives checkpoints in S3 objects named after
+// their size.
+type CheckpointArchiveStorage struct {
+	s3Client *s3.Client
+	bucket   string
+}
+
+// NewCheckpointArchiveStorage creates a new S3 based checkpoint archive.
+//
+// Checkpoints will be stored under staticct.CheckpointsPrefix.
+func NewCheckpointArchiveStorage(ctx context.Context, opts Options) (*CheckpointArchiveStorage, error) {
+	var sdkConfig aws.Config
+	if opts.SDKConfig != nil {
+		sdkConfig = *opts.SDKConfig
+	} else {
+		var err error
+		sdkConfig, err = config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default AWS configuration: %v", err)
+		}
+		opts.S3Options = func(_ *s3.Options) {}
+	}
+	return &CheckpointArchiveStorage{
+		s3Client: s3.NewFromConfig(sdkConfig, opts.S3Options),
+		bucket:   opts.Bucket,
+	}, nil
+}
+
+// AddCheckpoint stores cp under its size, unless a checkpoint of this size is
+// already archived.
+func (s *CheckpointArchiveStorage) AddCheckpoint(ctx context.Context, size uint64, cp []byte) error {
+	objName := path.Join(staticct.CheckpointsPrefix, strconv.FormatUint(size, 10))
+	if _, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(objName),
+		Body:        bytes.NewReader(cp),
+		ContentType: aws.String(staticct.CheckpointsContentType),
+		IfNoneMatch: aws.String("*"),
+	}); err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "PreconditionFailed" {
+			// A checkpoint of this size is already archived.
+			return nil
+		}
+		return fmt.Errorf("failed to write object %q to bucket %q: %w", objName, s.bucket, err)
+	}
+	return nil
+}
+
+// PruneCheckpoints deletes checkpoints whose object was last modified before t.
+func (s *CheckpointArchiveStorage) PruneCheckpoints(ctx context.Context, t time.Time) (int, error) {
+	n := 0
+	var errs []error
+	paginator := s3.NewListObjectsV2Paginator(s.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(staticct.CheckpointsPrefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list objects in bucket %q prefix %q: %w", s.bucket, staticct.CheckpointsPrefix, err))
+			break
+		}
+		for _, obj := range page.Contents {
+			if obj.LastModified == nil || !obj.LastModified.Before(t) {
+				continue
+			}
+			if _, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: aws.String(s.bucket),
+				Key:    obj.Key,
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete object %q: %w", *obj.Key, err))
+				continue
+			}
+			n++
+		}
+	}
+	return n, errors.Join(errs...)
+}
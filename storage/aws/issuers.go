@@ -99,6 +99,25 @@ func (s *IssuersStorage) objNameToKey(objName string) []byte {
 	return []byte(strings.TrimPrefix(objName, s.prefix))
 }
 
+// List returns the keys of all the values in the bucket under the prefix.
+func (s *IssuersStorage) List(ctx context.Context) ([][]byte, error) {
+	keys := [][]byte{}
+	paginator := s3.NewListObjectsV2Paginator(s.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return keys, fmt.Errorf("failed to list objects in bucket %q prefix %q: %w", s.bucket, s.prefix, err)
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, s.objNameToKey(*obj.Key))
+		}
+	}
+	return keys, nil
+}
+
 // LoadAll loads all the values in the bucket under the prefix.
 func (s *IssuersStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
 	errs := []error(nil)
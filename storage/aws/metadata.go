@@ -0,0 +1,66 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+// MetadataStorage stores the log metadata document in an S3 object.
+type MetadataStorage struct {
+	s3Client *s3.Client
+	bucket   string
+}
+
+// NewMetadataStorage creates a new S3 based metadata storage.
+//
+// The document will be stored under staticct.MetadataPath, next to the checkpoint.
+func NewMetadataStorage(ctx context.Context, opts Options) (*MetadataStorage, error) {
+	var sdkConfig aws.Config
+	if opts.SDKConfig != nil {
+		sdkConfig = *opts.SDKConfig
+	} else {
+		var err error
+		sdkConfig, err = config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default AWS configuration: %v", err)
+		}
+		opts.S3Options = func(_ *s3.Options) {}
+	}
+	return &MetadataStorage{
+		s3Client: s3.NewFromConfig(sdkConfig, opts.S3Options),
+		bucket:   opts.Bucket,
+	}, nil
+}
+
+// SetMetadata replaces the metadata document with data.
+func (s *MetadataStorage) SetMetadata(ctx context.Context, data []byte) error {
+	if _, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(staticct.MetadataPath),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(staticct.MetadataContentType),
+	}); err != nil {
+		return fmt.Errorf("failed to write object %q to bucket %q: %w", staticct.MetadataPath, s.bucket, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckpointArchiveStorage archives checkpoints in GCS objects named after
+// their size.
+type CheckpointArchiveStorage struct {
+	bucket *gcs.BucketHandle
+}
+
+// NewCheckpointArchiveStorage creates a new GCS based checkpoint archive and
+// GCS client.
+//
+// Checkpoints will be stored under staticct.CheckpointsPrefix.
+func NewCheckpointArchiveStorage(ctx context.Context, bucket string, gcsClient *gcs.Client) (*CheckpointArchiveStorage, error) {
+	if gcsClient == nil {
+		c, err := gcs.NewClient(ctx, gcs.WithJSONReads())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCS client: %v", err)
+		}
+		gcsClient = c
+	}
+	return &CheckpointArchiveStorage{bucket: gcsClient.Bucket(bucket)}, nil
+}
+
+// AddCheckpoint stores cp under its size, unless a checkpoint of this size is
+// already archived.
+func (s *CheckpointArchiveStorage) AddCheckpoint(ctx context.Context, size uint64, cp []byte) error {
+	objName := path.Join(staticct.CheckpointsPrefix, strconv.FormatUint(size, 10))
+	w := s.bucket.Object(objName).If(gcs.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ContentType = staticct.CheckpointsContentType
+	if _, err := w.Write(cp); err != nil {
+		return fmt.Errorf("failed to write object %q to bucket %q: %w", objName, s.bucket.BucketName(), err)
+	}
+	if err := w.Close(); err != nil {
+		// A checkpoint of this size is already archived. As for issuers, this
+		// is communicated differently over HTTP and gRPC.
+		if ee, ok := err.(*googleapi.Error); ok && ee.Code == http.StatusPreconditionFailed {
+			return nil
+		} else if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
+			return nil
+		}
+		return fmt.Errorf("failed to close write on %q: %v", objName, err)
+	}
+	return nil
+}
+
+// PruneCheckpoints deletes checkpoints whose object was created before t.
+func (s *CheckpointArchiveStorage) PruneCheckpoints(ctx context.Context, t time.Time) (int, error) {
+	n := 0
+	var errs []error
+	it := s.bucket.Objects(ctx, &gcs.Query{Prefix: staticct.CheckpointsPrefix})
+	for {
+		attr, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("failed to list objects in bucket %q under prefix %q: %v", s.bucket.BucketName(), staticct.CheckpointsPrefix, err)
+		}
+		if !attr.Created.Before(t) {
+			continue
+		}
+		if err := s.bucket.Object(attr.Name).Delete(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete object %q: %v", attr.Name, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+	"github.com/transparency-dev/tesseract/storage"
+)
+
+const issuerQueueTable = "TesseraCTIssuerQueue"
+
+// IssuerQueueStorage is a key value store backed by a Spanner table, to
+// durably queue issuers waiting to be written to GCS.
+//
+// Spanner reads are strongly consistent, so the queue can be shared by all the
+// instances of a log.
+type IssuerQueueStorage struct {
+	client *spanner.Client
+}
+
+// NewIssuerQueueStorage creates a new Spanner based issuer queue storage in
+// spannerDB, to be used by a storage.IssuerQueue. If spannerClient is nil, a
+// new Spanner client is created.
+//
+// If it doesn't exist, NewIssuerQueueStorage creates a TesseraCTIssuerQueue
+// table.
+func NewIssuerQueueStorage(ctx context.Context, spannerDB string, spannerClient *spanner.Client) (*IssuerQueueStorage, error) {
+	adminClient, err := database.NewDatabaseAdminClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Spanner admin client: %v", err)
+	}
+	defer func() {
+		if err := adminClient.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close Spanner admin client", slog.Any("error", err))
+		}
+	}()
+	op, err := adminClient.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+		Database:   spannerDB,
+		Statements: []string{fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id STRING(64) NOT NULL, data BYTES(MAX) NOT NULL) PRIMARY KEY (id)", issuerQueueTable)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table %q: %v", issuerQueueTable, err)
+	}
+	if err := op.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("failed to wait for table %q to be created: %v", issuerQueueTable, err)
+	}
+
+	if spannerClient == nil {
+		c, err := spanner.NewClient(ctx, spannerDB)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Spanner client: %v", err)
+		}
+		spannerClient = c
+	}
+	return &IssuerQueueStorage{client: spannerClient}, nil
+}
+
+// AddIfNotExist stores values under their Key.
+//
+// Keys are the hash of their value, so values which are already stored are
+// rewritten as is.
+func (s *IssuerQueueStorage) AddIfNotExist(ctx context.Context, kv []storage.KV) error {
+	ms := make([]*spanner.Mutation, 0, len(kv))
+	for _, kv := range kv {
+		ms = append(ms, spanner.InsertOrUpdate(issuerQueueTable, []string{"id", "data"}, []any{string(kv.K), kv.V}))
+	}
+	if _, err := s.client.Apply(ctx, ms); err != nil {
+		return fmt.Errorf("failed to write to %s: %v", issuerQueueTable, err)
+	}
+	return nil
+}
+
+// LoadAll returns all the key values in the table.
+func (s *IssuerQueueStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
+	kvs := []storage.KV{}
+	it := s.client.Single().Read(ctx, issuerQueueTable, spanner.AllKeys(), []string{"id", "data"})
+	if err := it.Do(func(r *spanner.Row) error {
+		var k string
+		var v []byte
+		if err := r.Columns(&k, &v); err != nil {
+			return err
+		}
+		kvs = append(kvs, storage.KV{K: []byte(k), V: v})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", issuerQueueTable, err)
+	}
+	return kvs, nil
+}
+
+// Delete removes the values stored under keys, if any.
+func (s *IssuerQueueStorage) Delete(ctx context.Context, keys [][]byte) error {
+	ks := make([]spanner.KeySet, 0, len(keys))
+	for _, k := range keys {
+		ks = append(ks, spanner.Key{string(k)})
+	}
+	if _, err := s.client.Apply(ctx, []*spanner.Mutation{spanner.Delete(issuerQueueTable, spanner.KeySets(ks...))}); err != nil {
+		return fmt.Errorf("failed to delete %d keys from %s: %v", len(keys), issuerQueueTable, err)
+	}
+	return nil
+}
@@ -85,6 +85,27 @@ func (s *IssuersStorage) objNameToKey(objName string) []byte {
 	return []byte(strings.TrimPrefix(objName, s.prefix))
 }
 
+// List returns the keys of all the values in the bucket under the prefix.
+func (s *IssuersStorage) List(ctx context.Context) ([][]byte, error) {
+	keys := [][]byte{}
+	q := &gcs.Query{Prefix: s.prefix}
+	if err := q.SetAttrSelection([]string{"Name"}); err != nil {
+		return nil, fmt.Errorf("failed to set query attributes: %v", err)
+	}
+	it := s.bucket.Objects(ctx, q)
+	for {
+		attr, err := it.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return keys, fmt.Errorf("failed to list objects in bucket %q under prefix %q: %v", s.bucket.BucketName(), s.prefix, err)
+		}
+		keys = append(keys, s.objNameToKey(attr.Name))
+	}
+	return keys, nil
+}
+
 // LoadAll loads all the values in the bucket under the prefix.
 func (s *IssuersStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
 	errs := []error(nil)
@@ -0,0 +1,58 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+// MetadataStorage stores the log metadata document in a GCS object.
+type MetadataStorage struct {
+	bucket *gcs.BucketHandle
+}
+
+// NewMetadataStorage creates a new GCS based metadata storage in bucket.
+//
+// gcsClient is used to access the bucket. If it is nil, a new GCS client is
+// created.
+//
+// The document will be stored under staticct.MetadataPath, next to the checkpoint.
+func NewMetadataStorage(ctx context.Context, bucket string, gcsClient *gcs.Client) (*MetadataStorage, error) {
+	if gcsClient == nil {
+		c, err := gcs.NewClient(ctx, gcs.WithJSONReads())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCS client: %v", err)
+		}
+		gcsClient = c
+	}
+	return &MetadataStorage{bucket: gcsClient.Bucket(bucket)}, nil
+}
+
+// SetMetadata replaces the metadata document with data.
+func (s *MetadataStorage) SetMetadata(ctx context.Context, data []byte) error {
+	w := s.bucket.Object(staticct.MetadataPath).NewWriter(ctx)
+	w.ContentType = staticct.MetadataContentType
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write object %q to bucket %q: %w", staticct.MetadataPath, s.bucket.BucketName(), err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close write on %q: %v", staticct.MetadataPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/logger"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// issuerQueueMaxBatch is the maximum number of queued issuers written at once.
+	issuerQueueMaxBatch = 256
+	// issuerQueueMinBackoff and issuerQueueMaxBackoff bound the time between
+	// two attempts to write queued issuers.
+	issuerQueueMinBackoff = 100 * time.Millisecond
+	issuerQueueMaxBackoff = 30 * time.Second
+	// issuerQueueFlushTimeout is how long signing a checkpoint waits for
+	// queued issuers to be written.
+	issuerQueueFlushTimeout = 30 * time.Second
+)
+
+// IssuerQueueStorage durably stores issuers waiting to be written to an
+// IssuerStorage, under their hex encoded sha256.
+//
+// It must be shared by all the instances of a log, and LoadAll must return
+// every issuer added before it was called.
+type IssuerQueueStorage interface {
+	AddIfNotExist(ctx context.Context, kv []KV) error
+	LoadAll(ctx context.Context) ([]KV, error)
+	Delete(ctx context.Context, keys [][]byte) error
+}
+
+// IssuerQueue is a durable queue of issuer writes.
+//
+// It allows SCTs to be issued when the issuer storage is temporarily
+// unavailable. Issuers are queued in an IssuerQueueStorage, which must not
+// depend on the issuer storage being available, and written to the issuer
+// storage in the background, with retries.
+//
+// Checkpoints must be signed with a signer wrapped by WrapSigner, so that a
+// checkpoint is never published before the issuers of the entries it covers
+// are in the issuer storage. Since the queue storage is shared by all the
+// instances of a log, the instance signing a checkpoint also writes the
+// issuers queued by the other ones.
+type IssuerQueue struct {
+	q IssuerQueueStorage
+	s IssuerStorage
+
+	// mu prevents concurrent flushes by this instance.
+	mu sync.Mutex
+	// notify wakes up the writer when issuers are queued.
+	notify chan struct{}
+}
+
+// NewIssuerQueue returns an IssuerQueue of writes to s, queued in q.
+func NewIssuerQueue(q IssuerQueueStorage, s IssuerStorage) *IssuerQueue {
+	issuerQueueOnce.Do(setupIssuerQueueMetrics)
+	return &IssuerQueue{
+		q:      q,
+		s:      s,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Enqueue durably queues kvs to be written to the issuer storage.
+func (q *IssuerQueue) Enqueue(ctx context.Context, kvs []KV) error {
+	if len(kvs) == 0 {
+		return nil
+	}
+	if err := q.q.AddIfNotExist(ctx, kvs); err != nil {
+		return fmt.Errorf("failed to queue issuers: %v", err)
+	}
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Flush writes every queued issuer to the issuer storage, including the ones
+// queued by other instances of the log, and removes them from the queue.
+func (q *IssuerQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	kvs, err := q.q.LoadAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load queued issuers: %v", err)
+	}
+	issuerQueueDepth.Record(ctx, int64(len(kvs)))
+	valid := make([]KV, 0, len(kvs))
+	for _, kv := range kvs {
+		// Skip values which are still being written, or were never fully
+		// written, to the queue.
+		if id := sha256.Sum256(kv.V); string(kv.K) != hex.EncodeToString(id[:]) {
+			logger.DebugExtraContext(ctx, "Skipping queued issuer not matching its key", slog.String("key", string(kv.K)))
+			continue
+		}
+		valid = append(valid, kv)
+	}
+	for len(valid) > 0 {
+		batch := valid[:min(len(valid), issuerQueueMaxBatch)]
+		valid = valid[len(batch):]
+		if err := q.s.AddIfNotExist(ctx, batch); err != nil {
+			return fmt.Errorf("failed to write queued issuers: %v", err)
+		}
+		keys := make([][]byte, 0, len(batch))
+		for _, kv := range batch {
+			keys = append(keys, kv.K)
+		}
+		// Issuers are only removed from the queue once they've been written.
+		// If this fails, they're written again, which is harmless.
+		if err := q.q.Delete(ctx, keys); err != nil {
+			return fmt.Errorf("failed to remove written issuers from the queue: %v", err)
+		}
+	}
+	return nil
+}
+
+// run flushes the queue on startup, and then every time this instance queues
+// issuers, until ctx is done.
+//
+// Failed flushes are retried with an exponential backoff.
+func (q *IssuerQueue) run(ctx context.Context) {
+	for {
+		for backoff := issuerQueueMinBackoff; ; backoff = min(2*backoff, issuerQueueMaxBackoff) {
+			err := q.Flush(ctx)
+			if err == nil {
+				break
+			}
+			issuerQueueFailures.Add(ctx, 1)
+			slog.WarnContext(ctx, "Failed to write queued issuers, will retry", slog.Duration("backoff", backoff), slog.Any("error", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.notify:
+		}
+	}
+}
+
+// WrapSigner returns a signer which flushes the queue before signing with s.
+//
+// Used to sign checkpoints, it guarantees that the issuers of the entries a
+// checkpoint covers are in the issuer storage before the checkpoint is
+// published, since the issuers of an entry are queued before the entry is
+// added to the log. If the queue can't be flushed, signing fails, and the log
+// retries publishing a checkpoint later.
+func (q *IssuerQueue) WrapSigner(s note.Signer) note.Signer {
+	return &flushingSigner{Signer: s, q: q}
+}
+
+type flushingSigner struct {
+	note.Signer
+	q *IssuerQueue
+}
+
+func (s *flushingSigner) Sign(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), issuerQueueFlushTimeout)
+	defer cancel()
+	if err := s.q.Flush(ctx); err != nil {
+		issuerQueueFailures.Add(ctx, 1)
+		return nil, fmt.Errorf("failed to flush issuer queue before signing checkpoint: %v", err)
+	}
+	return s.Signer.Sign(msg)
+}
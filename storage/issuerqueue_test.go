@@ -0,0 +1,236 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/mod/sumdb/note"
+)
+
+// memIssuerQueue is an IssuerQueueStorage in memory, which can be shared by
+// several IssuerQueues like the instances of a log share a database.
+type memIssuerQueue struct {
+	mu  sync.Mutex
+	kvs map[string][]byte
+}
+
+func (m *memIssuerQueue) AddIfNotExist(_ context.Context, kvs []KV) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, kv := range kvs {
+		m.kvs[string(kv.K)] = kv.V
+	}
+	return nil
+}
+
+func (m *memIssuerQueue) LoadAll(_ context.Context) ([]KV, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kvs := []KV{}
+	for _, k := range slices.Sorted(maps.Keys(m.kvs)) {
+		kvs = append(kvs, KV{K: []byte(k), V: m.kvs[k]})
+	}
+	return kvs, nil
+}
+
+func (m *memIssuerQueue) Delete(_ context.Context, keys [][]byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range keys {
+		delete(m.kvs, string(k))
+	}
+	return nil
+}
+
+func (m *memIssuerQueue) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.kvs)
+}
+
+// flakyIssuerStorage fails writes until healthy is set, and records the keys
+// it wrote.
+type flakyIssuerStorage struct {
+	mu      sync.Mutex
+	healthy bool
+	written map[string]bool
+}
+
+func (s *flakyIssuerStorage) AddIfNotExist(_ context.Context, kvs []KV) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.healthy {
+		return errors.New("storage unavailable")
+	}
+	for _, kv := range kvs {
+		s.written[string(kv.K)] = true
+	}
+	return nil
+}
+
+func (s *flakyIssuerStorage) setHealthy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.healthy = true
+}
+
+func (s *flakyIssuerStorage) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(maps.Keys(s.written))
+}
+
+// issuerKV returns the KV under which AddIssuerChain stores raw.
+func issuerKV(raw string) KV {
+	return KV{K: []byte(issuerKey(&x509.Certificate{Raw: []byte(raw)})), V: []byte(raw)}
+}
+
+func TestIssuerQueue(t *testing.T) {
+	m := &memIssuerQueue{kvs: map[string][]byte{}}
+	s := &flakyIssuerStorage{written: map[string]bool{}}
+	// Two instances of a log, sharing the queue storage.
+	q1 := NewIssuerQueue(m, s)
+	q2 := NewIssuerQueue(m, s)
+	a, b, c := issuerKV("a"), issuerKV("b"), issuerKV("c")
+	for _, kv := range []KV{a, b} {
+		if err := q1.Enqueue(t.Context(), []KV{kv}); err != nil {
+			t.Fatalf("Enqueue(): %v", err)
+		}
+	}
+	if err := q2.Enqueue(t.Context(), []KV{c}); err != nil {
+		t.Fatalf("Enqueue(): %v", err)
+	}
+
+	sk, v, err := note.GenerateKey(nil, "example.com/log")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer, err := note.NewSigner(sk)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	cp := &note.Note{Text: "example.com/log\n3\nAAAA\n"}
+	// Queued issuers can't be written, so checkpoints can't be signed.
+	if _, err := note.Sign(cp, q2.WrapSigner(signer)); err == nil {
+		t.Fatalf("note.Sign() succeeded with an unavailable issuer storage")
+	}
+
+	// The instance signing the checkpoint writes the issuers queued by both.
+	s.setHealthy()
+	n, err := note.Sign(cp, q2.WrapSigner(signer))
+	if err != nil {
+		t.Fatalf("note.Sign(): %v", err)
+	}
+	verifier, err := note.NewVerifier(v)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	if _, err := note.Open(n, note.VerifierList(verifier)); err != nil {
+		t.Errorf("note.Open(): %v", err)
+	}
+	want := []string{string(a.K), string(b.K), string(c.K)}
+	slices.Sort(want)
+	if got := s.keys(); !slices.Equal(got, want) {
+		t.Errorf("written=%q, want %q", got, want)
+	}
+	if got := m.len(); got != 0 {
+		t.Errorf("%d issuers left in the queue, want 0", got)
+	}
+}
+
+func TestIssuerQueueRun(t *testing.T) {
+	m := &memIssuerQueue{kvs: map[string][]byte{}}
+	s := &flakyIssuerStorage{written: map[string]bool{}}
+	q := NewIssuerQueue(m, s)
+	go q.run(t.Context())
+
+	const n = 100
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Go(func() {
+			if err := q.Enqueue(t.Context(), []KV{issuerKV(string(rune('a' + i)))}); err != nil {
+				t.Errorf("Enqueue(%d): %v", i, err)
+			}
+		})
+	}
+	wg.Wait()
+	// Queued issuers are written in the background, once the storage is back.
+	s.setHealthy()
+	for m.len() > 0 {
+		select {
+		case <-t.Context().Done():
+			t.Fatalf("%d issuers still queued", m.len())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if got := len(s.keys()); got != n {
+		t.Errorf("%d issuers written, want %d", got, n)
+	}
+}
+
+func TestIssuerQueueSkipsInvalid(t *testing.T) {
+	m := &memIssuerQueue{kvs: map[string][]byte{}}
+	s := &flakyIssuerStorage{healthy: true, written: map[string]bool{}}
+	q := NewIssuerQueue(m, s)
+	a := issuerKV("a")
+	partial := KV{K: issuerKV("b").K, V: []byte("partially written")}
+	if err := q.Enqueue(t.Context(), []KV{a, partial}); err != nil {
+		t.Fatalf("Enqueue(): %v", err)
+	}
+	if err := q.Flush(t.Context()); err != nil {
+		t.Fatalf("Flush(): %v", err)
+	}
+	if got, want := s.keys(), []string{string(a.K)}; !slices.Equal(got, want) {
+		t.Errorf("written=%q, want %q", got, want)
+	}
+	if got := m.len(); got != 1 {
+		t.Errorf("%d issuers left in the queue, want 1", got)
+	}
+}
+
+func TestAddIssuerChainQueued(t *testing.T) {
+	m := &memIssuerQueue{kvs: map[string][]byte{}}
+	s := &flakyIssuerStorage{written: map[string]bool{}}
+	cts := &CTStorage{
+		issuers:     newIssuerCache(s, 10),
+		issuerQueue: NewIssuerQueue(m, s),
+	}
+	chain := []*x509.Certificate{{Raw: []byte("a")}, {Raw: []byte("b")}}
+	// Issuers are queued while the issuer storage is unavailable.
+	if err := cts.AddIssuerChain(t.Context(), chain); err != nil {
+		t.Fatalf("AddIssuerChain(): %v", err)
+	}
+	if got := m.len(); got != 2 {
+		t.Fatalf("%d issuers queued, want 2", got)
+	}
+	// They're not queued again.
+	if err := m.Delete(t.Context(), [][]byte{[]byte(issuerKey(chain[0])), []byte(issuerKey(chain[1]))}); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+	if err := cts.AddIssuerChain(t.Context(), chain); err != nil {
+		t.Fatalf("AddIssuerChain() again: %v", err)
+	}
+	if got := m.len(); got != 0 {
+		t.Errorf("%d issuers queued again, want 0", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/transparency-dev/tesseract/internal/logger"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/singleflight"
+)
+
+// issuerCache remembers the keys of the issuers known to be in an
+// IssuerStorage, or queued to be written to it, to avoid writing them again.
+//
+// It does not keep a copy of the certs, only their sha256. Writes of the same
+// issuer by concurrent requests are coalesced into a single write.
+type issuerCache struct {
+	s      IssuerStorage
+	keys   *lruCache[string, struct{}]
+	writes singleflight.Group
+}
+
+// newIssuerCache returns an issuerCache holding up to size keys.
+func newIssuerCache(s IssuerStorage, size int) *issuerCache {
+	issuerCacheOnce.Do(setupIssuerCacheMetrics)
+	return &issuerCache{
+		s:    s,
+		keys: newLRUCache[string, struct{}](size),
+	}
+}
+
+// warm adds the keys of the issuers already in storage to the cache, if the
+// storage implements IssuerKeyLister.
+//
+// This avoids a burst of writes to the storage when a fleet of servers
+// restarts.
+func (c *issuerCache) warm(ctx context.Context) {
+	l, ok := c.s.(IssuerKeyLister)
+	if !ok {
+		return
+	}
+	keys, err := l.List(ctx)
+	if err != nil {
+		// Keys which could be listed are still useful.
+		slog.WarnContext(ctx, "Failed to list all issuers to warm-start the issuer cache", slog.Any("error", err))
+	}
+	for _, k := range keys {
+		c.keys.Add(string(k), struct{}{})
+	}
+	slog.InfoContext(ctx, "Warm-started issuer cache", slog.Int("listed", len(keys)), slog.Int("cached", c.keys.Len()))
+}
+
+// missing returns the issuers in kvs which aren't known to be in storage.
+func (c *issuerCache) missing(ctx context.Context, kvs []KV) []KV {
+	req := []KV{}
+	for _, kv := range kvs {
+		_, ok := c.keys.Get(string(kv.K))
+		issuerCacheLookups.Add(ctx, 1, metric.WithAttributes(cacheHitKey.Bool(ok)))
+		if ok {
+			logger.DebugExtraContext(ctx, "issuerCache: found in local key cache", slog.String("key", string(kv.K)))
+			continue
+		}
+		req = append(req, kv)
+	}
+	return req
+}
+
+// add caches the keys of kvs.
+func (c *issuerCache) add(kvs []KV) {
+	for _, kv := range kvs {
+		c.keys.Add(string(kv.K), struct{}{})
+	}
+}
+
+// write writes kvs to the storage, and caches their keys.
+func (c *issuerCache) write(ctx context.Context, kvs []KV) error {
+	results := []<-chan singleflight.Result{}
+	for _, kv := range kvs {
+		k := string(kv.K)
+		results = append(results, c.writes.DoChan(k, func() (any, error) {
+			// The write is shared with other requests, so it must not be
+			// cancelled with the one which started it.
+			if err := c.s.AddIfNotExist(context.WithoutCancel(ctx), []KV{kv}); err != nil {
+				return nil, err
+			}
+			c.keys.Add(k, struct{}{})
+			return nil, nil
+		}))
+	}
+	errs := []error{}
+	for _, r := range results {
+		var res singleflight.Result
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res = <-r:
+		}
+		if res.Shared {
+			issuerWritesCoalesced.Add(ctx, 1)
+		}
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("issuerStorage.AddIfNotExist(): error storing issuer data in the underlying IssuerStorage: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIssuerStorage counts writes per key, and blocks them until release is
+// closed.
+type fakeIssuerStorage struct {
+	mu      sync.Mutex
+	writes  map[string]int
+	keys    [][]byte
+	listErr error
+	release chan struct{}
+}
+
+func (s *fakeIssuerStorage) AddIfNotExist(_ context.Context, kvs []KV) error {
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, kv := range kvs {
+		s.writes[string(kv.K)]++
+	}
+	return nil
+}
+
+func (s *fakeIssuerStorage) List(_ context.Context) ([][]byte, error) {
+	return s.keys, s.listErr
+}
+
+// issuerKey returns the key under which AddIssuerChain stores cert.
+func issuerKey(cert *x509.Certificate) string {
+	id := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(id[:])
+}
+
+func TestAddIssuerChain(t *testing.T) {
+	a := &x509.Certificate{Raw: []byte("a")}
+	b := &x509.Certificate{Raw: []byte("b")}
+	known := &x509.Certificate{Raw: []byte("known")}
+	s := &fakeIssuerStorage{
+		writes:  map[string]int{},
+		keys:    [][]byte{[]byte(issuerKey(known))},
+		listErr: errors.New("partial listing"),
+		release: make(chan struct{}),
+	}
+	cts := &CTStorage{issuers: newIssuerCache(s, 10)}
+	cts.issuers.warm(t.Context())
+
+	// Concurrent requests writing the same issuers share the writes.
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			if err := cts.AddIssuerChain(t.Context(), []*x509.Certificate{a, b, known}); err != nil {
+				t.Errorf("AddIssuerChain(): %v", err)
+			}
+		})
+	}
+	// Give all requests a chance to join the in-flight writes.
+	time.Sleep(100 * time.Millisecond)
+	close(s.release)
+	wg.Wait()
+	// Issuers which were written are cached.
+	if err := cts.AddIssuerChain(t.Context(), []*x509.Certificate{a}); err != nil {
+		t.Errorf("AddIssuerChain(): %v", err)
+	}
+
+	want := map[string]int{issuerKey(a): 1, issuerKey(b): 1}
+	if len(s.writes) != len(want) || s.writes[issuerKey(a)] != 1 || s.writes[issuerKey(b)] != 1 {
+		t.Errorf("writes=%v, want %v", s.writes, want)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache[int, string](2)
+	c.Add(1, "one")
+	c.Add(2, "two")
+	// Reading 1 makes 2 the least recently used entry.
+	if v, ok := c.Get(1); !ok || v != "one" {
+		t.Errorf("Get(1)=%q, %t, want %q, true", v, ok, "one")
+	}
+	c.Add(3, "three")
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Get(2) found an evicted entry")
+	}
+	c.Add(1, "uno")
+	for k, want := range map[int]string{1: "uno", 3: "three"} {
+		if v, ok := c.Get(k); !ok || v != want {
+			t.Errorf("Get(%d)=%q, %t, want %q, true", k, v, ok, want)
+		}
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len()=%d, want 2", got)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tesseract/internal/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	mergeDelayOnce        sync.Once
+	mergeDelay            metric.Float64Histogram
+	mmdBreaches           metric.Int64Counter
+	outstandingSCTs       metric.Int64Gauge
+	outstandingSCTsMaxAge metric.Float64Gauge
+	mmdAlert              metric.Int64Gauge
+)
+
+func setupMergeDelayMetrics() {
+	mergeDelay = mustCreate(meter.Float64Histogram("tesseract.merge_delay",
+		metric.WithDescription("Time between issuing an SCT and observing the first checkpoint covering its entry"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(otel.MergeDelayHistogramBuckets...)))
+
+	mmdBreaches = mustCreate(meter.Int64Counter("tesseract.merge_delay.mmd_breach.count",
+		metric.WithDescription("SCTs whose entry was covered by a checkpoint after the maximum merge delay"),
+		metric.WithUnit("{sct}")))
+
+	outstandingSCTs = mustCreate(meter.Int64Gauge("tesseract.merge_delay.outstanding.count",
+		metric.WithDescription("SCTs whose entry isn't covered by a checkpoint yet"),
+		metric.WithUnit("{sct}")))
+
+	outstandingSCTsMaxAge = mustCreate(meter.Float64Gauge("tesseract.merge_delay.outstanding.max_age",
+		metric.WithDescription("Age of the oldest SCT whose entry isn't covered by a checkpoint yet"),
+		metric.WithUnit("s")))
+
+	mmdAlert = mustCreate(meter.Int64Gauge("tesseract.merge_delay.alert",
+		metric.WithDescription("Set to 1 when an outstanding SCT is close to, or past, the maximum merge delay, 0 otherwise"),
+		metric.WithUnit("{alert}")))
+}
+
+// issuedBundle holds the issue times of the SCTs of an entry bundle's index
+// range which aren't covered by a checkpoint yet.
+type issuedBundle struct {
+	issued [layout.EntryBundleWidth]time.Time
+	n      int
+}
+
+// MergeDelayTracker measures the time between issuing an SCT and the
+// publication of the first checkpoint covering its entry.
+//
+// It watches checkpoints published by the log, and raises an alert when SCTs
+// have been outstanding for close to the log's maximum merge delay. The
+// tracker's state is only kept in memory: SCTs issued before a restart are not
+// tracked.
+type MergeDelayTracker struct {
+	readCheckpoint func(ctx context.Context) ([]byte, error)
+	mmd            time.Duration
+	alertAge       time.Duration
+
+	mu sync.Mutex
+	// outstanding holds the issue times of SCTs not yet covered by a
+	// checkpoint, keyed by entry bundle index.
+	outstanding map[uint64]*issuedBundle
+	// size is the size of the latest checkpoint observed.
+	size     uint64
+	alerting bool
+}
+
+// NewMergeDelayTracker returns a MergeDelayTracker reading checkpoints with
+// readCheckpoint.
+//
+// The tracker alerts when an SCT has been outstanding for more than alertRatio
+// times the maximum merge delay mmd.
+func NewMergeDelayTracker(readCheckpoint func(ctx context.Context) ([]byte, error), mmd time.Duration, alertRatio float64) *MergeDelayTracker {
+	mergeDelayOnce.Do(setupMergeDelayMetrics)
+	return &MergeDelayTracker{
+		readCheckpoint: readCheckpoint,
+		mmd:            mmd,
+		alertAge:       time.Duration(float64(mmd) * alertRatio),
+		outstanding:    make(map[uint64]*issuedBundle),
+	}
+}
+
+// Run watches the log's checkpoints every interval, until ctx is done.
+func (m *MergeDelayTracker) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		now := time.Now()
+		cpRaw, err := m.readCheckpoint(ctx)
+		switch {
+		case errors.Is(err, os.ErrNotExist) || (err == nil && cpRaw == nil):
+			// Nothing has been published yet.
+		case err != nil:
+			slog.WarnContext(ctx, "Merge delay tracker failed to read checkpoint", slog.Any("error", err))
+		default:
+			if cp, err := parseCheckpoint(cpRaw); err != nil {
+				slog.WarnContext(ctx, "Merge delay tracker failed to parse checkpoint", slog.Any("error", err))
+			} else {
+				m.Integrated(ctx, cp.Size, now)
+			}
+		}
+		// Outstanding SCTs are checked even if no checkpoint could be read,
+		// since that's when they are the most likely to be late.
+		m.CheckOutstanding(ctx, now)
+	}
+}
+
+// track returns a future which resolves to f's result, and records that an
+// SCT was issued at t for the entry at f's index, unless it's a duplicate.
+//
+// Like Tessera's, the returned future can be called several times.
+func (m *MergeDelayTracker) track(ctx context.Context, f tessera.IndexFuture, t time.Time) tessera.IndexFuture {
+	return sync.OnceValues(func() (tessera.Index, error) {
+		idx, err := f()
+		if err == nil && !idx.IsDup {
+			m.Issued(ctx, idx.Index, t)
+		}
+		return idx, err
+	})
+}
+
+// Issued records that an SCT was issued at t for the entry at index.
+func (m *MergeDelayTracker) Issued(ctx context.Context, index uint64, t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if index < m.size {
+		// The entry is already covered by the latest checkpoint observed.
+		m.recordDelay(ctx, time.Since(t))
+		return
+	}
+	b, ok := m.outstanding[index/layout.EntryBundleWidth]
+	if !ok {
+		b = &issuedBundle{}
+		m.outstanding[index/layout.EntryBundleWidth] = b
+	}
+	if b.issued[index%layout.EntryBundleWidth].IsZero() {
+		b.n++
+	}
+	b.issued[index%layout.EntryBundleWidth] = t
+}
+
+// Integrated records that a checkpoint of the given size was observed at
+// now, and measures the merge delay of the SCTs it covers.
+func (m *MergeDelayTracker) Integrated(ctx context.Context, size uint64, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if size <= m.size {
+		return
+	}
+	m.size = size
+	for bIdx, b := range m.outstanding {
+		first := bIdx * layout.EntryBundleWidth
+		if first >= size {
+			continue
+		}
+		for i, t := range b.issued {
+			if t.IsZero() || first+uint64(i) >= size {
+				continue
+			}
+			m.recordDelay(ctx, now.Sub(t))
+			b.issued[i] = time.Time{}
+			b.n--
+		}
+		if b.n == 0 {
+			delete(m.outstanding, bIdx)
+		}
+	}
+}
+
+// recordDelay must be called with m.mu held.
+func (m *MergeDelayTracker) recordDelay(ctx context.Context, d time.Duration) {
+	mergeDelay.Record(ctx, d.Seconds())
+	if m.mmd > 0 && d > m.mmd {
+		mmdBreaches.Add(ctx, 1)
+	}
+}
+
+// Outstanding returns the number of SCTs whose entry isn't covered by a
+// checkpoint yet, and the age of the oldest one at now.
+func (m *MergeDelayTracker) Outstanding(now time.Time) (int, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	var oldest time.Time
+	for _, b := range m.outstanding {
+		n += b.n
+		for _, t := range b.issued {
+			if !t.IsZero() && (oldest.IsZero() || t.Before(oldest)) {
+				oldest = t
+			}
+		}
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return n, now.Sub(oldest)
+}
+
+// CheckOutstanding exports metrics about outstanding SCTs, and raises an
+// alert if one of them is older than the alerting threshold at now.
+//
+// It returns whether the alert is raised.
+func (m *MergeDelayTracker) CheckOutstanding(ctx context.Context, now time.Time) bool {
+	n, age := m.Outstanding(now)
+	outstandingSCTs.Record(ctx, int64(n))
+	outstandingSCTsMaxAge.Record(ctx, age.Seconds())
+
+	alerting := m.alertAge > 0 && age >= m.alertAge
+	m.mu.Lock()
+	changed := alerting != m.alerting
+	m.alerting = alerting
+	m.mu.Unlock()
+	switch {
+	case alerting:
+		mmdAlert.Record(ctx, 1)
+		if changed {
+			slog.ErrorContext(ctx, "Outstanding SCTs are approaching the maximum merge delay", slog.Int("outstanding", n), slog.Duration("max_age", age), slog.Duration("mmd", m.mmd))
+		}
+	default:
+		mmdAlert.Record(ctx, 0)
+		if changed {
+			slog.InfoContext(ctx, "Outstanding SCTs are back within the maximum merge delay alerting threshold", slog.Int("outstanding", n))
+		}
+	}
+	return alerting
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract/storage"
+)
+
+func TestMergeDelayTracker(t *testing.T) {
+	ctx := t.Context()
+	start := time.Now()
+	m := storage.NewMergeDelayTracker(nil, time.Minute, 0.5)
+
+	for _, idx := range []uint64{0, 1, 255, 256, 300} {
+		m.Issued(ctx, idx, start.Add(time.Duration(idx)*time.Millisecond))
+	}
+	if n, age := m.Outstanding(start.Add(time.Second)); n != 5 || age != time.Second {
+		t.Errorf("Outstanding()=%d, %v, want 5, 1s", n, age)
+	}
+
+	// A checkpoint covering the first entry bundle and part of the second.
+	m.Integrated(ctx, 257, start.Add(2*time.Second))
+	if n, age := m.Outstanding(start.Add(2 * time.Second)); n != 1 || age != 2*time.Second-300*time.Millisecond {
+		t.Errorf("Outstanding() after Integrated(257)=%d, %v, want 1, 1.7s", n, age)
+	}
+	// Smaller checkpoints don't change anything.
+	m.Integrated(ctx, 3, start.Add(3*time.Second))
+	if n, _ := m.Outstanding(start.Add(3 * time.Second)); n != 1 {
+		t.Errorf("Outstanding() after Integrated(3)=%d, want 1", n)
+	}
+	// SCTs for entries already covered by a checkpoint aren't outstanding.
+	m.Issued(ctx, 10, start)
+	if n, _ := m.Outstanding(start.Add(3 * time.Second)); n != 1 {
+		t.Errorf("Outstanding() after Issued(10)=%d, want 1", n)
+	}
+
+	if m.CheckOutstanding(ctx, start.Add(29*time.Second)) {
+		t.Errorf("CheckOutstanding() raised an alert before half of the MMD")
+	}
+	if !m.CheckOutstanding(ctx, start.Add(31*time.Second)) {
+		t.Errorf("CheckOutstanding() didn't raise an alert after half of the MMD")
+	}
+
+	m.Integrated(ctx, 301, start.Add(32*time.Second))
+	if n, age := m.Outstanding(start.Add(32 * time.Second)); n != 0 || age != 0 {
+		t.Errorf("Outstanding() after Integrated(301)=%d, %v, want 0, 0", n, age)
+	}
+	if m.CheckOutstanding(ctx, start.Add(32*time.Second)) {
+		t.Errorf("CheckOutstanding() raised an alert without outstanding SCTs")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const checkpointsTable = "TesseraCTCheckpoints"
+
+// CheckpointArchiveStorage archives checkpoints in a MySQL table keyed by
+// their size.
+type CheckpointArchiveStorage struct {
+	db *sql.DB
+}
+
+// NewCheckpointArchiveStorage creates a new MySQL based checkpoint archive.
+//
+// If it doesn't exist, NewCheckpointArchiveStorage creates a
+// TesseraCTCheckpoints table.
+func NewCheckpointArchiveStorage(ctx context.Context, db *sql.DB) (*CheckpointArchiveStorage, error) {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `"+checkpointsTable+"` (`size` BIGINT UNSIGNED NOT NULL, `data` BLOB NOT NULL, `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, PRIMARY KEY(`size`), INDEX(`created`))"); err != nil {
+		return nil, fmt.Errorf("failed to create table %q: %v", checkpointsTable, err)
+	}
+	return &CheckpointArchiveStorage{db: db}, nil
+}
+
+// AddCheckpoint stores cp under its size, unless a checkpoint of this size is
+// already archived.
+func (s *CheckpointArchiveStorage) AddCheckpoint(ctx context.Context, size uint64, cp []byte) error {
+	if _, err := s.db.ExecContext(ctx, "INSERT IGNORE INTO `"+checkpointsTable+"` (`size`, `data`) VALUES (?, ?)", size, cp); err != nil {
+		return fmt.Errorf("failed to archive checkpoint of size %d: %v", size, err)
+	}
+	return nil
+}
+
+// PruneCheckpoints deletes checkpoints which were archived before t.
+//
+// t is compared with the database clock, to the second, so that it doesn't
+// depend on the session's time zone.
+func (s *CheckpointArchiveStorage) PruneCheckpoints(ctx context.Context, t time.Time) (int, error) {
+	age := int64(time.Since(t) / time.Second)
+	r, err := s.db.ExecContext(ctx, "DELETE FROM `"+checkpointsTable+"` WHERE `created` < NOW() - INTERVAL ? SECOND", age)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune checkpoints: %v", err)
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pruned checkpoints: %v", err)
+	}
+	return int(n), nil
+}
+
+// Checkpoint returns the archived checkpoint of the given size.
+//
+// If there is no such checkpoint, Checkpoint returns an error wrapping
+// os.ErrNotExist.
+func (s *CheckpointArchiveStorage) Checkpoint(ctx context.Context, size uint64) ([]byte, error) {
+	var v []byte
+	if err := s.db.QueryRowContext(ctx, "SELECT `data` FROM `"+checkpointsTable+"` WHERE `size` = ?", size).Scan(&v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("checkpoint of size %d not found: %w", size, os.ErrNotExist)
+		}
+		return nil, fmt.Errorf("failed to read checkpoint of size %d: %v", size, err)
+	}
+	return v, nil
+}
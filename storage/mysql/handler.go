@@ -0,0 +1,192 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+const (
+	// Full tiles and issuers never change.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// Partial tiles are superseded once the tree grows past them.
+	partialCacheControl    = "public, max-age=300"
+	checkpointCacheControl = "no-cache"
+)
+
+// LogReader reads log data written by Tessera's MySQL driver.
+//
+// It is implemented by tessera.LogReader.
+type LogReader interface {
+	ReadCheckpoint(ctx context.Context) ([]byte, error)
+	ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error)
+	ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error)
+}
+
+// IssuerReader reads issuer certificates by their hex encoded sha256.
+//
+// It is implemented by IssuersStorage.
+type IssuerReader interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// MetadataReader reads the log metadata document.
+//
+// It is implemented by MetadataStorage.
+type MetadataReader interface {
+	Metadata(ctx context.Context) ([]byte, error)
+}
+
+// CheckpointArchiveReader reads archived checkpoints by their size.
+//
+// It is implemented by CheckpointArchiveStorage.
+type CheckpointArchiveReader interface {
+	Checkpoint(ctx context.Context, size uint64) ([]byte, error)
+}
+
+// ReadHandlerOpts holds the sources the read handlers serve data from.
+type ReadHandlerOpts struct {
+	Reader   LogReader
+	Issuers  IssuerReader
+	Metadata MetadataReader
+	// Checkpoints serves the checkpoint archive, if set.
+	Checkpoints CheckpointArchiveReader
+}
+
+// RegisterReadHandlers registers handlers serving the static-ct-api monitoring
+// APIs under prefix: https://c2sp.org/static-ct-api#monitoring-apis.
+//
+// Unlike object storage, MySQL can't serve these resources directly, so logs
+// running on Tessera's MySQL driver need to serve them from TesseraCT.
+// Metadata and Checkpoints are optional.
+func RegisterReadHandlers(mux *http.ServeMux, prefix string, opts ReadHandlerOpts) {
+	prefix = strings.TrimRight(prefix, "/")
+	mux.HandleFunc("GET "+prefix+"/checkpoint", func(w http.ResponseWriter, r *http.Request) {
+		cp, err := opts.Reader.ReadCheckpoint(r.Context())
+		if err == nil && cp == nil {
+			err = os.ErrNotExist
+		}
+		serve(w, r, cp, err, "text/plain; charset=utf-8", checkpointCacheControl)
+	})
+	mux.HandleFunc("GET "+prefix+"/tile/{level}/{index...}", func(w http.ResponseWriter, r *http.Request) {
+		index, p, err := parseTileIndex(r.PathValue("index"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid tile index: %v", err), http.StatusBadRequest)
+			return
+		}
+		cc := immutableCacheControl
+		if p > 0 {
+			cc = partialCacheControl
+		}
+		if l := r.PathValue("level"); l == "data" {
+			b, err := opts.Reader.ReadEntryBundle(r.Context(), index, p)
+			serve(w, r, b, err, "application/octet-stream", cc)
+		} else {
+			level, err := strconv.ParseUint(l, 10, 8)
+			if err != nil || level > 63 {
+				http.Error(w, fmt.Sprintf("invalid tile level %q", l), http.StatusBadRequest)
+				return
+			}
+			b, err := opts.Reader.ReadTile(r.Context(), level, index, p)
+			serve(w, r, b, err, "application/octet-stream", cc)
+		}
+	})
+	mux.HandleFunc("GET "+prefix+"/"+staticct.IssuersPrefix+"{key}", func(w http.ResponseWriter, r *http.Request) {
+		b, err := opts.Issuers.Get(r.Context(), r.PathValue("key"))
+		serve(w, r, b, err, staticct.IssuersContentType, immutableCacheControl)
+	})
+	if opts.Metadata != nil {
+		mux.HandleFunc("GET "+prefix+"/"+staticct.MetadataPath, func(w http.ResponseWriter, r *http.Request) {
+			b, err := opts.Metadata.Metadata(r.Context())
+			serve(w, r, b, err, staticct.MetadataContentType, checkpointCacheControl)
+		})
+	}
+	if opts.Checkpoints != nil {
+		mux.HandleFunc("GET "+prefix+"/"+staticct.CheckpointsPrefix+"{size}", func(w http.ResponseWriter, r *http.Request) {
+			size, err := strconv.ParseUint(r.PathValue("size"), 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid checkpoint size %q", r.PathValue("size")), http.StatusBadRequest)
+				return
+			}
+			b, err := opts.Checkpoints.Checkpoint(r.Context(), size)
+			// Archived checkpoints never change.
+			serve(w, r, b, err, staticct.CheckpointsContentType, immutableCacheControl)
+		})
+	}
+}
+
+// serve writes data to w, or an error status if err is not nil.
+func serve(w http.ResponseWriter, r *http.Request, data []byte, err error, contentType, cacheControl string) {
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		slog.ErrorContext(r.Context(), "Failed to read resource", slog.String("path", r.URL.Path), slog.Any("error", err))
+		http.Error(w, "failed to read resource", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", cacheControl)
+	if _, err := w.Write(data); err != nil {
+		slog.WarnContext(r.Context(), "Failed to write response", slog.String("path", r.URL.Path), slog.Any("error", err))
+	}
+}
+
+// parseTileIndex parses a tile index encoded as per https://c2sp.org/tlog-tiles#apis,
+// e.g. "x001/x234/067" or "x001/x234/067.p/8", and returns the index and the
+// partial tile width, or 0 for a full tile.
+func parseTileIndex(s string) (uint64, uint8, error) {
+	var p uint8
+	if i := strings.Index(s, ".p/"); i >= 0 {
+		w, err := strconv.ParseUint(s[i+3:], 10, 8)
+		if err != nil || w == 0 {
+			return 0, 0, fmt.Errorf("invalid partial width %q", s[i+3:])
+		}
+		p = uint8(w)
+		s = s[:i]
+	}
+	elems := strings.Split(s, "/")
+	var index uint64
+	for i, e := range elems {
+		if i < len(elems)-1 {
+			if !strings.HasPrefix(e, "x") {
+				return 0, 0, fmt.Errorf("invalid index element %q", e)
+			}
+			e = e[1:]
+		}
+		if len(e) != 3 {
+			return 0, 0, fmt.Errorf("invalid index element %q", e)
+		}
+		n, err := strconv.ParseUint(e, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid index element %q: %v", e, err)
+		}
+		if index > (1<<64-1-n)/1000 {
+			return 0, 0, fmt.Errorf("index %q overflows", s)
+		}
+		index = index*1000 + n
+	}
+	return index, p, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+type fakeReader struct {
+	cp []byte
+}
+
+func (f fakeReader) ReadCheckpoint(_ context.Context) ([]byte, error) {
+	return f.cp, nil
+}
+
+func (f fakeReader) ReadTile(_ context.Context, level, index uint64, p uint8) ([]byte, error) {
+	if level == 5 {
+		return nil, os.ErrNotExist
+	}
+	return fmt.Appendf(nil, "tile %d %d %d", level, index, p), nil
+}
+
+func (f fakeReader) ReadEntryBundle(_ context.Context, index uint64, p uint8) ([]byte, error) {
+	if index == 666 {
+		return nil, errors.New("boom")
+	}
+	return fmt.Appendf(nil, "bundle %d %d", index, p), nil
+}
+
+type fakeKV map[string][]byte
+
+func (f fakeKV) Get(_ context.Context, key string) ([]byte, error) {
+	v, ok := f[key]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return v, nil
+}
+
+func (f fakeKV) Metadata(ctx context.Context) ([]byte, error) {
+	return f.Get(ctx, "metadata")
+}
+
+func (f fakeKV) Checkpoint(ctx context.Context, size uint64) ([]byte, error) {
+	return f.Get(ctx, fmt.Sprintf("checkpoints/%d", size))
+}
+
+func TestReadHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterReadHandlers(mux, "/log/", ReadHandlerOpts{
+		Reader:      fakeReader{cp: []byte("checkpoint")},
+		Issuers:     fakeKV{"abcd": []byte("issuer")},
+		Metadata:    fakeKV{"metadata": []byte("{}")},
+		Checkpoints: fakeKV{"checkpoints/42": []byte("checkpoint 42")},
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	for _, tc := range []struct {
+		path       string
+		wantStatus int
+		wantBody   string
+		wantCC     string
+	}{
+		{path: "/log/checkpoint", wantStatus: http.StatusOK, wantBody: "checkpoint", wantCC: checkpointCacheControl},
+		{path: "/log/tile/0/x001/234", wantStatus: http.StatusOK, wantBody: "tile 0 1234 0", wantCC: immutableCacheControl},
+		{path: "/log/tile/2/000.p/8", wantStatus: http.StatusOK, wantBody: "tile 2 0 8", wantCC: partialCacheControl},
+		{path: "/log/tile/data/x001/x000/042.p/255", wantStatus: http.StatusOK, wantBody: "bundle 1000042 255", wantCC: partialCacheControl},
+		{path: "/log/tile/5/000", wantStatus: http.StatusNotFound},
+		{path: "/log/tile/data/666", wantStatus: http.StatusInternalServerError},
+		{path: "/log/tile/x/000", wantStatus: http.StatusBadRequest},
+		{path: "/log/tile/0/1234", wantStatus: http.StatusBadRequest},
+		{path: "/log/issuer/abcd", wantStatus: http.StatusOK, wantBody: "issuer", wantCC: immutableCacheControl},
+		{path: "/log/issuer/dcba", wantStatus: http.StatusNotFound},
+		{path: "/log/log.v3.json", wantStatus: http.StatusOK, wantBody: "{}", wantCC: checkpointCacheControl},
+		{path: "/log/checkpoints/42", wantStatus: http.StatusOK, wantBody: "checkpoint 42", wantCC: immutableCacheControl},
+		{path: "/log/checkpoints/43", wantStatus: http.StatusNotFound},
+		{path: "/log/checkpoints/x", wantStatus: http.StatusBadRequest},
+		{path: "/checkpoint", wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			resp, err := http.Get(s.URL + tc.path)
+			if err != nil {
+				t.Fatalf("http.Get(): %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("status=%d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("io.ReadAll(): %v", err)
+			}
+			if got := string(body); got != tc.wantBody {
+				t.Errorf("body=%q, want %q", got, tc.wantBody)
+			}
+			if got := resp.Header.Get("Cache-Control"); got != tc.wantCC {
+				t.Errorf("Cache-Control=%q, want %q", got, tc.wantCC)
+			}
+		})
+	}
+}
+
+func TestParseTileIndex(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		wantIdx uint64
+		wantP   uint8
+		wantErr bool
+	}{
+		{in: "000"},
+		{in: "123", wantIdx: 123},
+		{in: "x001/x234/067", wantIdx: 1234067},
+		{in: "x001/x234/067.p/8", wantIdx: 1234067, wantP: 8},
+		{in: "x018/x446/x744/x073/x709/x551/615", wantIdx: 18446744073709551615},
+		{in: "x018/x446/x744/x073/x709/x551/616", wantErr: true},
+		{in: "1234", wantErr: true},
+		{in: "12", wantErr: true},
+		{in: "001/234", wantErr: true},
+		{in: "x001", wantErr: true},
+		{in: "000.p/0", wantErr: true},
+		{in: "000.p/256", wantErr: true},
+		{in: "abc", wantErr: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			idx, p, err := parseTileIndex(tc.in)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("parseTileIndex()=%v, wantErr %t", err, tc.wantErr)
+			}
+			if idx != tc.wantIdx || p != tc.wantP {
+				t.Errorf("parseTileIndex()=(%d, %d), want (%d, %d)", idx, p, tc.wantIdx, tc.wantP)
+			}
+		})
+	}
+}
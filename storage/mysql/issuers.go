@@ -0,0 +1,193 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mysql implements TesseraCT storage on top of a MySQL database, for
+// logs running on Tessera's MySQL driver.
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/transparency-dev/tesseract/storage"
+)
+
+const (
+	issuersTable     = "TesseraCTIssuers"
+	rootsTable       = "TesseraCTRoots"
+	issuerQueueTable = "TesseraCTIssuerQueue"
+
+	// maxKeyLen is the length of a hex encoded sha256 hash, which all keys are.
+	maxKeyLen = 64
+)
+
+// IssuersStorage is a key value store backed by a MySQL table to store issuer chains.
+type IssuersStorage struct {
+	db    *sql.DB
+	table string
+}
+
+// NewIssuerStorage creates a new MySQL based issuer storage.
+//
+// If it doesn't exist, NewIssuerStorage creates a TesseraCTIssuers table.
+func NewIssuerStorage(ctx context.Context, db *sql.DB) (*IssuersStorage, error) {
+	return newTableStorage(ctx, db, issuersTable)
+}
+
+// NewRootsStorage creates a new MySQL based root storage.
+//
+// If it doesn't exist, NewRootsStorage creates a TesseraCTRoots table.
+func NewRootsStorage(ctx context.Context, db *sql.DB) (*IssuersStorage, error) {
+	return newTableStorage(ctx, db, rootsTable)
+}
+
+// NewIssuerQueueStorage creates a new MySQL based issuer queue storage, to be
+// used by a storage.IssuerQueue.
+//
+// If it doesn't exist, NewIssuerQueueStorage creates a TesseraCTIssuerQueue
+// table.
+func NewIssuerQueueStorage(ctx context.Context, db *sql.DB) (*IssuersStorage, error) {
+	return newTableStorage(ctx, db, issuerQueueTable)
+}
+
+func newTableStorage(ctx context.Context, db *sql.DB, table string) (*IssuersStorage, error) {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (`id` VARCHAR(%d) NOT NULL, `data` MEDIUMBLOB NOT NULL, PRIMARY KEY(`id`))", table, maxKeyLen)); err != nil {
+		return nil, fmt.Errorf("failed to create table %q: %v", table, err)
+	}
+	return &IssuersStorage{db: db, table: table}, nil
+}
+
+// Get returns the value stored under key.
+//
+// If there is no such value, Get returns an error wrapping os.ErrNotExist.
+func (s *IssuersStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	var v []byte
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT `data` FROM `%s` WHERE `id` = ?", s.table), key).Scan(&v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%q not found in %s: %w", key, s.table, os.ErrNotExist)
+		}
+		return nil, fmt.Errorf("failed to read %q from %s: %v", key, s.table, err)
+	}
+	return v, nil
+}
+
+// List returns all the keys in the table.
+func (s *IssuersStorage) List(ctx context.Context) ([][]byte, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT `id` FROM `%s` ORDER BY `id`", s.table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %v", s.table, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close rows", slog.String("table", s.table), slog.Any("error", err))
+		}
+	}()
+	keys := [][]byte{}
+	for rows.Next() {
+		var k string
+		if err := rows.Scan(&k); err != nil {
+			return nil, fmt.Errorf("failed to scan row from %s: %v", s.table, err)
+		}
+		keys = append(keys, []byte(k))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows from %s: %v", s.table, err)
+	}
+	return keys, nil
+}
+
+// LoadAll returns all the key values in the table.
+func (s *IssuersStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT `id`, `data` FROM `%s` ORDER BY `id`", s.table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %v", s.table, err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.WarnContext(ctx, "Failed to close rows", slog.String("table", s.table), slog.Any("error", err))
+		}
+	}()
+	kvs := []storage.KV{}
+	for rows.Next() {
+		var k string
+		var v []byte
+		if err := rows.Scan(&k, &v); err != nil {
+			return nil, fmt.Errorf("failed to scan row from %s: %v", s.table, err)
+		}
+		kvs = append(kvs, storage.KV{K: []byte(k), V: v})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows from %s: %v", s.table, err)
+	}
+	return kvs, nil
+}
+
+// AddIfNotExist stores values under their Key if there isn't an object under Key already.
+func (s *IssuersStorage) AddIfNotExist(ctx context.Context, kv []storage.KV) error {
+	errs := make([]error, 0)
+	for _, kv := range kv {
+		k := string(kv.K)
+		if len(k) == 0 || len(k) > maxKeyLen {
+			errs = append(errs, fmt.Errorf("%q is an invalid key", k))
+			continue
+		}
+		r, err := s.db.ExecContext(ctx, fmt.Sprintf("INSERT IGNORE INTO `%s` (`id`, `data`) VALUES (?, ?)", s.table), k, kv.V)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to insert %q into %s: %v", k, s.table, err))
+			continue
+		}
+		n, err := r.RowsAffected()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get number of rows inserted into %s: %v", s.table, err))
+			continue
+		}
+		if n == 0 {
+			existing, err := s.Get(ctx, k)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !bytes.Equal(kv.V, existing) {
+				errs = append(errs, fmt.Errorf("non-idempotent write for preexisting row %q in %s", k, s.table))
+				continue
+			}
+			// It already existed, but it also already contains the same data, so we're good.
+			continue
+		}
+		slog.InfoContext(ctx, "AddIfNotExist: added", slog.String("key", k), slog.String("table", s.table))
+	}
+	return errors.Join(errs...)
+}
+
+// Delete removes the values stored under keys, if any.
+func (s *IssuersStorage) Delete(ctx context.Context, keys [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, string(k))
+	}
+	q := fmt.Sprintf("DELETE FROM `%s` WHERE `id` IN (?%s)", s.table, strings.Repeat(", ?", len(keys)-1))
+	if _, err := s.db.ExecContext(ctx, q, args...); err != nil {
+		return fmt.Errorf("failed to delete %d keys from %s: %v", len(keys), s.table, err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/transparency-dev/tesseract/storage"
+)
+
+var (
+	mysqlURI            = flag.String("mysql_uri", "root:root@tcp(localhost:3306)/test_tesseract", "Connection string for a MySQL database")
+	isMySQLTestOptional = flag.Bool("is_mysql_test_optional", true, "Boolean value to control whether the MySQL test is optional")
+)
+
+// newTestDB returns a connection to an empty test database, or skips the test
+// if there isn't one and MySQL tests are optional.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("mysql", *mysqlURI)
+	if err == nil {
+		err = db.PingContext(t.Context())
+	}
+	if err != nil {
+		if *isMySQLTestOptional {
+			t.Skipf("MySQL not available, skipping: %v", err)
+		}
+		t.Fatalf("failed to connect to MySQL test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	for _, table := range []string{issuersTable, rootsTable, issuerQueueTable, metadataTable, checkpointsTable} {
+		if _, err := db.ExecContext(t.Context(), "DROP TABLE IF EXISTS `"+table+"`"); err != nil {
+			t.Fatalf("failed to drop table %q: %v", table, err)
+		}
+	}
+	return db
+}
+
+func TestIssuersStorage(t *testing.T) {
+	db := newTestDB(t)
+	s, err := NewIssuerStorage(t.Context(), db)
+	if err != nil {
+		t.Fatalf("NewIssuerStorage(): %v", err)
+	}
+	// Creating the storage again must not fail on the existing table.
+	if _, err := NewIssuerStorage(t.Context(), db); err != nil {
+		t.Fatalf("NewIssuerStorage() on existing table: %v", err)
+	}
+
+	kvs := []storage.KV{
+		{K: []byte("key1"), V: []byte("value1")},
+		{K: []byte("key2"), V: []byte("value2")},
+	}
+	if err := s.AddIfNotExist(t.Context(), kvs); err != nil {
+		t.Fatalf("AddIfNotExist(): %v", err)
+	}
+	// Adding the same values again is a no-op.
+	if err := s.AddIfNotExist(t.Context(), kvs); err != nil {
+		t.Fatalf("AddIfNotExist() with same values: %v", err)
+	}
+	if err := s.AddIfNotExist(t.Context(), []storage.KV{{K: []byte("key1"), V: []byte("other")}}); err == nil {
+		t.Error("AddIfNotExist() with different value: got nil error, want error")
+	}
+	if err := s.AddIfNotExist(t.Context(), []storage.KV{{K: []byte(""), V: []byte("value")}}); err == nil {
+		t.Error("AddIfNotExist() with empty key: got nil error, want error")
+	}
+
+	got, err := s.Get(t.Context(), "key1")
+	if err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+	if string(got) != "value1" {
+		t.Errorf("Get()=%q, want %q", got, "value1")
+	}
+	if _, err := s.Get(t.Context(), "missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Get(missing)=%v, want os.ErrNotExist", err)
+	}
+	keys, err := s.List(t.Context())
+	if err != nil {
+		t.Fatalf("List(): %v", err)
+	}
+	if want := [][]byte{[]byte("key1"), []byte("key2")}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("List()=%q, want %q", keys, want)
+	}
+}
+
+func TestRootsStorageLoadAll(t *testing.T) {
+	db := newTestDB(t)
+	s, err := NewRootsStorage(t.Context(), db)
+	if err != nil {
+		t.Fatalf("NewRootsStorage(): %v", err)
+	}
+	got, err := s.LoadAll(t.Context())
+	if err != nil {
+		t.Fatalf("LoadAll(): %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("LoadAll() on empty table returned %d values, want 0", len(got))
+	}
+
+	want := []storage.KV{
+		{K: []byte("root1"), V: []byte("cert1")},
+		{K: []byte("root2"), V: []byte("cert2")},
+	}
+	if err := s.AddIfNotExist(t.Context(), want); err != nil {
+		t.Fatalf("AddIfNotExist(): %v", err)
+	}
+	got, err = s.LoadAll(t.Context())
+	if err != nil {
+		t.Fatalf("LoadAll(): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAll()=%v, want %v", got, want)
+	}
+}
+
+func TestIssuerQueueStorage(t *testing.T) {
+	db := newTestDB(t)
+	s, err := NewIssuerQueueStorage(t.Context(), db)
+	if err != nil {
+		t.Fatalf("NewIssuerQueueStorage(): %v", err)
+	}
+	kvs := []storage.KV{
+		{K: []byte("key1"), V: []byte("value1")},
+		{K: []byte("key2"), V: []byte("value2")},
+		{K: []byte("key3"), V: []byte("value3")},
+	}
+	if err := s.AddIfNotExist(t.Context(), kvs); err != nil {
+		t.Fatalf("AddIfNotExist(): %v", err)
+	}
+	if err := s.Delete(t.Context(), [][]byte{[]byte("key1"), []byte("key3"), []byte("missing")}); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+	got, err := s.LoadAll(t.Context())
+	if err != nil {
+		t.Fatalf("LoadAll(): %v", err)
+	}
+	if want := kvs[1:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAll() after Delete()=%v, want %v", got, want)
+	}
+}
+
+func TestMetadataStorage(t *testing.T) {
+	db := newTestDB(t)
+	s, err := NewMetadataStorage(t.Context(), db)
+	if err != nil {
+		t.Fatalf("NewMetadataStorage(): %v", err)
+	}
+	if _, err := s.Metadata(t.Context()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Metadata() before SetMetadata()=%v, want os.ErrNotExist", err)
+	}
+	for _, data := range []string{`{"v":1}`, `{"v":2}`} {
+		if err := s.SetMetadata(t.Context(), []byte(data)); err != nil {
+			t.Fatalf("SetMetadata(): %v", err)
+		}
+		got, err := s.Metadata(t.Context())
+		if err != nil {
+			t.Fatalf("Metadata(): %v", err)
+		}
+		if string(got) != data {
+			t.Errorf("Metadata()=%q, want %q", got, data)
+		}
+	}
+}
+
+func TestCheckpointArchiveStorage(t *testing.T) {
+	db := newTestDB(t)
+	s, err := NewCheckpointArchiveStorage(t.Context(), db)
+	if err != nil {
+		t.Fatalf("NewCheckpointArchiveStorage(): %v", err)
+	}
+	if _, err := s.Checkpoint(t.Context(), 1); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Checkpoint(1) before AddCheckpoint()=%v, want os.ErrNotExist", err)
+	}
+	for size, cp := range map[uint64]string{1: "one", 2: "two"} {
+		if err := s.AddCheckpoint(t.Context(), size, []byte(cp)); err != nil {
+			t.Fatalf("AddCheckpoint(%d): %v", size, err)
+		}
+	}
+	// Republished checkpoints of an archived size are ignored.
+	if err := s.AddCheckpoint(t.Context(), 2, []byte("two again")); err != nil {
+		t.Fatalf("AddCheckpoint(2): %v", err)
+	}
+	if got, err := s.Checkpoint(t.Context(), 2); err != nil || string(got) != "two" {
+		t.Errorf("Checkpoint(2)=%q, %v, want %q, nil", got, err, "two")
+	}
+	if _, err := db.ExecContext(t.Context(), "UPDATE `"+checkpointsTable+"` SET `created` = NOW() - INTERVAL 2 HOUR WHERE `size` = 1"); err != nil {
+		t.Fatalf("failed to age checkpoint 1: %v", err)
+	}
+	if n, err := s.PruneCheckpoints(t.Context(), time.Now().Add(-time.Hour)); err != nil || n != 1 {
+		t.Fatalf("PruneCheckpoints()=%d, %v, want 1, nil", n, err)
+	}
+	if _, err := s.Checkpoint(t.Context(), 1); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Checkpoint(1) after PruneCheckpoints()=%v, want os.ErrNotExist", err)
+	}
+	if _, err := s.Checkpoint(t.Context(), 2); err != nil {
+		t.Errorf("Checkpoint(2) after PruneCheckpoints(): %v", err)
+	}
+}
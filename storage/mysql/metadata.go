@@ -0,0 +1,62 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const metadataTable = "TesseraCTMetadata"
+
+// MetadataStorage stores the log metadata document in a single row MySQL table.
+type MetadataStorage struct {
+	db *sql.DB
+}
+
+// NewMetadataStorage creates a new MySQL based metadata storage.
+//
+// If it doesn't exist, NewMetadataStorage creates a TesseraCTMetadata table.
+func NewMetadataStorage(ctx context.Context, db *sql.DB) (*MetadataStorage, error) {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `"+metadataTable+"` (`id` TINYINT NOT NULL, `data` MEDIUMBLOB NOT NULL, PRIMARY KEY(`id`))"); err != nil {
+		return nil, fmt.Errorf("failed to create table %q: %v", metadataTable, err)
+	}
+	return &MetadataStorage{db: db}, nil
+}
+
+// SetMetadata replaces the metadata document with data.
+func (s *MetadataStorage) SetMetadata(ctx context.Context, data []byte) error {
+	if _, err := s.db.ExecContext(ctx, "REPLACE INTO `"+metadataTable+"` (`id`, `data`) VALUES (0, ?)", data); err != nil {
+		return fmt.Errorf("failed to write metadata: %v", err)
+	}
+	return nil
+}
+
+// Metadata returns the metadata document.
+//
+// If it hasn't been set yet, Metadata returns an error wrapping os.ErrNotExist.
+func (s *MetadataStorage) Metadata(ctx context.Context) ([]byte, error) {
+	var v []byte
+	if err := s.db.QueryRowContext(ctx, "SELECT `data` FROM `"+metadataTable+"` WHERE `id` = 0").Scan(&v); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("metadata not found: %w", os.ErrNotExist)
+		}
+		return nil, fmt.Errorf("failed to read metadata: %v", err)
+	}
+	return v, nil
+}
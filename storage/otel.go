@@ -16,17 +16,100 @@ package storage
 
 import (
 	"context"
+	"log/slog"
+	"os"
+	"sync"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 )
 
 const name = "github.com/transparency-dev/tesseract/storage"
 
 var (
+	meter  = otel.Meter(name)
 	tracer = otel.Tracer(name)
 )
 
+var (
+	auditCheckKey = attribute.Key("tesseract.audit.check")
+	dedupCacheKey = attribute.Key("tesseract.dedup.cache")
+	cacheHitKey   = attribute.Key("tesseract.cache.hit")
+)
+
+const (
+	dedupCacheSCT    = "sct"
+	dedupCacheBundle = "bundle"
+)
+
+var (
+	dedupCacheOnce    sync.Once
+	dedupCacheLookups metric.Int64Counter // cache, hit
+)
+
+func setupDedupCacheMetrics() {
+	dedupCacheLookups = mustCreate(meter.Int64Counter("tesseract.dedup.cache.lookup.count",
+		metric.WithDescription("Lookups in the caches of SCT inputs and full entry bundles used to resolve duplicate submissions"),
+		metric.WithUnit("{lookup}")))
+}
+
+var (
+	issuerCacheOnce       sync.Once
+	issuerCacheLookups    metric.Int64Counter // hit
+	issuerWritesCoalesced metric.Int64Counter
+)
+
+func setupIssuerCacheMetrics() {
+	issuerCacheLookups = mustCreate(meter.Int64Counter("tesseract.issuer.cache.lookup.count",
+		metric.WithDescription("Lookups in the cache of issuers known to be stored"),
+		metric.WithUnit("{lookup}")))
+
+	issuerWritesCoalesced = mustCreate(meter.Int64Counter("tesseract.issuer.write.coalesced.count",
+		metric.WithDescription("Issuer writes shared with a concurrent request writing the same issuer"),
+		metric.WithUnit("{write}")))
+}
+
+var (
+	checkpointArchiveOnce    sync.Once
+	checkpointArchiveSkipped metric.Int64Counter
+)
+
+func setupCheckpointArchiveMetrics() {
+	checkpointArchiveSkipped = mustCreate(meter.Int64Counter("tesseract.checkpoint.archive.skipped.count",
+		metric.WithDescription("Checkpoints signed by this instance which were replaced, or never published, before they could be archived"),
+		metric.WithUnit("{checkpoint}")))
+}
+
+var (
+	issuerQueueOnce     sync.Once
+	issuerQueueDepth    metric.Int64Gauge
+	issuerQueueFailures metric.Int64Counter
+)
+
+func setupIssuerQueueMetrics() {
+	issuerQueueDepth = mustCreate(meter.Int64Gauge("tesseract.issuer.queue.depth",
+		metric.WithDescription("Issuers queued to be written to the issuer storage"),
+		metric.WithUnit("{issuer}")))
+
+	issuerQueueFailures = mustCreate(meter.Int64Counter("tesseract.issuer.queue.failure.count",
+		metric.WithDescription("Failed attempts to write queued issuers to the issuer storage"),
+		metric.WithUnit("{attempt}")))
+}
+
+func recordDedupCacheLookup(ctx context.Context, cache string, hit bool) {
+	dedupCacheLookups.Add(ctx, 1, metric.WithAttributes(dedupCacheKey.String(cache), cacheHitKey.Bool(hit)))
+}
+
+func mustCreate[T any](t T, err error) T {
+	if err != nil {
+		slog.ErrorContext(context.Background(), err.Error())
+		os.Exit(1)
+	}
+	return t
+}
+
 // trace1 executes logic that returns (Value, error).
 func trace1[T any](ctx context.Context, name string, fn func(context.Context) (T, error)) (T, error) {
 	ctx, span := tracer.Start(ctx, name)
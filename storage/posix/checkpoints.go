@@ -0,0 +1,84 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+// CheckpointArchiveStorage archives checkpoints in files named after their
+// size.
+type CheckpointArchiveStorage struct {
+	dir string
+}
+
+// NewCheckpointArchiveStorage creates a new POSIX based checkpoint archive.
+//
+// Checkpoints will be stored in a directory called staticct.CheckpointsPrefix
+// within the provided root directory.
+func NewCheckpointArchiveStorage(ctx context.Context, root string) (*CheckpointArchiveStorage, error) {
+	dir := filepath.Join(root, staticct.CheckpointsPrefix)
+	if err := mkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to make directory structure: %w", err)
+	}
+	return &CheckpointArchiveStorage{dir: dir}, nil
+}
+
+// AddCheckpoint stores cp under its size, unless a checkpoint of this size is
+// already archived.
+func (s *CheckpointArchiveStorage) AddCheckpoint(ctx context.Context, size uint64, cp []byte) error {
+	p := filepath.Join(s.dir, strconv.FormatUint(size, 10))
+	if err := createEx(p, cp); err != nil && !errors.Is(err, os.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+// PruneCheckpoints deletes checkpoints whose file was last modified before t.
+func (s *CheckpointArchiveStorage) PruneCheckpoints(ctx context.Context, t time.Time) (int, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("os.ReadDir(%q): %v", s.dir, err)
+	}
+	n := 0
+	var errs []error
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to stat %q: %v", f.Name(), err))
+			continue
+		}
+		if !info.ModTime().Before(t) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, f.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
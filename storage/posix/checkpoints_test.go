@@ -0,0 +1,69 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+func TestCheckpointArchiveStorage(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewCheckpointArchiveStorage(t.Context(), root)
+	if err != nil {
+		t.Fatalf("NewCheckpointArchiveStorage(): %v", err)
+	}
+	for _, c := range []struct {
+		size uint64
+		cp   string
+	}{
+		{size: 1, cp: "one"},
+		{size: 2, cp: "two"},
+		// Republished checkpoints of an archived size are ignored.
+		{size: 2, cp: "two again"},
+	} {
+		if err := s.AddCheckpoint(t.Context(), c.size, []byte(c.cp)); err != nil {
+			t.Fatalf("AddCheckpoint(%d): %v", c.size, err)
+		}
+	}
+	for size, want := range map[string]string{"1": "one", "2": "two"} {
+		got, err := os.ReadFile(filepath.Join(root, staticct.CheckpointsPrefix, size))
+		if err != nil {
+			t.Fatalf("ReadFile(%s): %v", size, err)
+		}
+		if string(got) != want {
+			t.Errorf("checkpoint %s = %q, want %q", size, got, want)
+		}
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(root, staticct.CheckpointsPrefix, "1"), old, old); err != nil {
+		t.Fatalf("Chtimes(): %v", err)
+	}
+	n, err := s.PruneCheckpoints(t.Context(), time.Now().Add(-time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("PruneCheckpoints() = %d, %v, want 1, nil", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, staticct.CheckpointsPrefix, "1")); !os.IsNotExist(err) {
+		t.Errorf("checkpoint 1 wasn't pruned: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, staticct.CheckpointsPrefix, "2")); err != nil {
+		t.Errorf("checkpoint 2 was pruned: %v", err)
+	}
+}
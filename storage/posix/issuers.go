@@ -59,6 +59,35 @@ func NewRootsStorage(ctx context.Context, parent string) (*IssuersStorage, error
 	return &IssuersStorage{dir}, nil
 }
 
+// NewIssuerQueueStorage creates a new POSIX based issuer queue storage in dir,
+// to be used by a storage.IssuerQueue.
+//
+// If the directory doesn't exist, NewIssuerQueueStorage creates it and its
+// parents.
+func NewIssuerQueueStorage(ctx context.Context, dir string) (*IssuersStorage, error) {
+	if err := mkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to make directory structure: %w", err)
+	}
+
+	return &IssuersStorage{dir}, nil
+}
+
+// List returns the keys of all the values in the directory.
+func (s *IssuersStorage) List(ctx context.Context) ([][]byte, error) {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadDir(%q): %v", s.dir, err)
+	}
+	keys := [][]byte{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		keys = append(keys, []byte(f.Name()))
+	}
+	return keys, nil
+}
+
 func (s *IssuersStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
 	files, err := os.ReadDir(s.dir)
 	if err != nil {
@@ -71,6 +100,11 @@ func (s *IssuersStorage) LoadAll(ctx context.Context) ([]storage.KV, error) {
 		}
 		p := filepath.Join(s.dir, f.Name())
 		b, err := os.ReadFile(p)
+		if errors.Is(err, os.ErrNotExist) {
+			// A temporary file, or a value which was deleted since the
+			// directory was read.
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to read existing file %q: %v", p, err)
 		}
@@ -113,3 +147,19 @@ func (s *IssuersStorage) AddIfNotExist(ctx context.Context, kv []storage.KV) err
 	}
 	return errors.Join(errs...)
 }
+
+// Delete removes the values stored under keys, if any.
+func (s *IssuersStorage) Delete(ctx context.Context, keys [][]byte) error {
+	return syncDir(s.dir, func() error {
+		for _, k := range keys {
+			if strings.ContainsRune(string(k), filepath.Separator) {
+				return fmt.Errorf("%q is an invalid key", k)
+			}
+			p := filepath.Join(s.dir, string(k))
+			if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove %q: %v", p, err)
+			}
+		}
+		return nil
+	})
+}
@@ -158,6 +158,19 @@ func TestLoadAll(t *testing.T) {
 					t.Errorf("LoadAll() key %q = %s, want %s", gKV.K, gKV.V, wV)
 				}
 			}
+
+			gotKeys, err := s.List(t.Context())
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(gotKeys) != len(tt.data) {
+				t.Errorf("List() returned %d keys, want %d", len(gotKeys), len(tt.data))
+			}
+			for _, k := range gotKeys {
+				if _, ok := wKV[string(k)]; !ok {
+					t.Errorf("List() returned unexpected key %q", k)
+				}
+			}
 		})
 	}
 }
@@ -274,3 +287,32 @@ func TestAddIfNotExist(t *testing.T) {
 		})
 	}
 }
+
+func TestDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "queue")
+	s, err := NewIssuerQueueStorage(t.Context(), dir)
+	if err != nil {
+		t.Fatalf("NewIssuerQueueStorage(): %v", err)
+	}
+	kvs := []storage.KV{
+		{K: []byte("key1"), V: []byte("value1")},
+		{K: []byte("key2"), V: []byte("value2")},
+		{K: []byte("key3"), V: []byte("value3")},
+	}
+	if err := s.AddIfNotExist(t.Context(), kvs); err != nil {
+		t.Fatalf("AddIfNotExist(): %v", err)
+	}
+	if err := s.Delete(t.Context(), [][]byte{[]byte("key1"), []byte("key3"), []byte("missing")}); err != nil {
+		t.Fatalf("Delete(): %v", err)
+	}
+	if err := s.Delete(t.Context(), [][]byte{[]byte("../key2")}); err == nil {
+		t.Error("Delete() with an invalid key: got nil error, want error")
+	}
+	got, err := s.LoadAll(t.Context())
+	if err != nil {
+		t.Fatalf("LoadAll(): %v", err)
+	}
+	if want := kvs[1:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAll() after Delete()=%v, want %v", got, want)
+	}
+}
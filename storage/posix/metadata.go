@@ -0,0 +1,58 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package posix
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+)
+
+// MetadataStorage stores the log metadata document in a file.
+type MetadataStorage struct {
+	path string
+}
+
+// NewMetadataStorage creates a new POSIX based metadata storage.
+//
+// The document will be stored in a file called staticct.MetadataPath within
+// the provided root directory, next to the checkpoint.
+func NewMetadataStorage(ctx context.Context, root string) (*MetadataStorage, error) {
+	if err := mkdirAll(root, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to make directory structure: %w", err)
+	}
+	return &MetadataStorage{path: filepath.Join(root, staticct.MetadataPath)}, nil
+}
+
+// SetMetadata atomically replaces the metadata document with data.
+func (s *MetadataStorage) SetMetadata(ctx context.Context, data []byte) error {
+	return syncDir(filepath.Dir(s.path), func() error {
+		tmpName, err := createTemp(s.path, data)
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %v", err)
+		}
+		if err := os.Rename(tmpName, s.path); err != nil {
+			if err := os.Remove(tmpName); err != nil {
+				slog.WarnContext(ctx, "Failed to remove temporary file", slog.String("name", tmpName), slog.Any("error", err))
+			}
+			return fmt.Errorf("failed to rename temporary file to target %q: %v", s.path, err)
+		}
+		return nil
+	})
+}
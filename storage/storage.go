@@ -25,13 +25,11 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/transparency-dev/tessera"
 	"github.com/transparency-dev/tessera/api/layout"
 	"github.com/transparency-dev/tessera/ctonly"
-	"github.com/transparency-dev/tesseract/internal/logger"
 	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
 	"github.com/transparency-dev/tesseract/internal/types/staticct"
 
@@ -44,10 +42,17 @@ type CreateStorage func(context.Context, note.Signer) (*CTStorage, error)
 const (
 	// Each key is 64 bytes long, so this will take up to 64MB.
 	// A CT log references ~15k unique issuer certifiates in 2024, so this gives plenty of space
-	// if we ever run into this limit, we should re-think how it works.
+	// for the least recently used keys to only be evicted in exceptional circumstances.
 	maxCachedIssuerKeys        = 1 << 20
 	RootsPrefix                = "roots/"
 	DefaultAwaiterPollInterval = 200 * time.Millisecond
+	// DefaultDedupSCTCacheSize is the default number of SCT inputs cached to
+	// resolve duplicate submissions.
+	DefaultDedupSCTCacheSize = 1 << 14
+	// DefaultDedupBundleCacheSize is the default number of full entry bundles
+	// cached to resolve duplicate submissions. Bundles hold 256 entries, and
+	// can weigh a few hundred kilobytes each.
+	DefaultDedupBundleCacheSize = 64
 )
 
 type KV struct {
@@ -56,47 +61,140 @@ type KV struct {
 }
 
 // IssuerStorage issuer certificates under their hex encoded sha256.
+//
+// IssuerStorage implementations may also implement IssuerKeyLister, to
+// warm-start the cache of issuers known to be stored.
 type IssuerStorage interface {
 	AddIfNotExist(ctx context.Context, kv []KV) error
 }
 
+// IssuerKeyLister lists the keys of the issuers in an IssuerStorage.
+type IssuerKeyLister interface {
+	List(ctx context.Context) ([][]byte, error)
+}
+
 // RootsStorage stores root certificates under their hex encoded sha256.
 type RootsStorage interface {
 	AddIfNotExist(ctx context.Context, kv []KV) error
 	LoadAll(ctx context.Context) ([]KV, error)
 }
 
+// MetadataStorage stores the log metadata document, next to the checkpoint.
+type MetadataStorage interface {
+	// SetMetadata stores data, replacing any existing document.
+	SetMetadata(ctx context.Context, data []byte) error
+}
+
 type CTStorageOptions struct {
 	Appender            *tessera.Appender
 	Reader              tessera.LogReader
 	IssuerStorage       IssuerStorage
 	AwaiterPollInterval time.Duration
 	EnablePubAwaiter    bool
+	// AuditInterval is the interval at which the self-auditor checks newly
+	// published checkpoints, see Auditor. Zero disables it.
+	AuditInterval time.Duration
+	// AuditBundleSampleRate is the fraction of new entry bundles the
+	// self-auditor re-hashes against tiles.
+	AuditBundleSampleRate float64
+	// CheckpointArchive, if set, is where every new checkpoint is archived,
+	// see CheckpointArchiver.
+	CheckpointArchive CheckpointArchiveStorage
+	// CheckpointArchiveInterval is the interval at which the latest checkpoint
+	// is read to be archived. It defaults to CheckpointInterval, and must not
+	// be longer, so that no checkpoint is replaced before being archived.
+	CheckpointArchiveInterval time.Duration
+	// CheckpointInterval is the interval between two checkpoints published by
+	// the log. It must be set if CheckpointArchive is.
+	CheckpointInterval time.Duration
+	// SignedCheckpoints, if set, records the checkpoints signed by the log, so
+	// that the ones which aren't archived are reported. Checkpoints must then
+	// be signed by a signer wrapped with SignedCheckpoints.WrapSigner.
+	SignedCheckpoints *SignedCheckpoints
+	// CheckpointArchiveMaxAge is how long checkpoints are kept in the
+	// archive. Zero keeps them forever.
+	CheckpointArchiveMaxAge time.Duration
+	// MergeDelayPollInterval, if positive, is the interval at which
+	// checkpoints are read to measure the merge delay of issued SCTs, see
+	// MergeDelayTracker.
+	MergeDelayPollInterval time.Duration
+	// MMD is the maximum merge delay of the log.
+	MMD time.Duration
+	// MergeDelayAlertRatio is the fraction of the MMD after which outstanding
+	// SCTs raise an alert.
+	MergeDelayAlertRatio float64
+	// DedupSCTCacheSize and DedupBundleCacheSize are the number of SCT inputs
+	// and full entry bundles cached by DedupFuture. Zero disables a cache.
+	DedupSCTCacheSize    int
+	DedupBundleCacheSize int
+	// IssuerQueue, if set, queues issuer writes instead of writing them
+	// synchronously. It must write to IssuerStorage, and checkpoints must
+	// then be signed by a signer wrapped with IssuerQueue.WrapSigner.
+	IssuerQueue *IssuerQueue
 }
 
 // CTStorage implements ct.Storage and tessera.LogReader.
 type CTStorage struct {
 	storeData        func(context.Context, *ctonly.Entry) tessera.IndexFuture
-	storeIssuers     func(context.Context, []KV) error
+	issuers          *issuerCache
+	issuerQueue      *IssuerQueue
 	reader           tessera.LogReader
 	awaiter          *tessera.PublicationAwaiter
 	enablePubAwaiter bool
+	mergeDelay       *MergeDelayTracker
+	// dedupSCTs caches SCT inputs by index, if not nil.
+	dedupSCTs *lruCache[uint64, rfc6962.CertificateTimestamp]
+	// dedupBundles caches full entry bundles by index, if not nil. Partial
+	// bundles are never cached, since they get superseded as the log grows.
+	dedupBundles *lruCache[uint64, []byte]
 }
 
 // NewCTStorage instantiates a CTStorage object.
 func NewCTStorage(ctx context.Context, opts *CTStorageOptions) (*CTStorage, error) {
+	archiveInterval := opts.CheckpointArchiveInterval
+	if archiveInterval <= 0 {
+		archiveInterval = opts.CheckpointInterval
+	}
+	if opts.CheckpointArchive != nil && (archiveInterval <= 0 || archiveInterval > opts.CheckpointInterval) {
+		return nil, fmt.Errorf("checkpoint archive interval %v must be positive, and not longer than the checkpoint interval %v", archiveInterval, opts.CheckpointInterval)
+	}
 	pollInterval := opts.AwaiterPollInterval
 	if pollInterval <= 0 {
 		pollInterval = DefaultAwaiterPollInterval
 	}
 	awaiter := tessera.NewPublicationAwaiter(ctx, opts.Reader.ReadCheckpoint, pollInterval)
+	issuers := newIssuerCache(opts.IssuerStorage, maxCachedIssuerKeys)
+	go issuers.warm(ctx)
 	ctStorage := &CTStorage{
 		storeData:        tessera.NewCertificateTransparencyAppender(opts.Appender),
-		storeIssuers:     cachedStoreIssuers(opts.IssuerStorage),
+		issuers:          issuers,
+		issuerQueue:      opts.IssuerQueue,
 		reader:           opts.Reader,
 		awaiter:          awaiter,
 		enablePubAwaiter: opts.EnablePubAwaiter,
 	}
+	if opts.IssuerQueue != nil {
+		go opts.IssuerQueue.run(ctx)
+	}
+	if opts.DedupSCTCacheSize > 0 || opts.DedupBundleCacheSize > 0 {
+		dedupCacheOnce.Do(setupDedupCacheMetrics)
+	}
+	if opts.DedupSCTCacheSize > 0 {
+		ctStorage.dedupSCTs = newLRUCache[uint64, rfc6962.CertificateTimestamp](opts.DedupSCTCacheSize)
+	}
+	if opts.DedupBundleCacheSize > 0 {
+		ctStorage.dedupBundles = newLRUCache[uint64, []byte](opts.DedupBundleCacheSize)
+	}
+	if opts.AuditInterval > 0 {
+		go NewAuditor(opts.Reader, opts.AuditBundleSampleRate).Run(ctx, opts.AuditInterval)
+	}
+	if opts.CheckpointArchive != nil {
+		go NewCheckpointArchiver(opts.Reader.ReadCheckpoint, opts.CheckpointArchive, opts.SignedCheckpoints).Run(ctx, archiveInterval, opts.CheckpointArchiveMaxAge)
+	}
+	if opts.MergeDelayPollInterval > 0 {
+		ctStorage.mergeDelay = NewMergeDelayTracker(opts.Reader.ReadCheckpoint, opts.MMD, opts.MergeDelayAlertRatio)
+		go ctStorage.mergeDelay.Run(ctx, opts.MergeDelayPollInterval)
+	}
 
 	return ctStorage, nil
 }
@@ -104,9 +202,9 @@ func NewCTStorage(ctx context.Context, opts *CTStorageOptions) (*CTStorage, erro
 // DedupFuture returns the SCT input matching a future.
 //
 // It waits for the entry matching the future to be integrated, fetches it and
-// extracts the SCT input fields from it.
-//
-// TODO(phbnf): cache entries (or more) to avoid reparsing the entire leaf bundle
+// extracts the SCT input fields from it. SCT inputs and full entry bundles are
+// cached, so that floods of duplicate submissions don't require fetching and
+// parsing the same bundles over and over.
 func (cts *CTStorage) DedupFuture(ctx context.Context, f tessera.IndexFuture) (rfc6962.CertificateTimestamp, error) {
 	return trace1(ctx, "tesseract.storage.DedupFuture", func(ctx context.Context) (rfc6962.CertificateTimestamp, error) {
 		idx, cpRaw, err := cts.awaiter.Await(ctx, f)
@@ -114,6 +212,14 @@ func (cts *CTStorage) DedupFuture(ctx context.Context, f tessera.IndexFuture) (r
 			return rfc6962.CertificateTimestamp{}, fmt.Errorf("error waiting for Tessera index future and its integration: %w", err)
 		}
 
+		if cts.dedupSCTs != nil {
+			sct, ok := cts.dedupSCTs.Get(idx.Index)
+			recordDedupCacheLookup(ctx, dedupCacheSCT, ok)
+			if ok {
+				return sct, nil
+			}
+		}
+
 		// A https://c2sp.org/static-ct-api logsize is on the second line
 		l := bytes.SplitN(cpRaw, []byte("\n"), 3)
 		if len(l) < 2 {
@@ -125,12 +231,9 @@ func (cts *CTStorage) DedupFuture(ctx context.Context, f tessera.IndexFuture) (r
 		}
 
 		eBIdx := idx.Index / layout.EntryBundleWidth
-		eBRaw, err := cts.reader.ReadEntryBundle(ctx, eBIdx, layout.PartialTileSize(0, eBIdx, ckptSize))
+		eBRaw, err := cts.readDedupEntryBundle(ctx, eBIdx, layout.PartialTileSize(0, eBIdx, ckptSize))
 		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				return rfc6962.CertificateTimestamp{}, fmt.Errorf("leaf bundle at index %d not found: %v", eBIdx, err)
-			}
-			return rfc6962.CertificateTimestamp{}, fmt.Errorf("failed to fetch entry bundle at index %d: %v", eBIdx, err)
+			return rfc6962.CertificateTimestamp{}, err
 		}
 		eIdx := idx.Index % layout.EntryBundleWidth
 		sct, err := staticct.ExtractSCTInputFromBundle(eBRaw, eIdx)
@@ -144,14 +247,44 @@ func (cts *CTStorage) DedupFuture(ctx context.Context, f tessera.IndexFuture) (r
 		if extractedIdx != idx.Index {
 			return rfc6962.CertificateTimestamp{}, fmt.Errorf("extracted index %d does not match expected index %d", extractedIdx, idx.Index)
 		}
+		if cts.dedupSCTs != nil {
+			cts.dedupSCTs.Add(idx.Index, sct)
+		}
 		return sct, nil
 	})
 }
 
+// readDedupEntryBundle reads the entry bundle at index eBIdx, of width p, from
+// the bundle cache if it's a full bundle.
+func (cts *CTStorage) readDedupEntryBundle(ctx context.Context, eBIdx uint64, p uint8) ([]byte, error) {
+	cache := cts.dedupBundles != nil && p == 0
+	if cache {
+		eBRaw, ok := cts.dedupBundles.Get(eBIdx)
+		recordDedupCacheLookup(ctx, dedupCacheBundle, ok)
+		if ok {
+			return eBRaw, nil
+		}
+	}
+	eBRaw, err := cts.reader.ReadEntryBundle(ctx, eBIdx, p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("leaf bundle at index %d not found: %v", eBIdx, err)
+		}
+		return nil, fmt.Errorf("failed to fetch entry bundle at index %d: %v", eBIdx, err)
+	}
+	if cache {
+		cts.dedupBundles.Add(eBIdx, eBRaw)
+	}
+	return eBRaw, nil
+}
+
 // Add stores CT entries.
 func (cts *CTStorage) Add(ctx context.Context, entry *ctonly.Entry) (tessera.IndexFuture, error) {
 	return trace1(ctx, "tesseract.storage.Add", func(ctx context.Context) (tessera.IndexFuture, error) {
 		future := cts.storeData(ctx, entry)
+		if cts.mergeDelay != nil {
+			future = cts.mergeDelay.track(ctx, future, time.UnixMilli(int64(entry.Timestamp)))
+		}
 
 		if cts.enablePubAwaiter {
 			_, _, err := cts.awaiter.Await(ctx, future)
@@ -166,7 +299,9 @@ func (cts *CTStorage) Add(ctx context.Context, entry *ctonly.Entry) (tessera.Ind
 
 // AddIssuerChain stores every chain certificate under its sha256.
 //
-// If an object is already stored under this hash, continues.
+// If an object is already stored under this hash, continues. With an
+// IssuerQueue, certificates which aren't known to be stored are queued
+// instead, and written asynchronously.
 func (cts *CTStorage) AddIssuerChain(ctx context.Context, chain []*x509.Certificate) error {
 	return traceErr(ctx, "tesseract.storage.AddIssuerChain", func(ctx context.Context) error {
 
@@ -176,44 +311,21 @@ func (cts *CTStorage) AddIssuerChain(ctx context.Context, chain []*x509.Certific
 			key := []byte(hex.EncodeToString(id[:]))
 			kvs = append(kvs, KV{K: key, V: c.Raw})
 		}
-		if err := cts.storeIssuers(ctx, kvs); err != nil {
-			return fmt.Errorf("error storing intermediates: %v", err)
-		}
-		return nil
-	})
-}
-
-// cachedStoreIssuers returns a caching wrapper for an IssuerStorage
-//
-// This is intended to make querying faster. It does not keep a copy of the certs, only sha256.
-// Only up to maxCachedIssuerKeys keys will be stored locally.
-func cachedStoreIssuers(s IssuerStorage) func(context.Context, []KV) error {
-	var mu sync.RWMutex
-	m := make(map[string]struct{})
-	return func(ctx context.Context, kv []KV) error {
-		req := []KV{}
-		for _, kv := range kv {
-			mu.RLock()
-			_, ok := m[string(kv.K)]
-			mu.RUnlock()
-			if ok {
-				logger.DebugExtraContext(ctx, "cachedStoreIssuers wrapper: found in local key cache", slog.String("key", string(kv.K)))
-				continue
-			}
-			req = append(req, kv)
-		}
-		if err := s.AddIfNotExist(ctx, req); err != nil {
-			return fmt.Errorf("issuerStorage.AddIfNotExist(): error storing issuer data in the underlying IssuerStorage: %v", err)
-		}
-		for _, kv := range req {
-			if len(m) >= maxCachedIssuerKeys {
-				logger.DebugExtraContext(ctx, "cachedStoreIssuers wrapper: local issuer cache full, will stop caching issuers.")
+		kvs = cts.issuers.missing(ctx, kvs)
+		if cts.issuerQueue != nil {
+			err := cts.issuerQueue.Enqueue(ctx, kvs)
+			if err == nil {
+				// Queued issuers are written before any checkpoint covering
+				// the chain is published, so they needn't be queued again.
+				cts.issuers.add(kvs)
 				return nil
 			}
-			mu.Lock()
-			m[string(kv.K)] = struct{}{}
-			mu.Unlock()
+			// Fall back to writing the issuers synchronously.
+			slog.WarnContext(ctx, "Failed to queue issuers", slog.Any("error", err))
+		}
+		if err := cts.issuers.write(ctx, kvs); err != nil {
+			return fmt.Errorf("error storing intermediates: %v", err)
 		}
 		return nil
-	}
+	})
 }
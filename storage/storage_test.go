@@ -0,0 +1,130 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tessera/ctonly"
+)
+
+// countingReader serves the entry bundles of a log of a given size, and counts
+// how many times each bundle is read.
+type countingReader struct {
+	tessera.LogReader
+	size    uint64
+	mu      sync.Mutex
+	bundles map[string]int
+}
+
+func (r *countingReader) ReadCheckpoint(_ context.Context) ([]byte, error) {
+	return fmt.Appendf(nil, "example.com/log\n%d\nAAAA\n", r.size), nil
+}
+
+func (r *countingReader) ReadEntryBundle(_ context.Context, index uint64, p uint8) ([]byte, error) {
+	r.mu.Lock()
+	r.bundles[layout.EntriesPath(index, p)]++
+	r.mu.Unlock()
+	var b []byte
+	first := index * layout.EntryBundleWidth
+	for i := first; i < r.size && i < first+layout.EntryBundleWidth; i++ {
+		e := ctonly.Entry{Timestamp: 1000 + i, Certificate: fmt.Appendf(nil, "cert %d", i)}
+		b = append(b, e.LeafData(i)...)
+	}
+	return b, nil
+}
+
+func TestDedupFutureCache(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		sctCacheSize    int
+		bundleCacheSize int
+		wantReads       map[string]int
+	}{
+		{
+			name:      "no cache",
+			wantReads: map[string]int{"tile/entries/000": 4, "tile/entries/001.p/44": 2},
+		},
+		{
+			name:         "sct cache",
+			sctCacheSize: 10,
+			wantReads:    map[string]int{"tile/entries/000": 2, "tile/entries/001.p/44": 1},
+		},
+		{
+			// Partial bundles are never cached.
+			name:            "bundle cache",
+			bundleCacheSize: 10,
+			wantReads:       map[string]int{"tile/entries/000": 1, "tile/entries/001.p/44": 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &countingReader{size: 300, bundles: map[string]int{}}
+			cts, err := NewCTStorage(t.Context(), &CTStorageOptions{
+				Reader:               r,
+				AwaiterPollInterval:  time.Millisecond,
+				DedupSCTCacheSize:    tc.sctCacheSize,
+				DedupBundleCacheSize: tc.bundleCacheSize,
+			})
+			if err != nil {
+				t.Fatalf("NewCTStorage(): %v", err)
+			}
+			for _, idx := range []uint64{1, 2, 1, 2, 260, 260} {
+				f := func() (tessera.Index, error) { return tessera.Index{Index: idx, IsDup: true}, nil }
+				sct, err := cts.DedupFuture(t.Context(), f)
+				if err != nil {
+					t.Fatalf("DedupFuture(%d): %v", idx, err)
+				}
+				if want := 1000 + idx; sct.Timestamp != want {
+					t.Errorf("DedupFuture(%d).Timestamp=%d, want %d", idx, sct.Timestamp, want)
+				}
+			}
+			if fmt.Sprint(r.bundles) != fmt.Sprint(tc.wantReads) {
+				t.Errorf("entry bundle reads=%v, want %v", r.bundles, tc.wantReads)
+			}
+		})
+	}
+}
+
+func TestNewCTStorageCheckpointArchiveInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		archiveInterval    time.Duration
+		checkpointInterval time.Duration
+		wantErr            bool
+	}{
+		{name: "default", checkpointInterval: time.Second},
+		{name: "shorter", archiveInterval: time.Millisecond, checkpointInterval: time.Second},
+		{name: "longer", archiveInterval: 2 * time.Second, checkpointInterval: time.Second, wantErr: true},
+		{name: "no checkpoint interval", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCTStorage(t.Context(), &CTStorageOptions{
+				Reader:                    &countingReader{bundles: map[string]int{}},
+				CheckpointArchive:         memArchive{},
+				CheckpointArchiveInterval: tc.archiveInterval,
+				CheckpointInterval:        tc.checkpointInterval,
+			})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewCTStorage()=%v, want err %t", err, tc.wantErr)
+			}
+		})
+	}
+}
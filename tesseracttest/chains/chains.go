@@ -0,0 +1,457 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chains builds certificate chains to test CT logs with.
+//
+// A Factory creates test roots, intermediates and pre-issuers, and issues
+// certificate chains, precertificate chains, final certificate chains with
+// embedded SCTs, and chains with deliberate defects. Certificates are valid
+// relative to the factory's clock, rather than for fixed dates.
+//
+// DO NOT use this package to issue certificates outside of tests.
+package chains
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/tls"
+)
+
+const (
+	// Organization is the organization of all certificates issued by a
+	// Factory.
+	Organization = "TesseraCT Test"
+	// DNSName is the default DNS name of leaf certificates.
+	DNSName = "test.tesseract.example.com"
+)
+
+var (
+	// RejectedExtensionOID identifies the extension added to leaf
+	// certificates of RejectedExtension chains. It's under the 2.999 arc,
+	// reserved for examples.
+	RejectedExtensionOID = asn1.ObjectIdentifier{2, 999, 1}
+
+	// From RFC6962 Section 3.3. To embed SCTs in final certificates.
+	oidExtensionSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+	asn1Null = []byte{0x05, 0x00}
+)
+
+// Defect is a deliberate defect of a certificate chain, see BrokenChain.
+type Defect int
+
+const (
+	// WrongOrder chains have their issuers in reverse order, from the root to
+	// the leaf's issuer. TesseraCT rejects them.
+	WrongOrder Defect = iota
+	// MissingIssuer chains don't include the issuer of the leaf. TesseraCT
+	// rejects them.
+	MissingIssuer
+	// SHA1Signature chains have a leaf signed with SHA-1, by an RSA
+	// intermediate. TesseraCT rejects them unless AcceptSHA1 is set.
+	SHA1Signature
+	// Expired chains have a leaf which expired a day ago. TesseraCT accepts
+	// them unless RejectExpired is set.
+	Expired
+	// RejectedExtension chains have a leaf with a RejectedExtensionOID
+	// extension. TesseraCT accepts them unless RejectExtensions lists
+	// RejectedExtensionOID.
+	RejectedExtension
+)
+
+// String returns the name of d.
+func (d Defect) String() string {
+	switch d {
+	case WrongOrder:
+		return "WrongOrder"
+	case MissingIssuer:
+		return "MissingIssuer"
+	case SHA1Signature:
+		return "SHA1Signature"
+	case Expired:
+		return "Expired"
+	case RejectedExtension:
+		return "RejectedExtension"
+	default:
+		return fmt.Sprintf("Defect(%d)", int(d))
+	}
+}
+
+// Issuer is a CA certificate, along with its private key.
+type Issuer struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+	// Parent is the issuer of Cert, nil for roots.
+	Parent *Issuer
+}
+
+// PreIssuer returns whether i is a Precertificate Signing Certificate, as
+// defined in RFC6962 Section 3.1.
+func (i *Issuer) PreIssuer() bool {
+	return slices.ContainsFunc(i.Cert.UnknownExtKeyUsage, rfc6962.OIDExtKeyUsageCertificateTransparency.Equal)
+}
+
+// chain returns i's certificate, followed by its parents' up to the root.
+func (i *Issuer) chain() []*x509.Certificate {
+	var chain []*x509.Certificate
+	for ; i != nil; i = i.Parent {
+		chain = append(chain, i.Cert)
+	}
+	return chain
+}
+
+// Option modifies a certificate before it is issued.
+type Option func(*template)
+
+// template is a certificate to issue, along with its subject's key.
+type template struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// WithValidity sets the validity period of the certificate.
+func WithValidity(notBefore, notAfter time.Time) Option {
+	return func(t *template) {
+		t.cert.NotBefore, t.cert.NotAfter = notBefore, notAfter
+	}
+}
+
+// WithSubject sets the subject of the certificate.
+func WithSubject(subject pkix.Name) Option {
+	return func(t *template) {
+		t.cert.Subject = subject
+	}
+}
+
+// WithDNSNames sets the DNS names of the certificate.
+func WithDNSNames(names ...string) Option {
+	return func(t *template) {
+		t.cert.DNSNames = names
+	}
+}
+
+// WithExtension adds an extension to the certificate.
+func WithExtension(ext pkix.Extension) Option {
+	return func(t *template) {
+		t.cert.ExtraExtensions = append(t.cert.ExtraExtensions, ext)
+	}
+}
+
+// WithSignatureAlgorithm sets the algorithm the issuer signs the certificate
+// with. It must match the type of the issuer's key.
+func WithSignatureAlgorithm(alg x509.SignatureAlgorithm) Option {
+	return func(t *template) {
+		t.cert.SignatureAlgorithm = alg
+	}
+}
+
+// WithKey sets the subject key of the certificate. Issuers default to a new
+// ECDSA P-256 key, and leaves to a key shared by all leaves of a Factory.
+func WithKey(key crypto.Signer) Option {
+	return func(t *template) {
+		t.key = key
+	}
+}
+
+// Factory issues test certificates.
+//
+// A Factory is safe for concurrent use.
+type Factory struct {
+	now     func() time.Time
+	leafKey crypto.Signer
+	serial  atomic.Int64
+}
+
+// NewFactory returns a Factory issuing certificates valid relative to now. If
+// now is nil, time.Now is used.
+func NewFactory(now func() time.Time) (*Factory, error) {
+	if now == nil {
+		now = time.Now
+	}
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate leaf key: %v", err)
+	}
+	return &Factory{now: now, leafKey: leafKey}, nil
+}
+
+// Root returns a new self-signed root CA, valid from a day ago for 10 years.
+func (f *Factory) Root(opts ...Option) (*Issuer, error) {
+	now := f.time()
+	t := f.template(opts, x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{Organization}, CommonName: Organization + " Root CA"},
+		NotBefore:             now.AddDate(0, 0, -1),
+		NotAfter:              now.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	})
+	if t.key == nil {
+		var err error
+		if t.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, fmt.Errorf("failed to generate root key: %v", err)
+		}
+	}
+	cert, err := issue(t, &Issuer{Cert: t.cert, Key: t.key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root certificate: %v", err)
+	}
+	return &Issuer{Cert: cert, Key: t.key}, nil
+}
+
+// Intermediate returns a new intermediate CA issued by parent, valid from a
+// day ago for 5 years.
+func (f *Factory) Intermediate(parent *Issuer, opts ...Option) (*Issuer, error) {
+	return f.intermediate(parent, "Intermediate CA", nil, opts)
+}
+
+// PreIssuer returns a new Precertificate Signing Certificate issued by parent,
+// valid from a day ago for 5 years.
+//
+// Final certificates matching precertificates issued by a pre-issuer are
+// issued by its parent, see FinalChain.
+func (f *Factory) PreIssuer(parent *Issuer, opts ...Option) (*Issuer, error) {
+	return f.intermediate(parent, "Precertificate Signing CA", []asn1.ObjectIdentifier{rfc6962.OIDExtKeyUsageCertificateTransparency}, opts)
+}
+
+func (f *Factory) intermediate(parent *Issuer, name string, ekus []asn1.ObjectIdentifier, opts []Option) (*Issuer, error) {
+	now := f.time()
+	t := f.template(opts, x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{Organization}, CommonName: Organization + " " + name},
+		NotBefore:             now.AddDate(0, 0, -1),
+		NotAfter:              now.AddDate(5, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		UnknownExtKeyUsage:    ekus,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	})
+	if t.key == nil {
+		var err error
+		if t.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, fmt.Errorf("failed to generate intermediate key: %v", err)
+		}
+	}
+	cert, err := issue(t, parent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create intermediate certificate: %v", err)
+	}
+	return &Issuer{Cert: cert, Key: t.key, Parent: parent}, nil
+}
+
+// Chain issues a new leaf certificate from issuer, valid from an hour ago for
+// 90 days, and returns it followed by issuer and its parents up to the root.
+func (f *Factory) Chain(issuer *Issuer, opts ...Option) ([]*x509.Certificate, error) {
+	return f.leafChain(issuer, false, opts)
+}
+
+// PrecertChain issues a new precertificate from issuer, valid from an hour ago
+// for 90 days, and returns it followed by issuer and its parents up to the
+// root.
+func (f *Factory) PrecertChain(issuer *Issuer, opts ...Option) ([]*x509.Certificate, error) {
+	return f.leafChain(issuer, true, opts)
+}
+
+func (f *Factory) leafChain(issuer *Issuer, precert bool, opts []Option) ([]*x509.Certificate, error) {
+	now := f.time()
+	base := x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{Organization}, CommonName: DNSName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(0, 0, 90),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{DNSName},
+	}
+	if precert {
+		base.ExtraExtensions = []pkix.Extension{{Id: rfc6962.OIDExtensionCTPoison, Critical: true, Value: asn1Null}}
+	}
+	t := f.template(opts, base)
+	if t.key == nil {
+		t.key = f.leafKey
+	}
+	cert, err := issue(t, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create leaf certificate: %v", err)
+	}
+	return append([]*x509.Certificate{cert}, issuer.chain()...), nil
+}
+
+// FinalChain issues the final certificate matching precert, with scts embedded
+// in an SCT list extension, and returns it followed by its issuer and their
+// parents up to the root.
+//
+// issuer is the issuer of precert. If it is a pre-issuer, the final
+// certificate is issued by its parent.
+func (f *Factory) FinalChain(issuer *Issuer, precert *x509.Certificate, scts ...*client.SCT) ([]*x509.Certificate, error) {
+	if issuer.PreIssuer() {
+		if issuer.Parent == nil {
+			return nil, errors.New("pre-issuer has no parent")
+		}
+		issuer = issuer.Parent
+	}
+	sctList, err := sctListExtension(scts)
+	if err != nil {
+		return nil, err
+	}
+	cert := &x509.Certificate{
+		SerialNumber:          precert.SerialNumber,
+		Subject:               precert.Subject,
+		NotBefore:             precert.NotBefore,
+		NotAfter:              precert.NotAfter,
+		KeyUsage:              precert.KeyUsage,
+		ExtKeyUsage:           precert.ExtKeyUsage,
+		UnknownExtKeyUsage:    precert.UnknownExtKeyUsage,
+		BasicConstraintsValid: precert.BasicConstraintsValid,
+		IsCA:                  precert.IsCA,
+		DNSNames:              precert.DNSNames,
+		SignatureAlgorithm:    precert.SignatureAlgorithm,
+	}
+	// Keep the precertificate's extensions, apart from the poison, and the
+	// authority key ID which is generated for the final issuer.
+	for _, ext := range precert.Extensions {
+		if ext.Id.Equal(rfc6962.OIDExtensionCTPoison) || ext.Id.Equal(rfc6962.OIDExtAuthorityKeyId) {
+			continue
+		}
+		cert.ExtraExtensions = append(cert.ExtraExtensions, ext)
+	}
+	cert.ExtraExtensions = append(cert.ExtraExtensions, sctList)
+	der, err := x509.CreateCertificate(rand.Reader, cert, issuer.Cert, precert.PublicKey, issuer.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create final certificate: %v", err)
+	}
+	final, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse final certificate: %v", err)
+	}
+	return append([]*x509.Certificate{final}, issuer.chain()...), nil
+}
+
+// BrokenChain issues a new certificate chain with defect d from issuer.
+//
+// WrongOrder and MissingIssuer chains need issuer to have a parent.
+// SHA1Signature chains are issued from a new RSA intermediate, issued by
+// issuer.
+func (f *Factory) BrokenChain(issuer *Issuer, d Defect) ([]*x509.Certificate, error) {
+	switch d {
+	case WrongOrder, MissingIssuer:
+		if issuer.Parent == nil {
+			return nil, fmt.Errorf("%v chains need an issuer with a parent", d)
+		}
+		chain, err := f.Chain(issuer)
+		if err != nil {
+			return nil, err
+		}
+		if d == MissingIssuer {
+			return slices.Delete(chain, 1, 2), nil
+		}
+		slices.Reverse(chain[1:])
+		return chain, nil
+	case SHA1Signature:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate RSA key: %v", err)
+		}
+		rsaIssuer, err := f.Intermediate(issuer, WithKey(key))
+		if err != nil {
+			return nil, err
+		}
+		return f.Chain(rsaIssuer, WithSignatureAlgorithm(x509.SHA1WithRSA))
+	case Expired:
+		now := f.time()
+		return f.Chain(issuer, WithValidity(now.AddDate(0, 0, -91), now.AddDate(0, 0, -1)))
+	case RejectedExtension:
+		return f.Chain(issuer, WithExtension(pkix.Extension{Id: RejectedExtensionOID, Value: asn1Null}))
+	default:
+		return nil, fmt.Errorf("unknown defect %v", d)
+	}
+}
+
+// time returns the current time of the factory's clock, truncated to the
+// second since certificates can't be more precise.
+func (f *Factory) time() time.Time {
+	return f.now().Truncate(time.Second)
+}
+
+// template returns base with a new serial number, modified by opts.
+func (f *Factory) template(opts []Option, base x509.Certificate) *template {
+	base.SerialNumber = big.NewInt(f.serial.Add(1))
+	t := &template{cert: &base}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// issue issues t's certificate from issuer.
+func issue(t *template, issuer *Issuer) (*x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, t.cert, issuer.Cert, t.key.Public(), issuer.Key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// sctListExtension returns an RFC6962 Section 3.3 SCT list extension holding
+// scts.
+func sctListExtension(scts []*client.SCT) (pkix.Extension, error) {
+	var list []byte
+	for i, resp := range scts {
+		sct := rfc6962.SignedCertificateTimestamp{
+			SCTVersion: rfc6962.Version(resp.Version),
+			Timestamp:  resp.Timestamp,
+		}
+		if len(resp.ID) != len(sct.LogID.KeyID) {
+			return pkix.Extension{}, fmt.Errorf("SCT %d: invalid log ID length %d", i, len(resp.ID))
+		}
+		copy(sct.LogID.KeyID[:], resp.ID)
+		ext, err := base64.StdEncoding.DecodeString(resp.Extensions)
+		if err != nil {
+			return pkix.Extension{}, fmt.Errorf("SCT %d: failed to decode extensions: %v", i, err)
+		}
+		sct.Extensions = ext
+		if rest, err := tls.Unmarshal(resp.Signature, &sct.Signature); err != nil {
+			return pkix.Extension{}, fmt.Errorf("SCT %d: failed to parse signature: %v", i, err)
+		} else if len(rest) > 0 {
+			return pkix.Extension{}, fmt.Errorf("SCT %d: trailing data after signature", i)
+		}
+		b, err := tls.Marshal(sct)
+		if err != nil {
+			return pkix.Extension{}, fmt.Errorf("SCT %d: failed to marshal: %v", i, err)
+		}
+		list = append(list, byte(len(b)>>8), byte(len(b)))
+		list = append(list, b...)
+	}
+	list = append([]byte{byte(len(list) >> 8), byte(len(list))}, list...)
+	value, err := asn1.Marshal(list)
+	if err != nil {
+		return pkix.Extension{}, fmt.Errorf("failed to marshal SCT list: %v", err)
+	}
+	return pkix.Extension{Id: oidExtensionSCTList, Value: value}, nil
+}
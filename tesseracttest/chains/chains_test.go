@@ -0,0 +1,228 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chains_test
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+	"github.com/transparency-dev/tesseract/tesseracttest"
+	"github.com/transparency-dev/tesseract/tesseracttest/chains"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+var oidExtensionSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+func TestPrecertChains(t *testing.T) {
+	l := tesseracttest.NewTestLog(t, tesseracttest.Options{})
+	preIssuer, err := l.Factory.PreIssuer(l.Issuer)
+	if err != nil {
+		t.Fatalf("PreIssuer(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		issuer *chains.Issuer
+	}{
+		{name: "intermediate", issuer: l.Issuer},
+		{name: "pre-issuer", issuer: preIssuer},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			precertChain, err := l.Factory.PrecertChain(tc.issuer)
+			if err != nil {
+				t.Fatalf("PrecertChain(): %v", err)
+			}
+			if isPrecert, err := x509util.IsPrecertificate(precertChain[0]); err != nil || !isPrecert {
+				t.Fatalf("IsPrecertificate(PrecertChain()[0]) = %v, %v, want true, nil", isPrecert, err)
+			}
+			sct := l.MustSubmit(t, precertChain)
+			l.AssertSCTVerifies(t, precertChain, sct)
+
+			finalChain, err := l.Factory.FinalChain(tc.issuer, precertChain[0], sct)
+			if err != nil {
+				t.Fatalf("FinalChain(): %v", err)
+			}
+			final := finalChain[0]
+			if isPrecert, err := x509util.IsPrecertificate(final); err != nil || isPrecert {
+				t.Errorf("IsPrecertificate(FinalChain()[0]) = %v, %v, want false, nil", isPrecert, err)
+			}
+			if got, want := final.SerialNumber, precertChain[0].SerialNumber; got.Cmp(want) != 0 {
+				t.Errorf("final certificate serial number = %v, want %v", got, want)
+			}
+			if got, want := final.Issuer.String(), l.Intermediate.Subject.String(); got != want {
+				t.Errorf("final certificate issuer = %q, want %q", got, want)
+			}
+			if got, want := len(finalChain), 3; got != want {
+				t.Errorf("FinalChain() returned %d certificates, want %d", got, want)
+			}
+			if got, want := embeddedSCTTimestamps(t, final), []uint64{sct.Timestamp}; !slices.Equal(got, want) {
+				t.Errorf("embedded SCT timestamps = %v, want %v", got, want)
+			}
+			// The final certificate is accepted by the log, as a new entry.
+			l.AssertSCTVerifies(t, finalChain, l.MustSubmit(t, finalChain))
+		})
+	}
+}
+
+func TestBrokenChains(t *testing.T) {
+	for _, tc := range []struct {
+		defect chains.Defect
+		// acceptCfg, if set, is a log configuration accepting the chain.
+		acceptCfg *tesseract.ChainValidationConfig
+		// rejectCfg, if set, is a log configuration rejecting the chain.
+		rejectCfg *tesseract.ChainValidationConfig
+	}{
+		{
+			defect:    chains.WrongOrder,
+			rejectCfg: &tesseract.ChainValidationConfig{},
+		},
+		{
+			defect:    chains.MissingIssuer,
+			rejectCfg: &tesseract.ChainValidationConfig{},
+		},
+		{
+			defect:    chains.SHA1Signature,
+			acceptCfg: &tesseract.ChainValidationConfig{AcceptSHA1: true},
+			rejectCfg: &tesseract.ChainValidationConfig{},
+		},
+		{
+			defect:    chains.Expired,
+			acceptCfg: &tesseract.ChainValidationConfig{},
+			rejectCfg: &tesseract.ChainValidationConfig{RejectExpired: true},
+		},
+		{
+			defect:    chains.RejectedExtension,
+			acceptCfg: &tesseract.ChainValidationConfig{},
+			rejectCfg: &tesseract.ChainValidationConfig{RejectExtensions: chains.RejectedExtensionOID.String()},
+		},
+	} {
+		t.Run(tc.defect.String(), func(t *testing.T) {
+			for _, c := range []struct {
+				cfg        *tesseract.ChainValidationConfig
+				wantStatus int
+			}{
+				{cfg: tc.acceptCfg, wantStatus: http.StatusOK},
+				{cfg: tc.rejectCfg, wantStatus: http.StatusBadRequest},
+			} {
+				if c.cfg == nil {
+					continue
+				}
+				l := tesseracttest.NewTestLog(t, tesseracttest.Options{ChainValidation: c.cfg})
+				chain, err := l.Factory.BrokenChain(l.Issuer, tc.defect)
+				if err != nil {
+					t.Fatalf("BrokenChain(): %v", err)
+				}
+				if _, status := l.Submit(t, chain); status != c.wantStatus {
+					t.Errorf("Submit() with %+v: got status %d, want %d", *c.cfg, status, c.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestBrokenChainNeedsParent(t *testing.T) {
+	f, err := chains.NewFactory(nil)
+	if err != nil {
+		t.Fatalf("NewFactory(): %v", err)
+	}
+	root, err := f.Root()
+	if err != nil {
+		t.Fatalf("Root(): %v", err)
+	}
+	for _, d := range []chains.Defect{chains.WrongOrder, chains.MissingIssuer} {
+		if _, err := f.BrokenChain(root, d); err == nil {
+			t.Errorf("BrokenChain(root, %v): got nil error, want error", d)
+		}
+	}
+}
+
+func TestFactoryClock(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err := chains.NewFactory(func() time.Time { return now })
+	if err != nil {
+		t.Fatalf("NewFactory(): %v", err)
+	}
+	root, err := f.Root()
+	if err != nil {
+		t.Fatalf("Root(): %v", err)
+	}
+	chain, err := f.Chain(root, chains.WithDNSNames("a.example.com"))
+	if err != nil {
+		t.Fatalf("Chain(): %v", err)
+	}
+	if len(chain) != 2 || !bytes.Equal(chain[1].Raw, root.Cert.Raw) {
+		t.Fatalf("Chain() didn't return the leaf followed by the root")
+	}
+	leaf := chain[0]
+	if leaf.NotBefore.After(now) || !leaf.NotAfter.After(now) {
+		t.Errorf("leaf is valid from %v to %v, want it valid at %v", leaf.NotBefore, leaf.NotAfter, now)
+	}
+	if got, want := leaf.DNSNames, []string{"a.example.com"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("leaf DNS names = %v, want %v", got, want)
+	}
+	if err := leaf.CheckSignatureFrom(root.Cert); err != nil {
+		t.Errorf("leaf isn't signed by root: %v", err)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: certPool(root.Cert), CurrentTime: now}); err != nil {
+		t.Errorf("leaf.Verify(): %v", err)
+	}
+}
+
+// embeddedSCTTimestamps returns the timestamps of the SCTs embedded in cert.
+func embeddedSCTTimestamps(t *testing.T, cert *x509.Certificate) []uint64 {
+	t.Helper()
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidExtensionSCTList) {
+			continue
+		}
+		var list []byte
+		if rest, err := asn1.Unmarshal(ext.Value, &list); err != nil || len(rest) > 0 {
+			t.Fatalf("Failed to unmarshal SCT list extension: %v", err)
+		}
+		var ts []uint64
+		s := cryptobyte.String(list)
+		var scts cryptobyte.String
+		if !s.ReadUint16LengthPrefixed(&scts) || !s.Empty() {
+			t.Fatal("Failed to parse SCT list")
+		}
+		for !scts.Empty() {
+			var sct cryptobyte.String
+			var version uint8
+			var timestamp uint64
+			if !scts.ReadUint16LengthPrefixed(&sct) || !sct.ReadUint8(&version) || !sct.Skip(32) || !sct.ReadUint64(&timestamp) {
+				t.Fatal("Failed to parse SCT")
+			}
+			ts = append(ts, timestamp)
+		}
+		return ts
+	}
+	t.Fatal("Certificate has no SCT list extension")
+	return nil
+}
+
+func certPool(certs ...*x509.Certificate) *x509.CertPool {
+	p := x509.NewCertPool()
+	for _, c := range certs {
+		p.AddCert(c)
+	}
+	return p
+}
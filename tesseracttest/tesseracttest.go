@@ -0,0 +1,465 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tesseracttest provides a TesseraCT log to write end-to-end tests
+// against.
+//
+// NewTestLog starts a development log from the devlog package in a test
+// directory, serving both its submission and monitoring APIs. The log
+// timestamps SCTs and checkpoints with a fake clock, and tests can inject
+// pushback, wait for entries to be integrated, and check SCTs, entries and
+// inclusion proofs.
+package tesseracttest
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api/layout"
+	"github.com/transparency-dev/tessera/ctonly"
+	"github.com/transparency-dev/tesseract"
+	"github.com/transparency-dev/tesseract/client"
+	"github.com/transparency-dev/tesseract/devlog"
+	ctrfc6962 "github.com/transparency-dev/tesseract/internal/types/rfc6962"
+	"github.com/transparency-dev/tesseract/internal/types/staticct"
+	"github.com/transparency-dev/tesseract/internal/x509util"
+	"github.com/transparency-dev/tesseract/tesseracttest/chains"
+	"golang.org/x/mod/sumdb/note"
+)
+
+const (
+	// DefaultOrigin is the default origin of test logs.
+	DefaultOrigin = "tesseracttest.example.com"
+	// DefaultCheckpointInterval is the default interval between two
+	// checkpoints of test logs.
+	DefaultCheckpointInterval = 50 * time.Millisecond
+
+	// waitTimeout is how long to wait for the log to integrate entries or
+	// publish checkpoints before failing a test.
+	waitTimeout = 30 * time.Second
+	pollPeriod  = 10 * time.Millisecond
+)
+
+// Options configures a test log.
+type Options struct {
+	// Origin is the origin of the log. Defaults to DefaultOrigin.
+	Origin string
+	// Signer is the log key. Defaults to a generated ECDSA P-256 key.
+	Signer crypto.Signer
+	// Roots are trusted by the log, in addition to the root of the log's
+	// test CA.
+	Roots []*x509.Certificate
+	// Now is the initial time of the log's fake clock. Defaults to the
+	// current time.
+	Now time.Time
+	// CheckpointInterval is the interval between two checkpoints. Defaults to
+	// DefaultCheckpointInterval.
+	CheckpointInterval time.Duration
+	// ChainValidation, if set, is used to validate chains. Its RootsPEMFile is
+	// overridden with a file containing Roots and the log's test CA root.
+	ChainValidation *tesseract.ChainValidationConfig
+}
+
+// TestLog is a running test log.
+type TestLog struct {
+	// Origin is the origin of the log.
+	Origin string
+	// PublicKey is the public key of the log.
+	PublicKey crypto.PublicKey
+	// Verifier verifies the log's checkpoints.
+	Verifier note.Verifier
+	// Clock is the fake clock used to timestamp SCTs and checkpoints.
+	Clock *FakeClock
+	// Root is the root of the log's test CA, trusted by the log.
+	Root *x509.Certificate
+	// Intermediate is the intermediate of the log's test CA, issuing the
+	// chains returned by IssueChain and IssuePrecertChain.
+	Intermediate *x509.Certificate
+	// Dir is the directory the log is stored in.
+	Dir string
+
+	// Factory issues certificates valid relative to Clock.
+	Factory *chains.Factory
+	// Issuer is the intermediate of the log's test CA, along with its key, to
+	// issue custom chains from with Factory.
+	Issuer *chains.Issuer
+
+	log      *devlog.Log
+	fetcher  *client.HTTPFetcher
+	pushback atomic.Bool
+}
+
+// NewTestLog starts a test log, which is shut down when the test completes.
+func NewTestLog(t testing.TB, opts Options) *TestLog {
+	t.Helper()
+	l := &TestLog{
+		Origin: opts.Origin,
+		Clock:  NewFakeClock(opts.Now),
+	}
+	if l.Origin == "" {
+		l.Origin = DefaultOrigin
+	}
+	if opts.Now.IsZero() {
+		l.Clock.Set(time.Now())
+	}
+	checkpointInterval := opts.CheckpointInterval
+	if checkpointInterval <= 0 {
+		checkpointInterval = DefaultCheckpointInterval
+	}
+	dl, err := devlog.Start(t.Context(), devlog.Options{
+		Dir:                         t.TempDir(),
+		Origin:                      l.Origin,
+		CheckpointInterval:          checkpointInterval,
+		CheckpointRepublishInterval: checkpointInterval,
+		Signer:                      opts.Signer,
+		TimeSource:                  l.Clock,
+		Roots:                       opts.Roots,
+		ChainValidation:             opts.ChainValidation,
+		WrapAdd: func(add tessera.AddFn) tessera.AddFn {
+			return func(ctx context.Context, e *tessera.Entry) tessera.IndexFuture {
+				if l.pushback.Load() {
+					return func() (tessera.Index, error) { return tessera.Index{}, tessera.ErrPushback }
+				}
+				return add(ctx, e)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to start log: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := dl.Close(); err != nil {
+			t.Errorf("Failed to close log: %v", err)
+		}
+	})
+	l.log = dl
+	l.PublicKey, l.Verifier = dl.PublicKey, dl.Verifier
+	l.Root, l.Intermediate, l.Dir = dl.Root, dl.Intermediate, dl.Dir
+	l.Factory, l.Issuer = dl.Factory, dl.Issuer
+
+	u, err := url.Parse(l.URL())
+	if err != nil {
+		t.Fatalf("Failed to parse log URL: %v", err)
+	}
+	l.fetcher, err = client.NewHTTPFetcher(u, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("Failed to create fetcher: %v", err)
+	}
+	return l
+}
+
+// URL returns the log's submission and monitoring prefix, with a trailing
+// slash.
+func (l *TestLog) URL() string {
+	return l.log.URL
+}
+
+// IssueChain returns a new certificate chain accepted by the log, from the
+// leaf to the root.
+func (l *TestLog) IssueChain(t testing.TB) []*x509.Certificate {
+	t.Helper()
+	return l.issueChain(t, false)
+}
+
+// IssuePrecertChain returns a new precertificate chain accepted by the log,
+// from the precertificate to the root.
+func (l *TestLog) IssuePrecertChain(t testing.TB) []*x509.Certificate {
+	t.Helper()
+	return l.issueChain(t, true)
+}
+
+func (l *TestLog) issueChain(t testing.TB, preCert bool) []*x509.Certificate {
+	t.Helper()
+	issue := l.Factory.Chain
+	if preCert {
+		issue = l.Factory.PrecertChain
+	}
+	chain, err := issue(l.Issuer)
+	if err != nil {
+		t.Fatalf("Failed to issue chain: %v", err)
+	}
+	return chain
+}
+
+// Submit submits chain to add-pre-chain if its first certificate is a
+// precertificate, or add-chain otherwise.
+//
+// It returns the HTTP status code of the response, and the SCT if the status
+// is 200.
+func (l *TestLog) Submit(t testing.TB, chain []*x509.Certificate) (*client.SCT, int) {
+	t.Helper()
+	isPrecert, err := x509util.IsPrecertificate(chain[0])
+	if err != nil {
+		t.Fatalf("Failed to check whether the leaf is a precertificate: %v", err)
+	}
+	p := ctrfc6962.AddChainPath
+	if isPrecert {
+		p = ctrfc6962.AddPreChainPath
+	}
+	req := ctrfc6962.AddChainRequest{}
+	for _, c := range chain {
+		req.Chain = append(req.Chain, c.Raw)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	resp, err := http.Post(l.URL()+strings.TrimPrefix(p, "/"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to post to %s: %v", p, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+	sct := &client.SCT{}
+	if err := json.NewDecoder(resp.Body).Decode(sct); err != nil {
+		t.Fatalf("Failed to decode %s response: %v", p, err)
+	}
+	return sct, resp.StatusCode
+}
+
+// MustSubmit submits chain like Submit, and fails the test if the log doesn't
+// return an SCT.
+func (l *TestLog) MustSubmit(t testing.TB, chain []*x509.Certificate) *client.SCT {
+	t.Helper()
+	sct, status := l.Submit(t, chain)
+	if status != http.StatusOK {
+		t.Fatalf("Submit(): got status %d, want %d", status, http.StatusOK)
+	}
+	return sct
+}
+
+// SetPushback sets whether the log pushes back on new entries. When it does,
+// add-chain and add-pre-chain requests for entries which are not already in
+// the log fail with a 429 status.
+func (l *TestLog) SetPushback(pushback bool) {
+	l.pushback.Store(pushback)
+}
+
+// Checkpoint fetches and verifies the latest checkpoint of the log.
+func (l *TestLog) Checkpoint(t testing.TB) *log.Checkpoint {
+	t.Helper()
+	cp, _, err := l.checkpoint(t.Context())
+	if err != nil {
+		t.Fatalf("Failed to get checkpoint: %v", err)
+	}
+	return cp
+}
+
+// checkpoint fetches and verifies the latest checkpoint, and returns it along
+// with its timestamp.
+func (l *TestLog) checkpoint(ctx context.Context) (*log.Checkpoint, time.Time, error) {
+	raw, err := l.fetcher.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	cp, _, n, err := log.ParseCheckpoint(raw, l.Origin, l.Verifier)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid checkpoint: %v", err)
+	}
+	// An RFC6962NoteSignature starts with a key hash and a timestamp.
+	sig, err := base64.StdEncoding.DecodeString(n.Sigs[0].Base64)
+	if err != nil || len(sig) < 12 {
+		return nil, time.Time{}, fmt.Errorf("invalid checkpoint signature: %v", err)
+	}
+	return cp, time.UnixMilli(int64(binary.BigEndian.Uint64(sig[4:12]))), nil
+}
+
+// Integrate waits for all the entries added to the log so far to be
+// integrated, and returns the first checkpoint that covers them.
+func (l *TestLog) Integrate(t testing.TB) *log.Checkpoint {
+	t.Helper()
+	next, err := l.log.Reader.NextIndex(t.Context())
+	if err != nil {
+		t.Fatalf("Failed to get next index: %v", err)
+	}
+	return l.awaitCheckpoint(t, func(cp *log.Checkpoint, _ time.Time) bool {
+		return cp.Size >= next
+	})
+}
+
+// AdvanceCheckpoint advances the fake clock by d, and waits for the log to
+// publish a checkpoint at the new time.
+func (l *TestLog) AdvanceCheckpoint(t testing.TB, d time.Duration) *log.Checkpoint {
+	t.Helper()
+	now := l.Clock.Advance(d).Truncate(time.Millisecond)
+	return l.awaitCheckpoint(t, func(_ *log.Checkpoint, ts time.Time) bool {
+		return !ts.Before(now)
+	})
+}
+
+// awaitCheckpoint polls the log's checkpoint until done returns true.
+func (l *TestLog) awaitCheckpoint(t testing.TB, done func(*log.Checkpoint, time.Time) bool) *log.Checkpoint {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), waitTimeout)
+	defer cancel()
+	for {
+		cp, ts, err := l.checkpoint(ctx)
+		if err == nil && done(cp, ts) {
+			return cp
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Failed to get checkpoint: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Timed out waiting for checkpoint: %v", ctx.Err())
+		case <-time.After(pollPeriod):
+		}
+	}
+}
+
+// AssertSCTVerifies checks that sct is a valid SCT from the log for chain, and
+// returns the leaf index it carries.
+func (l *TestLog) AssertSCTVerifies(t testing.TB, chain []*x509.Certificate, sct *client.SCT) uint64 {
+	t.Helper()
+	idx, err := client.VerifySCT(l.PublicKey, chain, sct)
+	if err != nil {
+		t.Fatalf("SCT doesn't verify: %v", err)
+	}
+	return idx
+}
+
+// AssertEntryAt checks that the entry at index in the log is for chain.
+//
+// The entry must be covered by the latest checkpoint, see Integrate.
+func (l *TestLog) AssertEntryAt(t testing.TB, index uint64, chain []*x509.Certificate) {
+	t.Helper()
+	cp := l.Checkpoint(t)
+	if index >= cp.Size {
+		t.Fatalf("Entry %d is not covered by checkpoint of size %d", index, cp.Size)
+	}
+	bIdx := index / layout.EntryBundleWidth
+	raw, err := l.fetcher.ReadEntryBundle(t.Context(), bIdx, layout.PartialTileSize(0, bIdx, cp.Size))
+	if err != nil {
+		t.Fatalf("Failed to read entry bundle %d: %v", bIdx, err)
+	}
+	var eb staticct.EntryBundle
+	if err := eb.UnmarshalText(raw); err != nil {
+		t.Fatalf("Failed to parse entry bundle %d: %v", bIdx, err)
+	}
+	var got staticct.Entry
+	if err := got.UnmarshalText(eb.Entries[index%layout.EntryBundleWidth]); err != nil {
+		t.Fatalf("Failed to parse entry %d: %v", index, err)
+	}
+	want := l.entry(t, chain, got.Timestamp)
+	if got.IsPrecert != want.IsPrecert ||
+		!bytes.Equal(got.Certificate, want.Certificate) ||
+		!bytes.Equal(got.Precertificate, want.Precertificate) ||
+		!bytes.Equal(got.IssuerKeyHash, want.IssuerKeyHash) {
+		t.Errorf("Entry %d doesn't match chain", index)
+	}
+	if len(got.FingerprintsChain) != len(want.FingerprintsChain) {
+		t.Errorf("Entry %d has %d issuer fingerprints, want %d", index, len(got.FingerprintsChain), len(want.FingerprintsChain))
+		return
+	}
+	for i := range got.FingerprintsChain {
+		if got.FingerprintsChain[i] != want.FingerprintsChain[i] {
+			t.Errorf("Entry %d issuer fingerprint %d = %x, want %x", index, i, got.FingerprintsChain[i], want.FingerprintsChain[i])
+		}
+	}
+}
+
+// AssertInclusion checks that the entry for chain and sct is included in the
+// log, under the latest checkpoint, with a valid inclusion proof.
+//
+// The entry must be covered by the latest checkpoint, see Integrate.
+func (l *TestLog) AssertInclusion(t testing.TB, chain []*x509.Certificate, sct *client.SCT) {
+	t.Helper()
+	index := l.AssertSCTVerifies(t, chain, sct)
+	cp := l.Checkpoint(t)
+	if index >= cp.Size {
+		t.Fatalf("Entry %d is not covered by checkpoint of size %d", index, cp.Size)
+	}
+	e := l.entry(t, chain, sct.Timestamp)
+	leafHash := rfc6962.DefaultHasher.HashLeaf(e.MerkleTreeLeaf(index))
+	pb, err := client.NewProofBuilder(t.Context(), *cp, l.fetcher.ReadTile)
+	if err != nil {
+		t.Fatalf("Failed to create proof builder: %v", err)
+	}
+	p, err := pb.InclusionProof(t.Context(), index)
+	if err != nil {
+		t.Fatalf("Failed to build inclusion proof for entry %d: %v", index, err)
+	}
+	if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, cp.Size, leafHash, p, cp.Hash); err != nil {
+		t.Errorf("Inclusion proof for entry %d doesn't verify: %v", index, err)
+	}
+}
+
+// entry returns the log entry for chain, at the given timestamp.
+func (l *TestLog) entry(t testing.TB, chain []*x509.Certificate, timestamp uint64) *ctonly.Entry {
+	t.Helper()
+	isPrecert, err := x509util.IsPrecertificate(chain[0])
+	if err != nil {
+		t.Fatalf("Failed to check whether the leaf is a precertificate: %v", err)
+	}
+	e, err := x509util.EntryFromChain(chain, isPrecert, timestamp)
+	if err != nil {
+		t.Fatalf("Failed to build entry from chain: %v", err)
+	}
+	return e
+}
+
+// FakeClock is a clock which only moves when told to.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set sets the time of the clock.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d, and returns the new time.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
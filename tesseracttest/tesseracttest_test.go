@@ -0,0 +1,107 @@
+// Copyright 2026 The Tessera authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tesseracttest
+
+import (
+	"crypto/x509"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var fakeTimeStart = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTestLog(t *testing.T) {
+	l := NewTestLog(t, Options{Now: fakeTimeStart})
+
+	for i, tc := range []struct {
+		name  string
+		issue func(testing.TB) []*x509.Certificate
+	}{
+		{name: "chain", issue: l.IssueChain},
+		{name: "precert", issue: l.IssuePrecertChain},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l.Clock.Advance(time.Minute)
+			chain := tc.issue(t)
+			sct := l.MustSubmit(t, chain)
+			if got, want := sct.Timestamp, uint64(l.Clock.Now().UnixMilli()); got != want {
+				t.Errorf("SCT timestamp = %d, want %d", got, want)
+			}
+			idx := l.AssertSCTVerifies(t, chain, sct)
+			if idx != uint64(i) {
+				t.Errorf("SCT index = %d, want %d", idx, i)
+			}
+			if cp := l.Integrate(t); cp.Size != uint64(i+1) {
+				t.Errorf("Integrate() returned checkpoint of size %d, want %d", cp.Size, i+1)
+			}
+			l.AssertEntryAt(t, idx, chain)
+			l.AssertInclusion(t, chain, sct)
+
+			// Duplicate submissions get the same index and timestamp.
+			l.Clock.Advance(time.Minute)
+			dup := l.MustSubmit(t, chain)
+			if got := l.AssertSCTVerifies(t, chain, dup); got != idx {
+				t.Errorf("Duplicate SCT index = %d, want %d", got, idx)
+			}
+			if dup.Timestamp != sct.Timestamp {
+				t.Errorf("Duplicate SCT timestamp = %d, want %d", dup.Timestamp, sct.Timestamp)
+			}
+		})
+	}
+}
+
+func TestTestLogPushback(t *testing.T) {
+	l := NewTestLog(t, Options{})
+	l.SetPushback(true)
+	if _, status := l.Submit(t, l.IssueChain(t)); status != http.StatusTooManyRequests {
+		t.Errorf("Submit() with pushback: got status %d, want %d", status, http.StatusTooManyRequests)
+	}
+	l.SetPushback(false)
+	chain := l.IssueChain(t)
+	sct := l.MustSubmit(t, chain)
+	if idx := l.AssertSCTVerifies(t, chain, sct); idx != 0 {
+		t.Errorf("SCT index = %d, want 0", idx)
+	}
+}
+
+func TestTestLogAdvanceCheckpoint(t *testing.T) {
+	l := NewTestLog(t, Options{Now: fakeTimeStart})
+	l.MustSubmit(t, l.IssueChain(t))
+	before := l.Integrate(t)
+	after := l.AdvanceCheckpoint(t, time.Hour)
+	if after.Size != before.Size {
+		t.Errorf("AdvanceCheckpoint() returned checkpoint of size %d, want %d", after.Size, before.Size)
+	}
+	_, ts, err := l.checkpoint(t.Context())
+	if err != nil {
+		t.Fatalf("checkpoint(): %v", err)
+	}
+	if want := fakeTimeStart.Add(time.Hour); ts.Before(want) {
+		t.Errorf("checkpoint timestamp = %v, want >= %v", ts, want)
+	}
+}
+
+func TestTestLogRejectsUnknownRoot(t *testing.T) {
+	l := NewTestLog(t, Options{})
+	other := NewTestLog(t, Options{})
+	if _, status := l.Submit(t, other.IssueChain(t)); status != http.StatusBadRequest {
+		t.Errorf("Submit() with untrusted root: got status %d, want %d", status, http.StatusBadRequest)
+	}
+	// Unless it's configured as a trusted root.
+	l = NewTestLog(t, Options{Roots: []*x509.Certificate{other.Root}})
+	chain := other.IssueChain(t)
+	l.AssertSCTVerifies(t, chain, l.MustSubmit(t, chain))
+}